@@ -6,7 +6,7 @@
 //
 // Quick Start:
 //
-//	client := aiptx.NewClient("http://localhost:8000", "")
+//	client := aiptx.NewClient("http://localhost:8000")
 //	health, err := client.Health()
 //	if err != nil {
 //	    log.Fatal(err)
@@ -16,11 +16,16 @@ package aiptx
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
@@ -28,11 +33,42 @@ import (
 // Types
 // =============================================================================
 
-// Client represents an AIPTX API client.
+// Client represents an AIPTX API client. A Client is safe for concurrent use
+// by multiple goroutines. Its fields may be set before it is first used;
+// after that, change the server or API key with SetBaseURL and SetAPIKey,
+// and anything else by deriving a new client with With.
+//
+// Clients created as struct literals, such as &aiptx.Client{BaseURL: url},
+// work too, but do not record the scans and sessions they start for
+// Shutdown, and a nil HTTPClient means http.DefaultClient.
 type Client struct {
-	BaseURL    string
-	APIKey     string
-	HTTPClient *http.Client
+	BaseURL      string
+	APIKey       string
+	Organization string
+	HTTPClient   *http.Client
+	Hooks        Hooks
+	RetryPolicy  RetryPolicy
+
+	mu             *sync.RWMutex // guards BaseURL, APIKey and HTTPClient
+	ownsTransport  bool
+	coalescer      *coalescer
+	resolver       *net.Resolver
+	dnsTTL         time.Duration
+	socketPath     string
+	dial           func(ctx context.Context, network, addr string) (net.Conn, error)
+	strictDecoding bool
+	versionCheck   *versionCheck
+	deprecations   *deprecations
+	rateLimit      *rateLimit
+	failover       *failover
+	instance       *instance
+	ownerTag       string
+	userAgent      string
+	readOnly       bool
+	dryRun         *dryRunSupport
+	auditSink      AuditSink
+	logger         *log.Logger
+	testingWindows *testingWindows
 }
 
 // Project represents a penetration testing project.
@@ -42,16 +78,29 @@ type Project struct {
 	Target      string    `json:"target"`
 	Description string    `json:"description,omitempty"`
 	Scope       []string  `json:"scope,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	// CreatedBy is the user or API key that created the project, and
+	// OwnerTag the owner tag of the client that created it. See
+	// WithOwnerTag.
+	CreatedBy string `json:"created_by,omitempty"`
+	OwnerTag  string `json:"owner_tag,omitempty"`
+	// TestingWindow restricts when the project may be scanned. It is nil
+	// if the project may be scanned at any time.
+	TestingWindow *TestingWindow `json:"testing_window,omitempty"`
+
+	unknown *rawFields
 }
 
 // ProjectCreate represents data for creating a new project.
 type ProjectCreate struct {
-	Name        string   `json:"name"`
-	Target      string   `json:"target"`
-	Description string   `json:"description,omitempty"`
-	Scope       []string `json:"scope,omitempty"`
+	Name          string         `json:"name"`
+	Target        string         `json:"target"`
+	Description   string         `json:"description,omitempty"`
+	Scope         []string       `json:"scope,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`
+	TestingWindow *TestingWindow `json:"testing_window,omitempty"`
 }
 
 // Session represents a scan session.
@@ -59,13 +108,19 @@ type Session struct {
 	ID            int64     `json:"id"`
 	ProjectID     int64     `json:"project_id"`
 	Name          string    `json:"name"`
-	Phase         string    `json:"phase"`
-	Status        string    `json:"status"`
+	Phase         Phase     `json:"phase"`
+	Status        ScanState `json:"status"`
 	Iteration     int       `json:"iteration"`
 	MaxIterations int       `json:"max_iterations"`
 	CreatedAt     time.Time `json:"created_at"`
 	StartedAt     time.Time `json:"started_at,omitempty"`
 	CompletedAt   time.Time `json:"completed_at,omitempty"`
+	// UpdatedAt is when the session last made progress.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	OwnerTag  string    `json:"owner_tag,omitempty"`
+
+	unknown *rawFields
 }
 
 // SessionCreate represents data for creating a new session.
@@ -82,35 +137,117 @@ type Finding struct {
 	Type          string                 `json:"type"`
 	Value         string                 `json:"value"`
 	Description   string                 `json:"description,omitempty"`
-	Severity      string                 `json:"severity"`
-	Phase         string                 `json:"phase"`
+	Severity      Severity               `json:"severity"`
+	Phase         Phase                  `json:"phase"`
 	Tool          string                 `json:"tool"`
 	RawOutput     string                 `json:"raw_output,omitempty"`
 	ExtraData     map[string]interface{} `json:"extra_data,omitempty"`
 	Verified      bool                   `json:"verified"`
 	FalsePositive bool                   `json:"false_positive"`
 	DiscoveredAt  time.Time              `json:"discovered_at"`
+
+	// KillChainStage is set by the server once the finding is classified.
+	KillChainStage KillChainStage `json:"kill_chain_stage,omitempty"`
+	// Remediation is nil until the finding is assigned for fixing.
+	Remediation *Remediation `json:"remediation,omitempty"`
+	// MergedInto is the ID of the finding this one was merged into by
+	// MergeFindings, if any.
+	MergedInto int64 `json:"merged_into,omitempty"`
+	// Confidence is the model's confidence in an AI-reported finding, from
+	// 0 to 1. It is zero for findings reported directly by tools.
+	Confidence float64 `json:"confidence,omitempty"`
+	// References link the finding to advisories and other external
+	// material. See AddFindingReference.
+	References []Reference `json:"references,omitempty"`
+
+	unknown *rawFields
+}
+
+// Evidence represents an artifact attached to a finding, such as a packet
+// capture, screenshot or HTTP transcript.
+type Evidence struct {
+	ID          int64     `json:"id"`
+	FindingID   int64     `json:"finding_id"`
+	Kind        string    `json:"kind"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// CollectedBy is the user or tool that collected the artifact, and
+	// CollectedAt when it was collected.
+	CollectedBy string    `json:"collected_by,omitempty"`
+	CollectedAt time.Time `json:"collected_at,omitempty"`
+	// SignedBy identifies the key that signed the artifact, and Signature
+	// is the base64-encoded Ed25519 signature of its SHA-256 digest. See
+	// VerifyEvidenceIntegrity.
+	SignedBy  string `json:"signed_by,omitempty"`
+	Signature string `json:"signature,omitempty"`
+
+	unknown *rawFields
 }
 
 // ScanRequest represents a scan request.
 type ScanRequest struct {
-	Target  string   `json:"target"`
-	Mode    string   `json:"mode,omitempty"`
-	AI      bool     `json:"ai,omitempty"`
-	Exploit bool     `json:"exploit,omitempty"`
-	Phases  []string `json:"phases,omitempty"`
+	ProjectID int64    `json:"project_id,omitempty"`
+	Target    string   `json:"target"`
+	Mode      ScanMode `json:"mode,omitempty"`
+	AI        bool     `json:"ai,omitempty"`
+	Exploit   bool     `json:"exploit,omitempty"`
+	Phases    []Phase  `json:"phases,omitempty"`
+	// PhasePlan controls which phases run concurrently and the tools each
+	// may use. It takes precedence over Phases.
+	PhasePlan *PhasePlan `json:"phase_plan,omitempty"`
+	// Limits caps the resources the scan may use. The server's defaults
+	// apply if it is nil.
+	Limits *ScanLimits `json:"limits,omitempty"`
+	// MaxDuration is how long the scan may run before it is aborted with
+	// status ScanAbortedTimeout. It overrides Limits.MaxDurationSeconds and
+	// is rounded up to whole seconds.
+	MaxDuration time.Duration `json:"-"`
+	// AbortOnPhaseTimeout aborts the scan with status ScanAbortedTimeout
+	// when a phase exceeds its timeout, instead of skipping to the next
+	// phase.
+	AbortOnPhaseTimeout bool `json:"abort_on_phase_timeout,omitempty"`
+	// MockLLM makes the scan use the server's canned LLM responses instead
+	// of calling the LLM provider. See SetServerTestMode.
+	MockLLM bool `json:"mock_llm,omitempty"`
+	// QueueOutsideWindow queues a scan started outside its project's
+	// testing window until the window opens, instead of failing with
+	// ErrOutsideTestingWindow.
+	QueueOutsideWindow bool `json:"-"`
+	// StartAfter holds a queued scan until the given time.
+	StartAfter *time.Time `json:"start_after,omitempty"`
 }
 
 // ScanStatus represents the status of a scan.
 type ScanStatus struct {
 	ID            string    `json:"id"`
-	Status        string    `json:"status"`
-	Phase         string    `json:"phase"`
+	Status        ScanState `json:"status"`
+	Phase         Phase     `json:"phase"`
 	Progress      int       `json:"progress"`
 	FindingsCount int       `json:"findings_count"`
+	CreatedAt     time.Time `json:"created_at,omitempty"`
 	StartedAt     time.Time `json:"started_at,omitempty"`
 	CompletedAt   time.Time `json:"completed_at,omitempty"`
-	Error         string    `json:"error,omitempty"`
+	// UpdatedAt is when the scan last made progress.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Error describes why the scan failed, and ErrorCode classifies the
+	// cause. See ScanStatus.Err.
+	Error     string        `json:"error,omitempty"`
+	ErrorCode ScanErrorCode `json:"error_code,omitempty"`
+	// ErrorTool is the tool that crashed, for ScanErrToolCrashed.
+	ErrorTool string `json:"error_tool,omitempty"`
+	// Limits are the resource limits in effect for the scan.
+	Limits *ScanLimits `json:"limits,omitempty"`
+	// Annotations are the operator notes recorded on the scan, oldest
+	// first.
+	Annotations []ScanAnnotation `json:"annotations,omitempty"`
+	CreatedBy   string           `json:"created_by,omitempty"`
+	OwnerTag    string           `json:"owner_tag,omitempty"`
+
+	unknown *rawFields
 }
 
 // HealthStatus represents the server health status.
@@ -123,87 +260,227 @@ type HealthStatus struct {
 		LLM      bool            `json:"llm"`
 		Scanners map[string]bool `json:"scanners,omitempty"`
 	} `json:"components"`
+
+	unknown *rawFields
 }
 
 // Tool represents an available security tool.
 type Tool struct {
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
-	Phase       string   `json:"phase"`
+	Phase       Phase    `json:"phase"`
 	Keywords    []string `json:"keywords"`
 	Available   bool     `json:"available"`
-}
-
-// APIError represents an API error response.
-type APIError struct {
-	StatusCode int
-	Message    string
-	Response   interface{}
-}
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("AIPTX API error (status %d): %s", e.StatusCode, e.Message)
+	unknown *rawFields
 }
 
 // =============================================================================
 // Client
 // =============================================================================
 
-// NewClient creates a new AIPTX API client.
-func NewClient(baseURL, apiKey string) *Client {
+// NewClient creates a new AIPTX API client configured by opts, such as
+// WithAPIKey:
+//
+//	client := aiptx.NewClient("https://aiptx.example.com",
+//	    aiptx.WithAPIKey(apiKey),
+//	    aiptx.WithTimeout(time.Minute),
+//	)
+//
+// baseURL may name a unix domain socket, as in unix:///var/run/aiptx.sock.
+func NewClient(baseURL string, opts ...ClientOption) *Client {
 	if baseURL == "" {
 		baseURL = "http://localhost:8000"
 	}
 
-	return &Client{
-		BaseURL: baseURL,
-		APIKey:  apiKey,
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	c := &Client{
+		HTTPClient:     newHTTPClient(),
+		RetryPolicy:    DefaultRetryPolicy,
+		mu:             &sync.RWMutex{},
+		ownsTransport:  true,
+		deprecations:   &deprecations{seen: map[string]bool{}},
+		testingWindows: &testingWindows{entries: map[int64]testingWindowEntry{}},
+		rateLimit:      &rateLimit{},
+		instance:       newInstance(),
+	}
+	c.setBaseURL(baseURL)
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-// request makes an HTTP request to the API.
-func (c *Client) request(method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, err
+// request makes an HTTP request to the API, sending in as the JSON body and
+// decoding the JSON response into out. Either may be nil.
+func (c *Client) request(method, path string, in, out interface{}) error {
+	return c.requestContext(context.Background(), method, path, in, out)
+}
+
+// requestContext makes an HTTP request to the API that is cancelled with ctx.
+func (c *Client) requestContext(ctx context.Context, method, path string, in, out interface{}) error {
+	return c.requestHeader(ctx, method, path, nil, in, out)
+}
+
+// requestHeader makes an HTTP request to the API with extra headers.
+func (c *Client) requestHeader(ctx context.Context, method, path string, header http.Header, in, out interface{}) error {
+	if in == nil {
+		return c.send(ctx, method, path, "application/json", header, nil, out)
+	}
+
+	buf := getBuffer()
+	if err := buf.encode(in); err != nil {
+		putBuffer(buf)
+		return err
+	}
+	err := c.send(ctx, method, path, "application/json", header, buf.Bytes(), out)
+	if err == nil {
+		// After a failure the transport may still be reading the body, so
+		// the buffer is only reused once the request has succeeded.
+		putBuffer(buf)
+	}
+	return err
+}
+
+// send makes an HTTP request with a raw body and decodes the JSON response
+// into out, which may be nil. GET requests are coalesced if the client was
+// created with WithRequestCoalescing.
+func (c *Client) send(ctx context.Context, method, path, contentType string, header http.Header, body []byte, out interface{}) error {
+	if c.coalescer != nil && method == "GET" && body == nil && header == nil {
+		key := c.ActiveURL() + path + "\x00" + c.apiKey(ctx) + "\x00" + c.Organization
+		data, err := c.coalescer.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+			resp, err := c.do(ctx, method, path, contentType, header, body)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+			return io.ReadAll(resp.Body)
+		})
+		if err != nil || out == nil || len(data) == 0 {
+			return err
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		return unmarshal(data, out, c.strictDecoding)
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	resp, err := c.do(ctx, method, path, contentType, header, body)
 	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, out, c.strictDecoding)
+}
+
+// do makes an HTTP request and returns the response for the caller to read
+// and close. Failed attempts are retried according to the client's
+// RetryPolicy, and error responses are converted to an *APIError or one of
+// the more specific types wrapping it.
+func (c *Client) do(ctx context.Context, method, path, contentType string, header http.Header, body []byte) (*http.Response, error) {
+	c.checkVersion(ctx)
+	if err := c.audit(ctx, method, path, body); err != nil {
 		return nil, err
 	}
+	retry := newRetryState(c.RetryPolicy, method)
+	failovers := 0
+	for {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		base := c.ActiveURL()
+		req, resp, err := c.attempt(ctx, method, path, contentType, header, reqBody)
+		if err == nil {
+			return resp, nil
+		}
+
+		// An unreachable server is skipped without counting as a retry,
+		// trying each of the client's servers once.
+		if c.failover != nil && failovers < len(c.failover.fallbacks) && shouldFailover(ctx, err) {
+			c.failed(base)
+			failovers++
+			continue
+		}
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+		delay, ok := retry.next(ctx, err)
+		if !ok {
+			return nil, err
+		}
+		c.Hooks.retry(req, retry.attempt, err, delay)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	resp, err := c.HTTPClient.Do(req)
+// attempt makes a single HTTP request. Bodies that cannot be replayed, such
+// as streamed uploads, are sent with attempt directly instead of do.
+func (c *Client) attempt(ctx context.Context, method, path, contentType string, header http.Header, body io.Reader) (*http.Request, *http.Response, error) {
+	if err := c.checkReadOnly(method, path); err != nil {
+		return nil, nil, err
+	}
+	path, err := c.dryRunPath(ctx, method, path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL()+path, body)
+	if err != nil {
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if apiKey := c.apiKey(ctx); apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	if c.Organization != "" {
+		req.Header.Set("X-AIPTX-Organization", c.Organization)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	c.Hooks.request(req)
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	c.Hooks.response(req, resp, err, time.Since(start))
 	if err != nil {
-		return nil, err
+		return req, nil, &TransportError{Method: method, URL: req.URL.Redacted(), Err: err}
 	}
+	c.checkDeprecation(req, resp)
+	c.recordRateLimit(resp)
 
 	if resp.StatusCode >= 400 {
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return req, nil, &TransportError{Method: method, URL: req.URL.Redacted(), Err: err}
 		}
+		return req, nil, newAPIError(resp, respBody)
 	}
 
-	return respBody, nil
+	return req, resp, nil
+}
+
+// apiKey returns the API key for a request made with ctx.
+func (c *Client) apiKey(ctx context.Context) string {
+	if key, ok := ContextAPIKey(ctx); ok {
+		return key
+	}
+	c.lock().RLock()
+	defer c.lock().RUnlock()
+	return c.APIKey
+}
+
+// Do sends a request to the API and decodes the JSON response into out, which
+// may be nil. It is intended for endpoints the SDK does not wrap yet.
+func (c *Client) Do(method, path string, body, out interface{}) error {
+	return c.DoContext(context.Background(), method, path, body, out)
+}
+
+// DoContext is like Do but uses ctx for its request.
+func (c *Client) DoContext(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.requestContext(ctx, method, path, body, out)
 }
 
 // =============================================================================
@@ -212,13 +489,13 @@ func (c *Client) request(method, path string, body interface{}) ([]byte, error)
 
 // Health returns the server health status.
 func (c *Client) Health() (*HealthStatus, error) {
-	body, err := c.request("GET", "/health", nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.HealthContext(context.Background())
+}
 
+// HealthContext is like Health but uses ctx for its request.
+func (c *Client) HealthContext(ctx context.Context) (*HealthStatus, error) {
 	var health HealthStatus
-	if err := json.Unmarshal(body, &health); err != nil {
+	if err := c.requestContext(ctx, "GET", "/health", nil, &health); err != nil {
 		return nil, err
 	}
 	return &health, nil
@@ -226,8 +503,12 @@ func (c *Client) Health() (*HealthStatus, error) {
 
 // Ready checks if the server is ready to accept requests.
 func (c *Client) Ready() bool {
-	_, err := c.request("GET", "/health/ready", nil)
-	return err == nil
+	return c.ReadyContext(context.Background())
+}
+
+// ReadyContext is like Ready but uses ctx for its request.
+func (c *Client) ReadyContext(ctx context.Context) bool {
+	return c.requestContext(ctx, "GET", "/health/ready", nil, nil) == nil
 }
 
 // =============================================================================
@@ -236,27 +517,55 @@ func (c *Client) Ready() bool {
 
 // ListProjects returns all projects.
 func (c *Client) ListProjects() ([]Project, error) {
-	body, err := c.request("GET", "/projects", nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.ListProjectsContext(context.Background())
+}
 
+// ListProjectsContext is like ListProjects but uses ctx for its request.
+func (c *Client) ListProjectsContext(ctx context.Context) ([]Project, error) {
+	return c.ListProjectsWithOptionsContext(ctx, nil)
+}
+
+// ListProjectsWithOptions returns the projects matching opts.
+func (c *Client) ListProjectsWithOptions(opts *ListOptions) ([]Project, error) {
+	return c.ListProjectsWithOptionsContext(context.Background(), opts)
+}
+
+// ListProjectsWithOptionsContext is like ListProjectsWithOptions but uses ctx for its
+// request.
+func (c *Client) ListProjectsWithOptionsContext(ctx context.Context, opts *ListOptions) ([]Project, error) {
 	var projects []Project
-	if err := json.Unmarshal(body, &projects); err != nil {
+	if err := c.requestContext(ctx, "GET", withQuery("/projects", opts.values()), nil, &projects); err != nil {
 		return nil, err
 	}
 	return projects, nil
 }
 
-// CreateProject creates a new project.
-func (c *Client) CreateProject(data *ProjectCreate) (*Project, error) {
-	body, err := c.request("POST", "/projects", data)
+// ListProjectsPage returns one page of the projects matching opts, with
+// pagination metadata.
+func (c *Client) ListProjectsPage(opts *ListOptions) ([]Project, *PageInfo, error) {
+	return c.ListProjectsPageContext(context.Background(), opts)
+}
+
+// ListProjectsPageContext is like ListProjectsPage but uses ctx for its
+// request.
+func (c *Client) ListProjectsPageContext(ctx context.Context, opts *ListOptions) ([]Project, *PageInfo, error) {
+	var projects []Project
+	page, err := c.listPage(ctx, withQuery("/projects", opts.values()), &projects)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return projects, page, nil
+}
+
+// CreateProject creates a new project.
+func (c *Client) CreateProject(data *ProjectCreate) (*Project, error) {
+	return c.CreateProjectContext(context.Background(), data)
+}
 
+// CreateProjectContext is like CreateProject but uses ctx for its request.
+func (c *Client) CreateProjectContext(ctx context.Context, data *ProjectCreate) (*Project, error) {
 	var project Project
-	if err := json.Unmarshal(body, &project); err != nil {
+	if err := c.requestHeader(ctx, "POST", "/projects", c.ownerHeader(), data, &project); err != nil {
 		return nil, err
 	}
 	return &project, nil
@@ -264,13 +573,13 @@ func (c *Client) CreateProject(data *ProjectCreate) (*Project, error) {
 
 // GetProject returns a project by ID.
 func (c *Client) GetProject(id int64) (*Project, error) {
-	body, err := c.request("GET", fmt.Sprintf("/projects/%d", id), nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetProjectContext(context.Background(), id)
+}
 
+// GetProjectContext is like GetProject but uses ctx for its request.
+func (c *Client) GetProjectContext(ctx context.Context, id int64) (*Project, error) {
 	var project Project
-	if err := json.Unmarshal(body, &project); err != nil {
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/projects/%d", id), nil, &project); err != nil {
 		return nil, err
 	}
 	return &project, nil
@@ -278,13 +587,14 @@ func (c *Client) GetProject(id int64) (*Project, error) {
 
 // UpdateProject updates a project.
 func (c *Client) UpdateProject(id int64, data *ProjectCreate) (*Project, error) {
-	body, err := c.request("PUT", fmt.Sprintf("/projects/%d", id), data)
-	if err != nil {
-		return nil, err
-	}
+	return c.UpdateProjectContext(context.Background(), id, data)
+}
 
+// UpdateProjectContext is like UpdateProject but uses ctx for its request.
+func (c *Client) UpdateProjectContext(ctx context.Context, id int64, data *ProjectCreate) (*Project, error) {
+	defer c.testingWindows.forget(id)
 	var project Project
-	if err := json.Unmarshal(body, &project); err != nil {
+	if err := c.requestContext(ctx, "PUT", fmt.Sprintf("/projects/%d", id), data, &project); err != nil {
 		return nil, err
 	}
 	return &project, nil
@@ -292,8 +602,13 @@ func (c *Client) UpdateProject(id int64, data *ProjectCreate) (*Project, error)
 
 // DeleteProject deletes a project.
 func (c *Client) DeleteProject(id int64) error {
-	_, err := c.request("DELETE", fmt.Sprintf("/projects/%d", id), nil)
-	return err
+	return c.DeleteProjectContext(context.Background(), id)
+}
+
+// DeleteProjectContext is like DeleteProject but uses ctx for its request.
+func (c *Client) DeleteProjectContext(ctx context.Context, id int64) error {
+	defer c.testingWindows.forget(id)
+	return c.requestContext(ctx, "DELETE", fmt.Sprintf("/projects/%d", id), nil, nil)
 }
 
 // =============================================================================
@@ -302,27 +617,63 @@ func (c *Client) DeleteProject(id int64) error {
 
 // ListSessions returns all sessions for a project.
 func (c *Client) ListSessions(projectID int64) ([]Session, error) {
-	body, err := c.request("GET", fmt.Sprintf("/projects/%d/sessions", projectID), nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.ListSessionsContext(context.Background(), projectID)
+}
 
+// ListSessionsContext is like ListSessions but uses ctx for its request.
+func (c *Client) ListSessionsContext(ctx context.Context, projectID int64) ([]Session, error) {
+	return c.ListSessionsWithOptionsContext(ctx, projectID, nil)
+}
+
+// ListSessionsWithOptions returns the sessions of a project matching opts.
+func (c *Client) ListSessionsWithOptions(projectID int64, opts *ListOptions) ([]Session, error) {
+	return c.ListSessionsWithOptionsContext(context.Background(), projectID, opts)
+}
+
+// ListSessionsWithOptionsContext is like ListSessionsWithOptions but uses ctx for its
+// request.
+func (c *Client) ListSessionsWithOptionsContext(ctx context.Context, projectID int64, opts *ListOptions) ([]Session, error) {
+	path := fmt.Sprintf("/projects/%d/sessions", projectID)
 	var sessions []Session
-	if err := json.Unmarshal(body, &sessions); err != nil {
+	if err := c.requestContext(ctx, "GET", withQuery(path, opts.values()), nil, &sessions); err != nil {
 		return nil, err
 	}
 	return sessions, nil
 }
 
-// CreateSession creates a new session for a project.
-func (c *Client) CreateSession(projectID int64, data *SessionCreate) (*Session, error) {
-	body, err := c.request("POST", fmt.Sprintf("/projects/%d/sessions", projectID), data)
+// ListSessionsPage returns one page of the sessions of a project matching
+// opts, with pagination metadata.
+func (c *Client) ListSessionsPage(projectID int64, opts *ListOptions) ([]Session, *PageInfo, error) {
+	return c.ListSessionsPageContext(context.Background(), projectID, opts)
+}
+
+// ListSessionsPageContext is like ListSessionsPage but uses ctx for its
+// request.
+func (c *Client) ListSessionsPageContext(ctx context.Context, projectID int64, opts *ListOptions) ([]Session, *PageInfo, error) {
+	path := fmt.Sprintf("/projects/%d/sessions", projectID)
+	var sessions []Session
+	page, err := c.listPage(ctx, withQuery(path, opts.values()), &sessions)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return sessions, page, nil
+}
+
+// CreateSession creates a new session for a project.
+func (c *Client) CreateSession(projectID int64, data *SessionCreate) (*Session, error) {
+	return c.CreateSessionContext(context.Background(), projectID, data)
+}
 
+// CreateSessionContext is like CreateSession but uses ctx for its request.
+func (c *Client) CreateSessionContext(ctx context.Context, projectID int64, data *SessionCreate) (*Session, error) {
 	var session Session
-	if err := json.Unmarshal(body, &session); err != nil {
+	err := c.create(ctx, fmt.Sprintf("/projects/%d/sessions", projectID), data, &session, func() {
+		// Sessions created in dry-run mode come back without an ID.
+		if session.ID != 0 {
+			c.instance.addSession(session.ID)
+		}
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &session, nil
@@ -330,15 +681,16 @@ func (c *Client) CreateSession(projectID int64, data *SessionCreate) (*Session,
 
 // GetSession returns a session by ID.
 func (c *Client) GetSession(id int64) (*Session, error) {
-	body, err := c.request("GET", fmt.Sprintf("/sessions/%d", id), nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetSessionContext(context.Background(), id)
+}
 
+// GetSessionContext is like GetSession but uses ctx for its request.
+func (c *Client) GetSessionContext(ctx context.Context, id int64) (*Session, error) {
 	var session Session
-	if err := json.Unmarshal(body, &session); err != nil {
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/sessions/%d", id), nil, &session); err != nil {
 		return nil, err
 	}
+	c.instance.sessionStatus(session.ID, session.Status)
 	return &session, nil
 }
 
@@ -347,52 +699,201 @@ func (c *Client) GetSession(id int64) (*Session, error) {
 // =============================================================================
 
 // FindingsFilter contains options for filtering findings.
+//
+// Multi-value fields match findings with any of the listed values. Verified
+// and FalsePositive are only applied when non-nil. A filter can also be built
+// from an expression with ParseFilter.
 type FindingsFilter struct {
-	ProjectID int64
-	Severity  string
-	Type      string
+	ListOptions
+
+	ProjectID     int64
+	Severity      Severity
+	MinSeverity   Severity
+	Severities    []Severity
+	Type          string
+	Types         []string
+	Tool          string
+	Verified      *bool
+	FalsePositive *bool
+
+	// MinConfidence excludes AI-reported findings whose Confidence is
+	// below it. Findings reported directly by tools always match.
+	MinConfidence float64
+
+	// KillChainStages matches findings classified in any of the stages.
+	KillChainStages []KillChainStage
+
+	// Owner, RemediationStatuses and DueBefore filter on the remediation
+	// of findings. Overdue restricts results to findings whose due date
+	// has passed without a fix.
+	Owner               string
+	RemediationStatuses []RemediationStatus
+	DueBefore           time.Time
+	Overdue             bool
+
+	// DiscoveredAfter and DiscoveredBefore bound the discovery time of
+	// returned findings. Zero values are ignored.
+	DiscoveredAfter  time.Time
+	DiscoveredBefore time.Time
+	// DiscoveredWithin restricts results to findings discovered within this
+	// long before the request. Unlike DiscoveredAfter it stays relative
+	// when the filter is stored in a saved search.
+	DiscoveredWithin time.Duration
+
+	// NewSinceBaseline restricts results to findings that were not present
+	// in their project's baseline scan. See SetBaseline.
+	NewSinceBaseline bool
 }
 
-// ListFindings returns all findings, optionally filtered.
-func (c *Client) ListFindings(filter *FindingsFilter) ([]Finding, error) {
-	path := "/findings"
-	if filter != nil {
-		params := url.Values{}
-		if filter.ProjectID > 0 {
-			params.Add("project_id", fmt.Sprintf("%d", filter.ProjectID))
-		}
-		if filter.Severity != "" {
-			params.Add("severity", filter.Severity)
-		}
-		if filter.Type != "" {
-			params.Add("type", filter.Type)
-		}
-		if len(params) > 0 {
-			path += "?" + params.Encode()
+// values encodes the filter as query parameters.
+func (f *FindingsFilter) values() url.Values {
+	params := f.ListOptions.values()
+	if f.ProjectID > 0 {
+		params.Add("project_id", fmt.Sprintf("%d", f.ProjectID))
+	}
+	if f.Severity != "" {
+		params.Add("severity", string(f.Severity))
+	}
+	if f.MinSeverity != "" {
+		params.Add("min_severity", string(f.MinSeverity))
+	}
+	for _, sev := range f.Severities {
+		params.Add("severity", string(sev))
+	}
+	if f.Type != "" {
+		params.Add("type", f.Type)
+	}
+	for _, typ := range f.Types {
+		params.Add("type", typ)
+	}
+	if f.Tool != "" {
+		params.Add("tool", f.Tool)
+	}
+	for _, stage := range f.KillChainStages {
+		params.Add("kill_chain_stage", string(stage))
+	}
+	if f.Owner != "" {
+		params.Add("owner", f.Owner)
+	}
+	for _, status := range f.RemediationStatuses {
+		params.Add("remediation_status", string(status))
+	}
+	if !f.DueBefore.IsZero() {
+		params.Add("due_before", f.DueBefore.UTC().Format(time.RFC3339))
+	}
+	if f.Overdue {
+		params.Add("overdue", "true")
+	}
+	if f.Verified != nil {
+		params.Add("verified", fmt.Sprintf("%t", *f.Verified))
+	}
+	if f.FalsePositive != nil {
+		params.Add("false_positive", fmt.Sprintf("%t", *f.FalsePositive))
+	}
+	if f.MinConfidence > 0 {
+		params.Add("min_confidence", strconv.FormatFloat(f.MinConfidence, 'f', -1, 64))
+	}
+	if !f.DiscoveredAfter.IsZero() {
+		params.Add("discovered_after", f.DiscoveredAfter.UTC().Format(time.RFC3339))
+	}
+	if !f.DiscoveredBefore.IsZero() {
+		params.Add("discovered_before", f.DiscoveredBefore.UTC().Format(time.RFC3339))
+	}
+	if f.DiscoveredWithin > 0 {
+		params.Add("discovered_within", strconv.FormatInt(int64(f.DiscoveredWithin/time.Second), 10))
+	}
+	if f.NewSinceBaseline {
+		params.Add("new_since_baseline", "true")
+	}
+	return params
+}
+
+// validate rejects severities the server does not know, which would match
+// no findings.
+func (f *FindingsFilter) validate() error {
+	if f == nil {
+		return nil
+	}
+	if err := checkSeverity(f.Severity); err != nil {
+		return err
+	}
+	if err := checkSeverity(f.MinSeverity); err != nil {
+		return err
+	}
+	for _, sev := range f.Severities {
+		if err := checkSeverity(sev); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	body, err := c.request("GET", path, nil)
-	if err != nil {
+func checkSeverity(sev Severity) error {
+	if sev != "" && !sev.Valid() {
+		return fmt.Errorf("%w: unknown severity %q in findings filter", ErrInvalidValue, sev)
+	}
+	return nil
+}
+
+// ListFindings returns all findings, optionally filtered. Use
+// IterateFindings for large projects, which it would have to load at once.
+func (c *Client) ListFindings(filter *FindingsFilter) ([]Finding, error) {
+	return c.ListFindingsContext(context.Background(), filter)
+}
+
+// ListFindingsContext is like ListFindings but uses ctx for its request.
+func (c *Client) ListFindingsContext(ctx context.Context, filter *FindingsFilter) ([]Finding, error) {
+	if err := filter.validate(); err != nil {
 		return nil, err
 	}
+	path := "/findings"
+	if filter != nil {
+		path = withQuery(path, filter.values())
+	}
 
 	var findings []Finding
-	if err := json.Unmarshal(body, &findings); err != nil {
+	if err := c.requestContext(ctx, "GET", path, nil, &findings); err != nil {
 		return nil, err
 	}
 	return findings, nil
 }
 
-// GetProjectFindings returns all findings for a project.
-func (c *Client) GetProjectFindings(projectID int64) ([]Finding, error) {
-	body, err := c.request("GET", fmt.Sprintf("/projects/%d/findings", projectID), nil)
+// ListFindingsPage returns one page of findings, optionally filtered, with
+// pagination metadata. Set filter.Limit and filter.Cursor to page through
+// the results.
+func (c *Client) ListFindingsPage(filter *FindingsFilter) ([]Finding, *PageInfo, error) {
+	return c.ListFindingsPageContext(context.Background(), filter)
+}
+
+// ListFindingsPageContext is like ListFindingsPage but uses ctx for its
+// request.
+func (c *Client) ListFindingsPageContext(ctx context.Context, filter *FindingsFilter) ([]Finding, *PageInfo, error) {
+	if err := filter.validate(); err != nil {
+		return nil, nil, err
+	}
+	path := "/findings"
+	if filter != nil {
+		path = withQuery(path, filter.values())
+	}
+
+	var findings []Finding
+	page, err := c.listPage(ctx, path, &findings)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return findings, page, nil
+}
 
+// GetProjectFindings returns all findings for a project.
+func (c *Client) GetProjectFindings(projectID int64) ([]Finding, error) {
+	return c.GetProjectFindingsContext(context.Background(), projectID)
+}
+
+// GetProjectFindingsContext is like GetProjectFindings but uses ctx for its
+// request.
+func (c *Client) GetProjectFindingsContext(ctx context.Context, projectID int64) ([]Finding, error) {
 	var findings []Finding
-	if err := json.Unmarshal(body, &findings); err != nil {
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/projects/%d/findings", projectID), nil, &findings); err != nil {
 		return nil, err
 	}
 	return findings, nil
@@ -400,47 +901,140 @@ func (c *Client) GetProjectFindings(projectID int64) ([]Finding, error) {
 
 // GetFinding returns a finding by ID.
 func (c *Client) GetFinding(id int64) (*Finding, error) {
-	body, err := c.request("GET", fmt.Sprintf("/findings/%d", id), nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetFindingContext(context.Background(), id)
+}
 
+// GetFindingContext is like GetFinding but uses ctx for its request.
+func (c *Client) GetFindingContext(ctx context.Context, id int64) (*Finding, error) {
 	var finding Finding
-	if err := json.Unmarshal(body, &finding); err != nil {
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/findings/%d", id), nil, &finding); err != nil {
 		return nil, err
 	}
 	return &finding, nil
 }
 
+// ListEvidence returns the evidence attached to a finding.
+func (c *Client) ListEvidence(findingID int64) ([]Evidence, error) {
+	return c.ListEvidenceContext(context.Background(), findingID)
+}
+
+// ListEvidenceContext is like ListEvidence but uses ctx for its request.
+func (c *Client) ListEvidenceContext(ctx context.Context, findingID int64) ([]Evidence, error) {
+	var evidence []Evidence
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/findings/%d/evidence", findingID), nil, &evidence); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
 // =============================================================================
 // Scanning
 // =============================================================================
 
-// StartScan starts a new security scan.
+// errNilScanRequest is returned when a scan is started without a request.
+var errNilScanRequest = errors.New("scan request is nil")
+
+// StartScan starts a new security scan. If the server rejects it because
+// exploitation or AI is disabled, the error is a *FeatureDisabledError.
+//
+// A scan of a project is refused with ErrOutsideTestingWindow while the
+// project's testing window is closed, unless req.QueueOutsideWindow is set.
 func (c *Client) StartScan(req *ScanRequest) (*ScanStatus, error) {
-	body, err := c.request("POST", "/scan", req)
-	if err != nil {
+	return c.StartScanContext(context.Background(), req)
+}
+
+// StartScanContext is like StartScan but uses ctx for its request.
+func (c *Client) StartScanContext(ctx context.Context, req *ScanRequest) (*ScanStatus, error) {
+	if req == nil {
+		return nil, errNilScanRequest
+	}
+	if err := req.validate(); err != nil {
 		return nil, err
 	}
-
+	if req.ProjectID != 0 {
+		var err error
+		if req, err = c.checkTestingWindow(ctx, req); err != nil {
+			return nil, err
+		}
+	}
 	var status ScanStatus
-	if err := json.Unmarshal(body, &status); err != nil {
-		return nil, err
+	err := c.create(ctx, "/scan", req.withMaxDuration(), &status, func() {
+		// Scans started in dry-run mode come back without an ID.
+		if status.ID != "" {
+			c.instance.addScan(status.ID)
+		}
+	})
+	if err != nil {
+		var features []string
+		if req.Exploit {
+			features = append(features, FeatureExploitation)
+		}
+		if req.AI {
+			features = append(features, FeatureAI)
+		}
+		return nil, c.featureError(ctx, err, features...)
 	}
 	return &status, nil
 }
 
-// GetScanStatus returns the status of a scan.
-func (c *Client) GetScanStatus(scanID string) (*ScanStatus, error) {
-	body, err := c.request("GET", fmt.Sprintf("/scans/%s", scanID), nil)
-	if err != nil {
+// validate rejects modes and phases the server does not know.
+func (r *ScanRequest) validate() error {
+	if r.Mode != "" && !r.Mode.Valid() {
+		return fmt.Errorf("%w: unknown scan mode %q", ErrInvalidValue, r.Mode)
+	}
+	for _, phase := range r.Phases {
+		if !phase.Valid() {
+			return fmt.Errorf("%w: unknown phase %q", ErrInvalidValue, phase)
+		}
+	}
+	if r.PhasePlan != nil {
+		return r.PhasePlan.validate()
+	}
+	return nil
+}
+
+// ListScans returns the scans matching opts.
+func (c *Client) ListScans(opts *ListOptions) ([]ScanStatus, error) {
+	return c.ListScansContext(context.Background(), opts)
+}
+
+// ListScansContext is like ListScans but uses ctx for its request.
+func (c *Client) ListScansContext(ctx context.Context, opts *ListOptions) ([]ScanStatus, error) {
+	var scans []ScanStatus
+	if err := c.requestContext(ctx, "GET", withQuery("/scans", opts.values()), nil, &scans); err != nil {
 		return nil, err
 	}
+	return scans, nil
+}
+
+// ListScansPage returns one page of the scans matching opts, with pagination
+// metadata.
+func (c *Client) ListScansPage(opts *ListOptions) ([]ScanStatus, *PageInfo, error) {
+	return c.ListScansPageContext(context.Background(), opts)
+}
+
+// ListScansPageContext is like ListScansPage but uses ctx for its request.
+func (c *Client) ListScansPageContext(ctx context.Context, opts *ListOptions) ([]ScanStatus, *PageInfo, error) {
+	var scans []ScanStatus
+	page, err := c.listPage(ctx, withQuery("/scans", opts.values()), &scans)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scans, page, nil
+}
 
+// GetScanStatus returns the status of a scan.
+func (c *Client) GetScanStatus(scanID string) (*ScanStatus, error) {
+	return c.GetScanStatusContext(context.Background(), scanID)
+}
+
+// GetScanStatusContext is like GetScanStatus but uses ctx for its request.
+func (c *Client) GetScanStatusContext(ctx context.Context, scanID string) (*ScanStatus, error) {
 	var status ScanStatus
-	if err := json.Unmarshal(body, &status); err != nil {
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/scans/%s", scanID), nil, &status); err != nil {
 		return nil, err
 	}
+	c.instance.scanStatus(status.ID, status.Status)
 	return &status, nil
 }
 
@@ -450,13 +1044,13 @@ func (c *Client) GetScanStatus(scanID string) (*ScanStatus, error) {
 
 // ListTools returns all available security tools.
 func (c *Client) ListTools() ([]Tool, error) {
-	body, err := c.request("GET", "/tools", nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.ListToolsContext(context.Background())
+}
 
+// ListToolsContext is like ListTools but uses ctx for its request.
+func (c *Client) ListToolsContext(ctx context.Context) ([]Tool, error) {
 	var tools []Tool
-	if err := json.Unmarshal(body, &tools); err != nil {
+	if err := c.requestContext(ctx, "GET", "/tools", nil, &tools); err != nil {
 		return nil, err
 	}
 	return tools, nil
@@ -6,13 +6,13 @@ import (
 
 func TestNewClient(t *testing.T) {
 	// Test default client creation
-	client := NewClient("", "")
+	client := NewClient("")
 	if client.BaseURL != "http://localhost:8000" {
 		t.Errorf("Expected default base URL, got %s", client.BaseURL)
 	}
 
 	// Test custom client creation
-	client = NewClient("http://custom:9000", "test-key")
+	client = NewClient("http://custom:9000", WithAPIKey("test-key"))
 	if client.BaseURL != "http://custom:9000" {
 		t.Errorf("Expected custom base URL, got %s", client.BaseURL)
 	}
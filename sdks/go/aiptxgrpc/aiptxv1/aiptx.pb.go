@@ -0,0 +1,968 @@
+// AIPTX gRPC interface.
+//
+// Messages mirror the REST API resources. Go code in aiptxv1 is generated
+// from this file with protoc-gen-go and protoc-gen-go-grpc.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aiptx/v1/aiptx.proto
+
+package aiptxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{0}
+}
+
+type HealthStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Uptime        int64                  `protobuf:"varint,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Database      bool                   `protobuf:"varint,4,opt,name=database,proto3" json:"database,omitempty"`
+	Llm           bool                   `protobuf:"varint,5,opt,name=llm,proto3" json:"llm,omitempty"`
+	Scanners      map[string]bool        `protobuf:"bytes,6,rep,name=scanners,proto3" json:"scanners,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthStatus) Reset() {
+	*x = HealthStatus{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthStatus) ProtoMessage() {}
+
+func (x *HealthStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthStatus.ProtoReflect.Descriptor instead.
+func (*HealthStatus) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HealthStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HealthStatus) GetUptime() int64 {
+	if x != nil {
+		return x.Uptime
+	}
+	return 0
+}
+
+func (x *HealthStatus) GetDatabase() bool {
+	if x != nil {
+		return x.Database
+	}
+	return false
+}
+
+func (x *HealthStatus) GetLlm() bool {
+	if x != nil {
+		return x.Llm
+	}
+	return false
+}
+
+func (x *HealthStatus) GetScanners() map[string]bool {
+	if x != nil {
+		return x.Scanners
+	}
+	return nil
+}
+
+type StartScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Ai            bool                   `protobuf:"varint,3,opt,name=ai,proto3" json:"ai,omitempty"`
+	Exploit       bool                   `protobuf:"varint,4,opt,name=exploit,proto3" json:"exploit,omitempty"`
+	Phases        []string               `protobuf:"bytes,5,rep,name=phases,proto3" json:"phases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartScanRequest) Reset() {
+	*x = StartScanRequest{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartScanRequest) ProtoMessage() {}
+
+func (x *StartScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartScanRequest.ProtoReflect.Descriptor instead.
+func (*StartScanRequest) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StartScanRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *StartScanRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *StartScanRequest) GetAi() bool {
+	if x != nil {
+		return x.Ai
+	}
+	return false
+}
+
+func (x *StartScanRequest) GetExploit() bool {
+	if x != nil {
+		return x.Exploit
+	}
+	return false
+}
+
+func (x *StartScanRequest) GetPhases() []string {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+type GetScanStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScanId        string                 `protobuf:"bytes,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScanStatusRequest) Reset() {
+	*x = GetScanStatusRequest{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScanStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScanStatusRequest) ProtoMessage() {}
+
+func (x *GetScanStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScanStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetScanStatusRequest) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetScanStatusRequest) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+type ScanStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Phase         string                 `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"`
+	Progress      int32                  `protobuf:"varint,4,opt,name=progress,proto3" json:"progress,omitempty"`
+	FindingsCount int32                  `protobuf:"varint,5,opt,name=findings_count,json=findingsCount,proto3" json:"findings_count,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanStatus) Reset() {
+	*x = ScanStatus{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanStatus) ProtoMessage() {}
+
+func (x *ScanStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanStatus.ProtoReflect.Descriptor instead.
+func (*ScanStatus) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScanStatus) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScanStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScanStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ScanStatus) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *ScanStatus) GetFindingsCount() int32 {
+	if x != nil {
+		return x.FindingsCount
+	}
+	return 0
+}
+
+func (x *ScanStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ScanStatus) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *ScanStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type WatchScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScanId        string                 `protobuf:"bytes,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchScanRequest) Reset() {
+	*x = WatchScanRequest{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchScanRequest) ProtoMessage() {}
+
+func (x *WatchScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchScanRequest.ProtoReflect.Descriptor instead.
+func (*WatchScanRequest) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchScanRequest) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+type ScanEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is one of "progress", "phase", "finding" or "completed".
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Status        *ScanStatus            `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Finding       *Finding               `protobuf:"bytes,3,opt,name=finding,proto3" json:"finding,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanEvent) Reset() {
+	*x = ScanEvent{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanEvent) ProtoMessage() {}
+
+func (x *ScanEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanEvent.ProtoReflect.Descriptor instead.
+func (*ScanEvent) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ScanEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetStatus() *ScanStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ScanEvent) GetFinding() *Finding {
+	if x != nil {
+		return x.Finding
+	}
+	return nil
+}
+
+func (x *ScanEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type ListFindingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Severities    []string               `protobuf:"bytes,2,rep,name=severities,proto3" json:"severities,omitempty"`
+	MinSeverity   string                 `protobuf:"bytes,3,opt,name=min_severity,json=minSeverity,proto3" json:"min_severity,omitempty"`
+	Types         []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	Tool          string                 `protobuf:"bytes,5,opt,name=tool,proto3" json:"tool,omitempty"`
+	Verified      *bool                  `protobuf:"varint,6,opt,name=verified,proto3,oneof" json:"verified,omitempty"`
+	FalsePositive *bool                  `protobuf:"varint,7,opt,name=false_positive,json=falsePositive,proto3,oneof" json:"false_positive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFindingsRequest) Reset() {
+	*x = ListFindingsRequest{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFindingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFindingsRequest) ProtoMessage() {}
+
+func (x *ListFindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFindingsRequest.ProtoReflect.Descriptor instead.
+func (*ListFindingsRequest) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListFindingsRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListFindingsRequest) GetSeverities() []string {
+	if x != nil {
+		return x.Severities
+	}
+	return nil
+}
+
+func (x *ListFindingsRequest) GetMinSeverity() string {
+	if x != nil {
+		return x.MinSeverity
+	}
+	return ""
+}
+
+func (x *ListFindingsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListFindingsRequest) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *ListFindingsRequest) GetVerified() bool {
+	if x != nil && x.Verified != nil {
+		return *x.Verified
+	}
+	return false
+}
+
+func (x *ListFindingsRequest) GetFalsePositive() bool {
+	if x != nil && x.FalsePositive != nil {
+		return *x.FalsePositive
+	}
+	return false
+}
+
+type ListFindingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Findings      []*Finding             `protobuf:"bytes,1,rep,name=findings,proto3" json:"findings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFindingsResponse) Reset() {
+	*x = ListFindingsResponse{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFindingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFindingsResponse) ProtoMessage() {}
+
+func (x *ListFindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFindingsResponse.ProtoReflect.Descriptor instead.
+func (*ListFindingsResponse) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListFindingsResponse) GetFindings() []*Finding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+type GetFindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFindingRequest) Reset() {
+	*x = GetFindingRequest{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFindingRequest) ProtoMessage() {}
+
+func (x *GetFindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFindingRequest.ProtoReflect.Descriptor instead.
+func (*GetFindingRequest) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFindingRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Finding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	SessionId     int64                  `protobuf:"varint,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Severity      string                 `protobuf:"bytes,7,opt,name=severity,proto3" json:"severity,omitempty"`
+	Phase         string                 `protobuf:"bytes,8,opt,name=phase,proto3" json:"phase,omitempty"`
+	Tool          string                 `protobuf:"bytes,9,opt,name=tool,proto3" json:"tool,omitempty"`
+	RawOutput     string                 `protobuf:"bytes,10,opt,name=raw_output,json=rawOutput,proto3" json:"raw_output,omitempty"`
+	Verified      bool                   `protobuf:"varint,11,opt,name=verified,proto3" json:"verified,omitempty"`
+	FalsePositive bool                   `protobuf:"varint,12,opt,name=false_positive,json=falsePositive,proto3" json:"false_positive,omitempty"`
+	DiscoveredAt  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=discovered_at,json=discoveredAt,proto3" json:"discovered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Finding) Reset() {
+	*x = Finding{}
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Finding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Finding) ProtoMessage() {}
+
+func (x *Finding) ProtoReflect() protoreflect.Message {
+	mi := &file_aiptx_v1_aiptx_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Finding.ProtoReflect.Descriptor instead.
+func (*Finding) Descriptor() ([]byte, []int) {
+	return file_aiptx_v1_aiptx_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Finding) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Finding) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Finding) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *Finding) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Finding) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Finding) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Finding) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Finding) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Finding) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *Finding) GetRawOutput() string {
+	if x != nil {
+		return x.RawOutput
+	}
+	return ""
+}
+
+func (x *Finding) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *Finding) GetFalsePositive() bool {
+	if x != nil {
+		return x.FalsePositive
+	}
+	return false
+}
+
+func (x *Finding) GetDiscoveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DiscoveredAt
+	}
+	return nil
+}
+
+var File_aiptx_v1_aiptx_proto protoreflect.FileDescriptor
+
+const file_aiptx_v1_aiptx_proto_rawDesc = "" +
+	"\n" +
+	"\x14aiptx/v1/aiptx.proto\x12\baiptx.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x0f\n" +
+	"\rHealthRequest\"\x85\x02\n" +
+	"\fHealthStatus\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
+	"\x06uptime\x18\x03 \x01(\x03R\x06uptime\x12\x1a\n" +
+	"\bdatabase\x18\x04 \x01(\bR\bdatabase\x12\x10\n" +
+	"\x03llm\x18\x05 \x01(\bR\x03llm\x12@\n" +
+	"\bscanners\x18\x06 \x03(\v2$.aiptx.v1.HealthStatus.ScannersEntryR\bscanners\x1a;\n" +
+	"\rScannersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\x80\x01\n" +
+	"\x10StartScanRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x0e\n" +
+	"\x02ai\x18\x03 \x01(\bR\x02ai\x12\x18\n" +
+	"\aexploit\x18\x04 \x01(\bR\aexploit\x12\x16\n" +
+	"\x06phases\x18\x05 \x03(\tR\x06phases\"/\n" +
+	"\x14GetScanStatusRequest\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\tR\x06scanId\"\x9d\x02\n" +
+	"\n" +
+	"ScanStatus\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05phase\x18\x03 \x01(\tR\x05phase\x12\x1a\n" +
+	"\bprogress\x18\x04 \x01(\x05R\bprogress\x12%\n" +
+	"\x0efindings_count\x18\x05 \x01(\x05R\rfindingsCount\x129\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"+\n" +
+	"\x10WatchScanRequest\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\tR\x06scanId\"\xaa\x01\n" +
+	"\tScanEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12,\n" +
+	"\x06status\x18\x02 \x01(\v2\x14.aiptx.v1.ScanStatusR\x06status\x12+\n" +
+	"\afinding\x18\x03 \x01(\v2\x11.aiptx.v1.FindingR\afinding\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\x8e\x02\n" +
+	"\x13ListFindingsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x1e\n" +
+	"\n" +
+	"severities\x18\x02 \x03(\tR\n" +
+	"severities\x12!\n" +
+	"\fmin_severity\x18\x03 \x01(\tR\vminSeverity\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x12\n" +
+	"\x04tool\x18\x05 \x01(\tR\x04tool\x12\x1f\n" +
+	"\bverified\x18\x06 \x01(\bH\x00R\bverified\x88\x01\x01\x12*\n" +
+	"\x0efalse_positive\x18\a \x01(\bH\x01R\rfalsePositive\x88\x01\x01B\v\n" +
+	"\t_verifiedB\x11\n" +
+	"\x0f_false_positive\"E\n" +
+	"\x14ListFindingsResponse\x12-\n" +
+	"\bfindings\x18\x01 \x03(\v2\x11.aiptx.v1.FindingR\bfindings\"#\n" +
+	"\x11GetFindingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x8c\x03\n" +
+	"\aFinding\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\x03R\tsessionId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bseverity\x18\a \x01(\tR\bseverity\x12\x14\n" +
+	"\x05phase\x18\b \x01(\tR\x05phase\x12\x12\n" +
+	"\x04tool\x18\t \x01(\tR\x04tool\x12\x1d\n" +
+	"\n" +
+	"raw_output\x18\n" +
+	" \x01(\tR\trawOutput\x12\x1a\n" +
+	"\bverified\x18\v \x01(\bR\bverified\x12%\n" +
+	"\x0efalse_positive\x18\f \x01(\bR\rfalsePositive\x12?\n" +
+	"\rdiscovered_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\fdiscoveredAt2\x95\x03\n" +
+	"\x05AIPTX\x129\n" +
+	"\x06Health\x12\x17.aiptx.v1.HealthRequest\x1a\x16.aiptx.v1.HealthStatus\x12=\n" +
+	"\tStartScan\x12\x1a.aiptx.v1.StartScanRequest\x1a\x14.aiptx.v1.ScanStatus\x12E\n" +
+	"\rGetScanStatus\x12\x1e.aiptx.v1.GetScanStatusRequest\x1a\x14.aiptx.v1.ScanStatus\x12>\n" +
+	"\tWatchScan\x12\x1a.aiptx.v1.WatchScanRequest\x1a\x13.aiptx.v1.ScanEvent0\x01\x12M\n" +
+	"\fListFindings\x12\x1d.aiptx.v1.ListFindingsRequest\x1a\x1e.aiptx.v1.ListFindingsResponse\x12<\n" +
+	"\n" +
+	"GetFinding\x12\x1b.aiptx.v1.GetFindingRequest\x1a\x11.aiptx.v1.FindingB5Z3github.com/aiptx/aiptx-go/aiptxgrpc/aiptxv1;aiptxv1b\x06proto3"
+
+var (
+	file_aiptx_v1_aiptx_proto_rawDescOnce sync.Once
+	file_aiptx_v1_aiptx_proto_rawDescData []byte
+)
+
+func file_aiptx_v1_aiptx_proto_rawDescGZIP() []byte {
+	file_aiptx_v1_aiptx_proto_rawDescOnce.Do(func() {
+		file_aiptx_v1_aiptx_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aiptx_v1_aiptx_proto_rawDesc), len(file_aiptx_v1_aiptx_proto_rawDesc)))
+	})
+	return file_aiptx_v1_aiptx_proto_rawDescData
+}
+
+var file_aiptx_v1_aiptx_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_aiptx_v1_aiptx_proto_goTypes = []any{
+	(*HealthRequest)(nil),         // 0: aiptx.v1.HealthRequest
+	(*HealthStatus)(nil),          // 1: aiptx.v1.HealthStatus
+	(*StartScanRequest)(nil),      // 2: aiptx.v1.StartScanRequest
+	(*GetScanStatusRequest)(nil),  // 3: aiptx.v1.GetScanStatusRequest
+	(*ScanStatus)(nil),            // 4: aiptx.v1.ScanStatus
+	(*WatchScanRequest)(nil),      // 5: aiptx.v1.WatchScanRequest
+	(*ScanEvent)(nil),             // 6: aiptx.v1.ScanEvent
+	(*ListFindingsRequest)(nil),   // 7: aiptx.v1.ListFindingsRequest
+	(*ListFindingsResponse)(nil),  // 8: aiptx.v1.ListFindingsResponse
+	(*GetFindingRequest)(nil),     // 9: aiptx.v1.GetFindingRequest
+	(*Finding)(nil),               // 10: aiptx.v1.Finding
+	nil,                           // 11: aiptx.v1.HealthStatus.ScannersEntry
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_aiptx_v1_aiptx_proto_depIdxs = []int32{
+	11, // 0: aiptx.v1.HealthStatus.scanners:type_name -> aiptx.v1.HealthStatus.ScannersEntry
+	12, // 1: aiptx.v1.ScanStatus.started_at:type_name -> google.protobuf.Timestamp
+	12, // 2: aiptx.v1.ScanStatus.completed_at:type_name -> google.protobuf.Timestamp
+	4,  // 3: aiptx.v1.ScanEvent.status:type_name -> aiptx.v1.ScanStatus
+	10, // 4: aiptx.v1.ScanEvent.finding:type_name -> aiptx.v1.Finding
+	12, // 5: aiptx.v1.ScanEvent.time:type_name -> google.protobuf.Timestamp
+	10, // 6: aiptx.v1.ListFindingsResponse.findings:type_name -> aiptx.v1.Finding
+	12, // 7: aiptx.v1.Finding.discovered_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: aiptx.v1.AIPTX.Health:input_type -> aiptx.v1.HealthRequest
+	2,  // 9: aiptx.v1.AIPTX.StartScan:input_type -> aiptx.v1.StartScanRequest
+	3,  // 10: aiptx.v1.AIPTX.GetScanStatus:input_type -> aiptx.v1.GetScanStatusRequest
+	5,  // 11: aiptx.v1.AIPTX.WatchScan:input_type -> aiptx.v1.WatchScanRequest
+	7,  // 12: aiptx.v1.AIPTX.ListFindings:input_type -> aiptx.v1.ListFindingsRequest
+	9,  // 13: aiptx.v1.AIPTX.GetFinding:input_type -> aiptx.v1.GetFindingRequest
+	1,  // 14: aiptx.v1.AIPTX.Health:output_type -> aiptx.v1.HealthStatus
+	4,  // 15: aiptx.v1.AIPTX.StartScan:output_type -> aiptx.v1.ScanStatus
+	4,  // 16: aiptx.v1.AIPTX.GetScanStatus:output_type -> aiptx.v1.ScanStatus
+	6,  // 17: aiptx.v1.AIPTX.WatchScan:output_type -> aiptx.v1.ScanEvent
+	8,  // 18: aiptx.v1.AIPTX.ListFindings:output_type -> aiptx.v1.ListFindingsResponse
+	10, // 19: aiptx.v1.AIPTX.GetFinding:output_type -> aiptx.v1.Finding
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_aiptx_v1_aiptx_proto_init() }
+func file_aiptx_v1_aiptx_proto_init() {
+	if File_aiptx_v1_aiptx_proto != nil {
+		return
+	}
+	file_aiptx_v1_aiptx_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aiptx_v1_aiptx_proto_rawDesc), len(file_aiptx_v1_aiptx_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aiptx_v1_aiptx_proto_goTypes,
+		DependencyIndexes: file_aiptx_v1_aiptx_proto_depIdxs,
+		MessageInfos:      file_aiptx_v1_aiptx_proto_msgTypes,
+	}.Build()
+	File_aiptx_v1_aiptx_proto = out.File
+	file_aiptx_v1_aiptx_proto_goTypes = nil
+	file_aiptx_v1_aiptx_proto_depIdxs = nil
+}
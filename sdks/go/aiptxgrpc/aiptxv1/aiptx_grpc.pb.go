@@ -0,0 +1,336 @@
+// AIPTX gRPC interface.
+//
+// Messages mirror the REST API resources. Go code in aiptxv1 is generated
+// from this file with protoc-gen-go and protoc-gen-go-grpc.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: aiptx/v1/aiptx.proto
+
+package aiptxv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AIPTX_Health_FullMethodName        = "/aiptx.v1.AIPTX/Health"
+	AIPTX_StartScan_FullMethodName     = "/aiptx.v1.AIPTX/StartScan"
+	AIPTX_GetScanStatus_FullMethodName = "/aiptx.v1.AIPTX/GetScanStatus"
+	AIPTX_WatchScan_FullMethodName     = "/aiptx.v1.AIPTX/WatchScan"
+	AIPTX_ListFindings_FullMethodName  = "/aiptx.v1.AIPTX/ListFindings"
+	AIPTX_GetFinding_FullMethodName    = "/aiptx.v1.AIPTX/GetFinding"
+)
+
+// AIPTXClient is the client API for AIPTX service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AIPTX exposes health, scanning and findings operations.
+type AIPTXClient interface {
+	// Health returns the server health status.
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthStatus, error)
+	// StartScan starts a new security scan.
+	StartScan(ctx context.Context, in *StartScanRequest, opts ...grpc.CallOption) (*ScanStatus, error)
+	// GetScanStatus returns the status of a scan.
+	GetScanStatus(ctx context.Context, in *GetScanStatusRequest, opts ...grpc.CallOption) (*ScanStatus, error)
+	// WatchScan streams events for a scan until it finishes.
+	WatchScan(ctx context.Context, in *WatchScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScanEvent], error)
+	// ListFindings returns findings matching a filter.
+	ListFindings(ctx context.Context, in *ListFindingsRequest, opts ...grpc.CallOption) (*ListFindingsResponse, error)
+	// GetFinding returns a finding by ID.
+	GetFinding(ctx context.Context, in *GetFindingRequest, opts ...grpc.CallOption) (*Finding, error)
+}
+
+type aIPTXClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAIPTXClient(cc grpc.ClientConnInterface) AIPTXClient {
+	return &aIPTXClient{cc}
+}
+
+func (c *aIPTXClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthStatus)
+	err := c.cc.Invoke(ctx, AIPTX_Health_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aIPTXClient) StartScan(ctx context.Context, in *StartScanRequest, opts ...grpc.CallOption) (*ScanStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanStatus)
+	err := c.cc.Invoke(ctx, AIPTX_StartScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aIPTXClient) GetScanStatus(ctx context.Context, in *GetScanStatusRequest, opts ...grpc.CallOption) (*ScanStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanStatus)
+	err := c.cc.Invoke(ctx, AIPTX_GetScanStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aIPTXClient) WatchScan(ctx context.Context, in *WatchScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScanEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AIPTX_ServiceDesc.Streams[0], AIPTX_WatchScan_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchScanRequest, ScanEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AIPTX_WatchScanClient = grpc.ServerStreamingClient[ScanEvent]
+
+func (c *aIPTXClient) ListFindings(ctx context.Context, in *ListFindingsRequest, opts ...grpc.CallOption) (*ListFindingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFindingsResponse)
+	err := c.cc.Invoke(ctx, AIPTX_ListFindings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aIPTXClient) GetFinding(ctx context.Context, in *GetFindingRequest, opts ...grpc.CallOption) (*Finding, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Finding)
+	err := c.cc.Invoke(ctx, AIPTX_GetFinding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AIPTXServer is the server API for AIPTX service.
+// All implementations must embed UnimplementedAIPTXServer
+// for forward compatibility.
+//
+// AIPTX exposes health, scanning and findings operations.
+type AIPTXServer interface {
+	// Health returns the server health status.
+	Health(context.Context, *HealthRequest) (*HealthStatus, error)
+	// StartScan starts a new security scan.
+	StartScan(context.Context, *StartScanRequest) (*ScanStatus, error)
+	// GetScanStatus returns the status of a scan.
+	GetScanStatus(context.Context, *GetScanStatusRequest) (*ScanStatus, error)
+	// WatchScan streams events for a scan until it finishes.
+	WatchScan(*WatchScanRequest, grpc.ServerStreamingServer[ScanEvent]) error
+	// ListFindings returns findings matching a filter.
+	ListFindings(context.Context, *ListFindingsRequest) (*ListFindingsResponse, error)
+	// GetFinding returns a finding by ID.
+	GetFinding(context.Context, *GetFindingRequest) (*Finding, error)
+	mustEmbedUnimplementedAIPTXServer()
+}
+
+// UnimplementedAIPTXServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAIPTXServer struct{}
+
+func (UnimplementedAIPTXServer) Health(context.Context, *HealthRequest) (*HealthStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedAIPTXServer) StartScan(context.Context, *StartScanRequest) (*ScanStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartScan not implemented")
+}
+func (UnimplementedAIPTXServer) GetScanStatus(context.Context, *GetScanStatusRequest) (*ScanStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScanStatus not implemented")
+}
+func (UnimplementedAIPTXServer) WatchScan(*WatchScanRequest, grpc.ServerStreamingServer[ScanEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchScan not implemented")
+}
+func (UnimplementedAIPTXServer) ListFindings(context.Context, *ListFindingsRequest) (*ListFindingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFindings not implemented")
+}
+func (UnimplementedAIPTXServer) GetFinding(context.Context, *GetFindingRequest) (*Finding, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFinding not implemented")
+}
+func (UnimplementedAIPTXServer) mustEmbedUnimplementedAIPTXServer() {}
+func (UnimplementedAIPTXServer) testEmbeddedByValue()               {}
+
+// UnsafeAIPTXServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AIPTXServer will
+// result in compilation errors.
+type UnsafeAIPTXServer interface {
+	mustEmbedUnimplementedAIPTXServer()
+}
+
+func RegisterAIPTXServer(s grpc.ServiceRegistrar, srv AIPTXServer) {
+	// If the following call pancis, it indicates UnimplementedAIPTXServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AIPTX_ServiceDesc, srv)
+}
+
+func _AIPTX_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AIPTXServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AIPTX_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AIPTXServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AIPTX_StartScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AIPTXServer).StartScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AIPTX_StartScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AIPTXServer).StartScan(ctx, req.(*StartScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AIPTX_GetScanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AIPTXServer).GetScanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AIPTX_GetScanStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AIPTXServer).GetScanStatus(ctx, req.(*GetScanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AIPTX_WatchScan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AIPTXServer).WatchScan(m, &grpc.GenericServerStream[WatchScanRequest, ScanEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AIPTX_WatchScanServer = grpc.ServerStreamingServer[ScanEvent]
+
+func _AIPTX_ListFindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AIPTXServer).ListFindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AIPTX_ListFindings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AIPTXServer).ListFindings(ctx, req.(*ListFindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AIPTX_GetFinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AIPTXServer).GetFinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AIPTX_GetFinding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AIPTXServer).GetFinding(ctx, req.(*GetFindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AIPTX_ServiceDesc is the grpc.ServiceDesc for AIPTX service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AIPTX_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aiptx.v1.AIPTX",
+	HandlerType: (*AIPTXServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Health",
+			Handler:    _AIPTX_Health_Handler,
+		},
+		{
+			MethodName: "StartScan",
+			Handler:    _AIPTX_StartScan_Handler,
+		},
+		{
+			MethodName: "GetScanStatus",
+			Handler:    _AIPTX_GetScanStatus_Handler,
+		},
+		{
+			MethodName: "ListFindings",
+			Handler:    _AIPTX_ListFindings_Handler,
+		},
+		{
+			MethodName: "GetFinding",
+			Handler:    _AIPTX_GetFinding_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchScan",
+			Handler:       _AIPTX_WatchScan_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "aiptx/v1/aiptx.proto",
+}
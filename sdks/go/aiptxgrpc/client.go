@@ -0,0 +1,283 @@
+// Package aiptxgrpc provides a gRPC client for AIPTX servers that expose the
+// gRPC interface.
+//
+// It offers the same operations as the REST client for health, scanning and
+// findings, returns the same aiptx types, and adds server-streamed scan
+// events, which are considerably cheaper than polling GetScanStatus:
+//
+//	client, err := aiptxgrpc.NewGRPCClient("aiptx.internal:9090", "api-key",
+//	    grpc.WithTransportCredentials(credentials.NewTLS(nil)))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Close()
+//
+//	status, err := client.GetScanStatus(ctx, scanID)
+//
+// The package lives in its own module so the core SDK stays free of the gRPC
+// dependency tree.
+package aiptxgrpc
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=github.com/aiptx/aiptx-go/aiptxgrpc --go-grpc_out=. --go-grpc_opt=module=github.com/aiptx/aiptx-go/aiptxgrpc aiptx/v1/aiptx.proto
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+	"github.com/aiptx/aiptx-go/aiptxgrpc/aiptxv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Client is an AIPTX gRPC client. It is safe for concurrent use.
+type Client struct {
+	conn   *grpc.ClientConn
+	api    aiptxv1.AIPTXClient
+	apiKey string
+}
+
+// NewGRPCClient connects to the AIPTX gRPC server at target. apiKey may be
+// empty; otherwise it is sent as a bearer token with every call. opts must
+// set transport credentials, such as
+// grpc.WithTransportCredentials(credentials.NewTLS(nil)), or
+// insecure.NewCredentials() for a plaintext connection; without them
+// NewGRPCClient returns an error.
+func NewGRPCClient(target, apiKey string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewGRPCClientFromConn(conn, apiKey), nil
+}
+
+// NewGRPCClientFromConn creates a client using an existing connection. Close
+// closes conn.
+func NewGRPCClientFromConn(conn *grpc.ClientConn, apiKey string) *Client {
+	return &Client{conn: conn, api: aiptxv1.NewAIPTXClient(conn), apiKey: apiKey}
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// outgoing attaches credentials to ctx.
+func (c *Client) outgoing(ctx context.Context) context.Context {
+	if c.apiKey == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.apiKey)
+}
+
+// Health returns the server health status.
+func (c *Client) Health(ctx context.Context) (*aiptx.HealthStatus, error) {
+	resp, err := c.api.Health(c.outgoing(ctx), &aiptxv1.HealthRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	health := &aiptx.HealthStatus{
+		Status:  resp.GetStatus(),
+		Version: resp.GetVersion(),
+		Uptime:  resp.GetUptime(),
+	}
+	health.Components.Database = resp.GetDatabase()
+	health.Components.LLM = resp.GetLlm()
+	health.Components.Scanners = resp.GetScanners()
+	return health, nil
+}
+
+// StartScan starts a new security scan.
+func (c *Client) StartScan(ctx context.Context, req *aiptx.ScanRequest) (*aiptx.ScanStatus, error) {
+	phases := make([]string, len(req.Phases))
+	for i, phase := range req.Phases {
+		phases[i] = string(phase)
+	}
+	resp, err := c.api.StartScan(c.outgoing(ctx), &aiptxv1.StartScanRequest{
+		Target:  req.Target,
+		Mode:    string(req.Mode),
+		Ai:      req.AI,
+		Exploit: req.Exploit,
+		Phases:  phases,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scanStatusFromProto(resp), nil
+}
+
+// GetScanStatus returns the status of a scan.
+func (c *Client) GetScanStatus(ctx context.Context, scanID string) (*aiptx.ScanStatus, error) {
+	resp, err := c.api.GetScanStatus(c.outgoing(ctx), &aiptxv1.GetScanStatusRequest{ScanId: scanID})
+	if err != nil {
+		return nil, err
+	}
+	return scanStatusFromProto(resp), nil
+}
+
+// ListFindings returns findings matching filter, which may be nil.
+func (c *Client) ListFindings(ctx context.Context, filter *aiptx.FindingsFilter) ([]aiptx.Finding, error) {
+	req := &aiptxv1.ListFindingsRequest{}
+	if filter != nil {
+		req.ProjectId = filter.ProjectID
+		req.MinSeverity = string(filter.MinSeverity)
+		req.Tool = filter.Tool
+		req.Verified = filter.Verified
+		req.FalsePositive = filter.FalsePositive
+		if filter.Severity != "" {
+			req.Severities = append(req.Severities, string(filter.Severity))
+		}
+		for _, sev := range filter.Severities {
+			req.Severities = append(req.Severities, string(sev))
+		}
+		if filter.Type != "" {
+			req.Types = append(req.Types, filter.Type)
+		}
+		req.Types = append(req.Types, filter.Types...)
+	}
+
+	resp, err := c.api.ListFindings(c.outgoing(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]aiptx.Finding, len(resp.GetFindings()))
+	for i, f := range resp.GetFindings() {
+		findings[i] = *findingFromProto(f)
+	}
+	return findings, nil
+}
+
+// GetFinding returns a finding by ID.
+func (c *Client) GetFinding(ctx context.Context, id int64) (*aiptx.Finding, error) {
+	resp, err := c.api.GetFinding(c.outgoing(ctx), &aiptxv1.GetFindingRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return findingFromProto(resp), nil
+}
+
+// =============================================================================
+// Scan Events
+// =============================================================================
+
+// Scan event types.
+const (
+	EventProgress  = "progress"
+	EventPhase     = "phase"
+	EventFinding   = "finding"
+	EventCompleted = "completed"
+)
+
+// ScanEvent is an event streamed by WatchScan.
+type ScanEvent struct {
+	Type    string
+	Status  *aiptx.ScanStatus
+	Finding *aiptx.Finding
+	Time    time.Time
+}
+
+// ScanEventStream reads events from a WatchScan call.
+type ScanEventStream struct {
+	stream aiptxv1.AIPTX_WatchScanClient
+}
+
+// Recv returns the next event. It returns io.EOF once the scan has finished
+// and the server has closed the stream.
+func (s *ScanEventStream) Recv() (*ScanEvent, error) {
+	ev, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	event := &ScanEvent{Type: ev.GetType(), Time: timeFromProto(ev.GetTime())}
+	if ev.GetStatus() != nil {
+		event.Status = scanStatusFromProto(ev.GetStatus())
+	}
+	if ev.GetFinding() != nil {
+		event.Finding = findingFromProto(ev.GetFinding())
+	}
+	return event, nil
+}
+
+// WatchScan streams events for a scan until it finishes or ctx is cancelled.
+func (c *Client) WatchScan(ctx context.Context, scanID string) (*ScanEventStream, error) {
+	stream, err := c.api.WatchScan(c.outgoing(ctx), &aiptxv1.WatchScanRequest{ScanId: scanID})
+	if err != nil {
+		return nil, err
+	}
+	return &ScanEventStream{stream: stream}, nil
+}
+
+// WaitForScan blocks until the scan completes and returns its final status.
+func (c *Client) WaitForScan(ctx context.Context, scanID string) (*aiptx.ScanStatus, error) {
+	stream, err := c.WatchScan(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *aiptx.ScanStatus
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			if last == nil {
+				return c.GetScanStatus(ctx, scanID)
+			}
+			return last, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ev.Status != nil {
+			last = ev.Status
+		}
+		if ev.Type == EventCompleted && last != nil {
+			return last, nil
+		}
+	}
+}
+
+// =============================================================================
+// Conversions
+// =============================================================================
+
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func scanStatusFromProto(s *aiptxv1.ScanStatus) *aiptx.ScanStatus {
+	return &aiptx.ScanStatus{
+		ID:            s.GetId(),
+		Status:        aiptx.ScanState(s.GetStatus()),
+		Phase:         aiptx.Phase(s.GetPhase()),
+		Progress:      int(s.GetProgress()),
+		FindingsCount: int(s.GetFindingsCount()),
+		StartedAt:     timeFromProto(s.GetStartedAt()),
+		CompletedAt:   timeFromProto(s.GetCompletedAt()),
+		Error:         s.GetError(),
+	}
+}
+
+func findingFromProto(f *aiptxv1.Finding) *aiptx.Finding {
+	return &aiptx.Finding{
+		ID:            f.GetId(),
+		ProjectID:     f.GetProjectId(),
+		SessionID:     f.GetSessionId(),
+		Type:          f.GetType(),
+		Value:         f.GetValue(),
+		Description:   f.GetDescription(),
+		Severity:      aiptx.Severity(f.GetSeverity()),
+		Phase:         aiptx.Phase(f.GetPhase()),
+		Tool:          f.GetTool(),
+		RawOutput:     f.GetRawOutput(),
+		Verified:      f.GetVerified(),
+		FalsePositive: f.GetFalsePositive(),
+		DiscoveredAt:  timeFromProto(f.GetDiscoveredAt()),
+	}
+}
@@ -0,0 +1,77 @@
+package aiptxgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/aiptx/aiptx-go/aiptxgrpc/aiptxv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeServer struct {
+	aiptxv1.UnimplementedAIPTXServer
+	auth string
+}
+
+func (s *fakeServer) GetScanStatus(ctx context.Context, req *aiptxv1.GetScanStatusRequest) (*aiptxv1.ScanStatus, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("authorization"); len(v) > 0 {
+		s.auth = v[0]
+	}
+	return &aiptxv1.ScanStatus{Id: req.GetScanId(), Status: "running", Progress: 40}, nil
+}
+
+func (s *fakeServer) WatchScan(req *aiptxv1.WatchScanRequest, stream aiptxv1.AIPTX_WatchScanServer) error {
+	stream.Send(&aiptxv1.ScanEvent{Type: EventFinding, Finding: &aiptxv1.Finding{Id: 1, Severity: "high"}})
+	stream.Send(&aiptxv1.ScanEvent{Type: EventCompleted, Status: &aiptxv1.ScanStatus{Id: req.GetScanId(), Status: "completed", Progress: 100}})
+	return nil
+}
+
+func newTestClient(t *testing.T, srv *fakeServer) *Client {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	aiptxv1.RegisterAIPTXServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	client, err := NewGRPCClient("passthrough:///bufnet", "test-key",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestGetScanStatus(t *testing.T) {
+	srv := &fakeServer{}
+	client := newTestClient(t, srv)
+
+	status, err := client.GetScanStatus(context.Background(), "scan-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.ID != "scan-1" || status.Progress != 40 {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	if srv.auth != "Bearer test-key" {
+		t.Errorf("Expected bearer credentials, got %q", srv.auth)
+	}
+}
+
+func TestWaitForScan(t *testing.T) {
+	client := newTestClient(t, &fakeServer{})
+
+	status, err := client.WaitForScan(context.Background(), "scan-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.Status != "completed" {
+		t.Errorf("Expected completed status, got %s", status.Status)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/aiptx/aiptx-go/aiptxssh
+
+go 1.21
+
+require (
+	github.com/aiptx/aiptx-go v0.1.1-0.20261016133627-61f8e54875ca
+	golang.org/x/crypto v0.33.0
+)
+
+require golang.org/x/sys v0.30.0 // indirect
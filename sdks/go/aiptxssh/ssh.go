@@ -0,0 +1,71 @@
+// Package aiptxssh connects to AIPTX servers through an SSH connection, for
+// servers on jump boxes that do not expose a TCP port:
+//
+//	config := &ssh.ClientConfig{
+//	    User:            "deploy",
+//	    Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+//	    HostKeyCallback: knownHosts,
+//	}
+//	client, err := aiptxssh.NewClientOverSSH("jump.example.com:22", config,
+//	    "unix:///var/run/aiptx.sock", aiptx.WithAPIKey(apiKey))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Close()
+//
+//	health, err := client.Health()
+//
+// The package lives in its own module so the core SDK stays free of the SSH
+// dependency.
+package aiptxssh
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/aiptx/aiptx-go"
+	"golang.org/x/crypto/ssh"
+)
+
+// Client is an AIPTX client whose connections are tunnelled over SSH.
+type Client struct {
+	*aiptx.Client
+
+	ssh *ssh.Client
+}
+
+// NewClientOverSSH connects to the SSH server at sshAddr and returns a client
+// for the AIPTX server at remoteURL as seen from that host. remoteURL may be
+// an http(s) URL or a unix:// socket path on the SSH host; sockets require an
+// OpenSSH server with stream local forwarding enabled. opts configure the
+// client as for aiptx.NewClient.
+func NewClientOverSSH(sshAddr string, config *ssh.ClientConfig, remoteURL string, opts ...aiptx.ClientOption) (*Client, error) {
+	conn, err := ssh.Dial("tcp", sshAddr, config)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromSSH(conn, remoteURL, opts...), nil
+}
+
+// NewClientFromSSH creates a client using an existing SSH connection. Close
+// closes conn.
+func NewClientFromSSH(conn *ssh.Client, remoteURL string, opts ...aiptx.ClientOption) *Client {
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return conn.DialContext(ctx, network, addr)
+	}
+	if path, ok := strings.CutPrefix(remoteURL, "unix://"); ok {
+		remoteURL = "http://localhost"
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return conn.DialContext(ctx, "unix", path)
+		}
+	}
+
+	opts = append([]aiptx.ClientOption{aiptx.WithDialer(dial)}, opts...)
+	return &Client{Client: aiptx.NewClient(remoteURL, opts...), ssh: conn}
+}
+
+// Close closes the SSH connection.
+func (c *Client) Close() error {
+	return c.ssh.Close()
+}
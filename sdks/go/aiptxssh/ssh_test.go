@@ -0,0 +1,115 @@
+package aiptxssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshServer starts an SSH server that accepts any password and forwards
+// direct-tcpip channels, returning its address.
+func sshServer(t *testing.T) string {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(ssh.ConnMetadata, []byte) (*ssh.Permissions, error) { return nil, nil },
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for ch := range chans {
+		if ch.ChannelType() != "direct-tcpip" {
+			ch.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		// The payload starts with the length-prefixed target host and its port.
+		payload := ch.ExtraData()
+		n := binary.BigEndian.Uint32(payload)
+		host := string(payload[4 : 4+n])
+		port := binary.BigEndian.Uint32(payload[4+n:])
+
+		target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
+		if err != nil {
+			ch.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, requests, err := ch.Accept()
+		if err != nil {
+			target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(requests)
+		go func() {
+			io.Copy(channel, target)
+			channel.Close()
+		}()
+		go func() {
+			io.Copy(target, channel)
+			target.Close()
+		}()
+	}
+}
+
+func TestNewClientOverSSH(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("Expected API key to be sent")
+		}
+		w.Write([]byte(`{"status": "ok", "version": "1.0.0"}`))
+	}))
+	defer server.Close()
+
+	config := &ssh.ClientConfig{
+		User:            "test",
+		Auth:            []ssh.AuthMethod{ssh.Password("secret")},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	}
+	client, err := NewClientOverSSH(sshServer(t), config, server.URL, aiptx.WithAPIKey("key"))
+	if err != nil {
+		t.Fatalf("NewClientOverSSH failed: %v", err)
+	}
+	defer client.Close()
+
+	health, err := client.Health()
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if health.Version != "1.0.0" {
+		t.Errorf("Expected version 1.0.0, got %s", health.Version)
+	}
+}
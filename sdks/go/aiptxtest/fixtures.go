@@ -0,0 +1,424 @@
+// Package aiptxtest provides fixtures and helpers for testing code that uses
+// the AIPTX SDK.
+//
+// Builders produce realistic fake resources with every field populated.
+// Override only what a test cares about:
+//
+//	finding := aiptxtest.NewFinding().WithSeverity(aiptx.SeverityHigh).Build()
+//	scan := aiptxtest.NewScan().Completed().Build()
+//
+// Generated data is deterministic: the same sequence of builder calls yields
+// the same values on every run. Tests that need independence from other
+// tests' calls should use their own Fixtures:
+//
+//	fx := aiptxtest.NewFixtures(42)
+//	findings := fx.Finding().WithProject(7).BuildN(100)
+package aiptxtest
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Epoch is the time around which fixture timestamps are generated.
+var Epoch = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+// Fixtures generates fake resources from a seeded random source. It is safe
+// for concurrent use.
+type Fixtures struct {
+	mu     sync.Mutex
+	rng    *rand.Rand
+	nextID int64
+}
+
+// NewFixtures returns a generator seeded with seed.
+func NewFixtures(seed int64) *Fixtures {
+	return &Fixtures{rng: rand.New(rand.NewSource(seed))}
+}
+
+var defaults = NewFixtures(1)
+
+// Seed resets the generator used by the package-level builders.
+func Seed(seed int64) {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+	defaults.rng = rand.New(rand.NewSource(seed))
+	defaults.nextID = 0
+}
+
+// id returns the next resource ID.
+func (f *Fixtures) id() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	return f.nextID
+}
+
+// intn returns a random int in [0, n).
+func (f *Fixtures) intn(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rng.Intn(n)
+}
+
+// timeAfter returns a random time up to max after t, in whole seconds.
+func (f *Fixtures) timeAfter(t time.Time, max time.Duration) time.Time {
+	return t.Add(time.Duration(f.intn(int(max/time.Second))) * time.Second)
+}
+
+// =============================================================================
+// Sample Data
+// =============================================================================
+
+var targets = []string{
+	"example.com", "shop.example.com", "api.example.org", "staging.example.net",
+	"10.0.12.0/24", "portal.example.io",
+}
+
+// findingSamples are realistic combinations of finding fields.
+var findingSamples = []struct {
+	typ, value, description, tool string
+	phase                         aiptx.Phase
+	severity                      aiptx.Severity
+}{
+	{"vulnerability", "CVE-2021-44228", "Apache Log4j2 JNDI remote code execution", "nuclei", "scan", aiptx.SeverityCritical},
+	{"vulnerability", "CVE-2023-34362", "MOVEit Transfer SQL injection", "nuclei", "scan", aiptx.SeverityCritical},
+	{"sqli", "/search?q=", "Boolean-based blind SQL injection in q parameter", "sqlmap", "exploit", aiptx.SeverityHigh},
+	{"xss", "/profile?name=", "Reflected cross-site scripting in name parameter", "dalfox", "exploit", aiptx.SeverityMedium},
+	{"misconfiguration", "Strict-Transport-Security", "HSTS header not set", "nikto", "scan", aiptx.SeverityLow},
+	{"misconfiguration", "/server-status", "Apache server-status page exposed", "nuclei", "scan", aiptx.SeverityMedium},
+	{"open_port", "22/tcp", "OpenSSH 8.9p1 Ubuntu", "nmap", "recon", aiptx.SeverityInfo},
+	{"open_port", "3306/tcp", "MySQL 8.0.35 reachable from the internet", "nmap", "recon", aiptx.SeverityHigh},
+	{"subdomain", "dev.example.com", "Subdomain discovered via certificate transparency", "subfinder", "recon", aiptx.SeverityInfo},
+	{"technology", "nginx/1.18.0", "Outdated web server version disclosed", "httpx", "recon", aiptx.SeverityLow},
+}
+
+var (
+	phases        = []aiptx.Phase{aiptx.PhaseRecon, aiptx.PhaseScan, aiptx.PhaseExploit, aiptx.PhaseReport}
+	sessionStates = []aiptx.ScanState{aiptx.SessionPending, aiptx.ScanRunning, aiptx.ScanCompleted}
+)
+
+// =============================================================================
+// Findings
+// =============================================================================
+
+// FindingBuilder builds an aiptx.Finding.
+type FindingBuilder struct {
+	fx      *Fixtures
+	finding aiptx.Finding
+	mods    []func(*aiptx.Finding)
+}
+
+// NewFinding starts a finding from the default generator.
+func NewFinding() *FindingBuilder {
+	return defaults.Finding()
+}
+
+// Finding starts a finding with realistic random values.
+func (f *Fixtures) Finding() *FindingBuilder {
+	return &FindingBuilder{fx: f, finding: f.finding()}
+}
+
+func (f *Fixtures) finding() aiptx.Finding {
+	sample := findingSamples[f.intn(len(findingSamples))]
+	return aiptx.Finding{
+		ID:           f.id(),
+		ProjectID:    1,
+		SessionID:    1,
+		Type:         sample.typ,
+		Value:        sample.value,
+		Description:  sample.description,
+		Severity:     sample.severity,
+		Phase:        sample.phase,
+		Tool:         sample.tool,
+		RawOutput:    fmt.Sprintf("[%s] [%s] %s", sample.tool, sample.severity, sample.value),
+		DiscoveredAt: f.timeAfter(Epoch, 24*time.Hour),
+	}
+}
+
+func (b *FindingBuilder) with(mod func(*aiptx.Finding)) *FindingBuilder {
+	b.mods = append(b.mods, mod)
+	return b
+}
+
+// WithID sets the finding ID.
+func (b *FindingBuilder) WithID(id int64) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.ID = id })
+}
+
+// WithProject sets the project ID.
+func (b *FindingBuilder) WithProject(id int64) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.ProjectID = id })
+}
+
+// WithSession sets the session ID.
+func (b *FindingBuilder) WithSession(id int64) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.SessionID = id })
+}
+
+// WithSeverity sets the severity.
+func (b *FindingBuilder) WithSeverity(severity aiptx.Severity) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.Severity = severity })
+}
+
+// WithType sets the finding type and value.
+func (b *FindingBuilder) WithType(typ, value string) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.Type, f.Value = typ, value })
+}
+
+// WithTool sets the tool and phase that produced the finding.
+func (b *FindingBuilder) WithTool(tool string, phase aiptx.Phase) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.Tool, f.Phase = tool, phase })
+}
+
+// WithKillChainStage sets the kill chain stage of the finding.
+func (b *FindingBuilder) WithKillChainStage(stage aiptx.KillChainStage) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.KillChainStage = stage })
+}
+
+// WithDiscoveredAt sets the discovery time.
+func (b *FindingBuilder) WithDiscoveredAt(t time.Time) *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.DiscoveredAt = t })
+}
+
+// Verified marks the finding as verified.
+func (b *FindingBuilder) Verified() *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.Verified = true })
+}
+
+// FalsePositive marks the finding as a false positive.
+func (b *FindingBuilder) FalsePositive() *FindingBuilder {
+	return b.with(func(f *aiptx.Finding) { f.FalsePositive = true })
+}
+
+// Build returns the finding.
+func (b *FindingBuilder) Build() aiptx.Finding {
+	return b.apply(b.finding)
+}
+
+// BuildN returns n findings with the builder's settings. Fields that were
+// not set explicitly, including the ID, vary between them.
+func (b *FindingBuilder) BuildN(n int) []aiptx.Finding {
+	findings := make([]aiptx.Finding, n)
+	for i := range findings {
+		if i == 0 {
+			findings[i] = b.Build()
+		} else {
+			findings[i] = b.apply(b.fx.finding())
+		}
+	}
+	return findings
+}
+
+func (b *FindingBuilder) apply(f aiptx.Finding) aiptx.Finding {
+	for _, mod := range b.mods {
+		mod(&f)
+	}
+	return f
+}
+
+// =============================================================================
+// Projects
+// =============================================================================
+
+// ProjectBuilder builds an aiptx.Project.
+type ProjectBuilder struct {
+	project aiptx.Project
+}
+
+// NewProject starts a project from the default generator.
+func NewProject() *ProjectBuilder {
+	return defaults.Project()
+}
+
+// Project starts a project with realistic random values.
+func (f *Fixtures) Project() *ProjectBuilder {
+	id := f.id()
+	target := targets[f.intn(len(targets))]
+	created := f.timeAfter(Epoch.Add(-30*24*time.Hour), 30*24*time.Hour)
+	return &ProjectBuilder{project: aiptx.Project{
+		ID:          id,
+		Name:        fmt.Sprintf("%s assessment %d", target, id),
+		Target:      target,
+		Description: "External penetration test",
+		Scope:       []string{target},
+		CreatedAt:   created,
+		UpdatedAt:   f.timeAfter(created, 7*24*time.Hour),
+	}}
+}
+
+// WithID sets the project ID.
+func (b *ProjectBuilder) WithID(id int64) *ProjectBuilder {
+	b.project.ID = id
+	return b
+}
+
+// WithName sets the project name.
+func (b *ProjectBuilder) WithName(name string) *ProjectBuilder {
+	b.project.Name = name
+	return b
+}
+
+// WithTarget sets the target and scopes the project to it.
+func (b *ProjectBuilder) WithTarget(target string) *ProjectBuilder {
+	b.project.Target = target
+	b.project.Scope = []string{target}
+	return b
+}
+
+// WithScope sets the project scope.
+func (b *ProjectBuilder) WithScope(scope ...string) *ProjectBuilder {
+	b.project.Scope = scope
+	return b
+}
+
+// Build returns the project.
+func (b *ProjectBuilder) Build() aiptx.Project {
+	p := b.project
+	p.Scope = append([]string(nil), p.Scope...)
+	return p
+}
+
+// =============================================================================
+// Sessions
+// =============================================================================
+
+// SessionBuilder builds an aiptx.Session.
+type SessionBuilder struct {
+	session aiptx.Session
+}
+
+// NewSession starts a session from the default generator.
+func NewSession() *SessionBuilder {
+	return defaults.Session()
+}
+
+// Session starts a session with realistic random values.
+func (f *Fixtures) Session() *SessionBuilder {
+	id := f.id()
+	created := f.timeAfter(Epoch, 12*time.Hour)
+	s := aiptx.Session{
+		ID:            id,
+		ProjectID:     1,
+		Name:          fmt.Sprintf("session-%d", id),
+		Phase:         phases[f.intn(len(phases))],
+		Status:        sessionStates[f.intn(len(sessionStates))],
+		MaxIterations: 10,
+		CreatedAt:     created,
+	}
+	if s.Status != "pending" {
+		s.StartedAt = created.Add(time.Minute)
+		s.Iteration = 1 + f.intn(s.MaxIterations)
+	}
+	if s.Status == "completed" {
+		s.Phase = "report"
+		s.Iteration = s.MaxIterations
+		s.CompletedAt = f.timeAfter(s.StartedAt, 2*time.Hour)
+	}
+	return &SessionBuilder{session: s}
+}
+
+// WithID sets the session ID.
+func (b *SessionBuilder) WithID(id int64) *SessionBuilder {
+	b.session.ID = id
+	return b
+}
+
+// WithProject sets the project ID.
+func (b *SessionBuilder) WithProject(id int64) *SessionBuilder {
+	b.session.ProjectID = id
+	return b
+}
+
+// WithPhase sets the current phase.
+func (b *SessionBuilder) WithPhase(phase aiptx.Phase) *SessionBuilder {
+	b.session.Phase = phase
+	return b
+}
+
+// WithStatus sets the status.
+func (b *SessionBuilder) WithStatus(status aiptx.ScanState) *SessionBuilder {
+	b.session.Status = status
+	return b
+}
+
+// Build returns the session.
+func (b *SessionBuilder) Build() aiptx.Session {
+	return b.session
+}
+
+// =============================================================================
+// Scans
+// =============================================================================
+
+// ScanBuilder builds an aiptx.ScanStatus.
+type ScanBuilder struct {
+	scan aiptx.ScanStatus
+}
+
+// NewScan starts a running scan from the default generator.
+func NewScan() *ScanBuilder {
+	return defaults.Scan()
+}
+
+// Scan starts a running scan with realistic random values.
+func (f *Fixtures) Scan() *ScanBuilder {
+	return &ScanBuilder{scan: aiptx.ScanStatus{
+		ID:            fmt.Sprintf("scan-%06d", f.id()),
+		Status:        "running",
+		Phase:         phases[f.intn(len(phases)-1)],
+		Progress:      f.intn(100),
+		FindingsCount: f.intn(50),
+		StartedAt:     f.timeAfter(Epoch, 12*time.Hour),
+	}}
+}
+
+// WithID sets the scan ID.
+func (b *ScanBuilder) WithID(id string) *ScanBuilder {
+	b.scan.ID = id
+	return b
+}
+
+// WithPhase sets the current phase.
+func (b *ScanBuilder) WithPhase(phase aiptx.Phase) *ScanBuilder {
+	b.scan.Phase = phase
+	return b
+}
+
+// WithProgress sets the progress percentage.
+func (b *ScanBuilder) WithProgress(progress int) *ScanBuilder {
+	b.scan.Progress = progress
+	return b
+}
+
+// WithFindings sets the number of findings.
+func (b *ScanBuilder) WithFindings(n int) *ScanBuilder {
+	b.scan.FindingsCount = n
+	return b
+}
+
+// Completed marks the scan as completed.
+func (b *ScanBuilder) Completed() *ScanBuilder {
+	b.scan.Status = "completed"
+	b.scan.Phase = "report"
+	b.scan.Progress = 100
+	b.scan.CompletedAt = b.scan.StartedAt.Add(45 * time.Minute)
+	return b
+}
+
+// Failed marks the scan as failed with the given error message.
+func (b *ScanBuilder) Failed(message string) *ScanBuilder {
+	b.scan.Status = "failed"
+	b.scan.Error = message
+	b.scan.CompletedAt = b.scan.StartedAt.Add(10 * time.Minute)
+	return b
+}
+
+// Build returns the scan status.
+func (b *ScanBuilder) Build() aiptx.ScanStatus {
+	return b.scan
+}
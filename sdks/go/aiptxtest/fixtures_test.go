@@ -0,0 +1,76 @@
+package aiptxtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestFindingBuilder(t *testing.T) {
+	f := NewFinding().WithSeverity(aiptx.SeverityHigh).WithProject(7).Verified().Build()
+
+	if f.Severity != "high" {
+		t.Errorf("Expected severity 'high', got '%s'", f.Severity)
+	}
+	if f.ProjectID != 7 || !f.Verified {
+		t.Errorf("Expected project 7 and verified, got %+v", f)
+	}
+	if f.ID == 0 || f.Type == "" || f.Tool == "" || f.DiscoveredAt.IsZero() {
+		t.Errorf("Expected populated finding, got %+v", f)
+	}
+}
+
+func TestBuildN(t *testing.T) {
+	findings := NewFixtures(1).Finding().WithSeverity(aiptx.SeverityLow).BuildN(20)
+
+	ids := map[int64]bool{}
+	values := map[string]bool{}
+	for _, f := range findings {
+		if f.Severity != "low" {
+			t.Errorf("Expected severity 'low', got '%s'", f.Severity)
+		}
+		ids[f.ID] = true
+		values[f.Value] = true
+	}
+	if len(ids) != 20 {
+		t.Errorf("Expected 20 distinct IDs, got %d", len(ids))
+	}
+	if len(values) < 2 {
+		t.Errorf("Expected values to vary, got %v", values)
+	}
+}
+
+func TestFixturesDeterministic(t *testing.T) {
+	build := func() []interface{} {
+		fx := NewFixtures(42)
+		return []interface{}{
+			fx.Project().Build(),
+			fx.Session().Build(),
+			fx.Scan().Build(),
+			fx.Finding().BuildN(5),
+		}
+	}
+	if a, b := build(), build(); !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected identical fixtures for the same seed")
+	}
+
+	Seed(3)
+	first := NewFinding().Build()
+	Seed(3)
+	if second := NewFinding().Build(); !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected Seed to reset the default generator")
+	}
+}
+
+func TestScanBuilder(t *testing.T) {
+	scan := NewScan().Completed().Build()
+	if scan.Status != "completed" || scan.Progress != 100 || scan.CompletedAt.Before(scan.StartedAt) {
+		t.Errorf("Unexpected completed scan: %+v", scan)
+	}
+
+	failed := NewScan().Failed("target unreachable").Build()
+	if failed.Status != "failed" || failed.Error != "target unreachable" {
+		t.Errorf("Unexpected failed scan: %+v", failed)
+	}
+}
@@ -0,0 +1,248 @@
+package aiptxtest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Schema Validation
+// =============================================================================
+
+// Samples holds example server responses, one JSON file per endpoint, e.g.
+// samples/finding.json. They are useful as canned responses for fake servers.
+//
+//go:embed samples/*.json
+var Samples embed.FS
+
+// Sample returns the sample response with the given name, e.g. "finding".
+// It panics if there is no such sample.
+func Sample(name string) []byte {
+	data, err := Samples.ReadFile("samples/" + name + ".json")
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// SchemaError lists the differences between a JSON document and the SDK type
+// it was validated against.
+type SchemaError struct {
+	Type     string
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: schema mismatch: %s", e.Type, strings.Join(e.Problems, "; "))
+}
+
+// Validate checks that data, a server response, matches the shape of v, the
+// SDK type it decodes into (e.g. &aiptx.Finding{} or &[]aiptx.Finding{}). It
+// returns a *SchemaError listing fields the SDK does not know, fields it
+// expects but the response lacks, and values of the wrong JSON type. Run it
+// against your own server's responses to catch schema drift before a silent
+// decoding difference reaches production:
+//
+//	body, _ := io.ReadAll(resp.Body)
+//	if err := aiptxtest.Validate(body, &aiptx.ScanStatus{}); err != nil {
+//	    t.Error(err)
+//	}
+//
+// Fields tagged omitempty may be absent.
+func Validate(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var problems []string
+	validate(&problems, "$", doc, t)
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return &SchemaError{Type: t.String(), Problems: problems}
+	}
+	return nil
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// validate appends the problems found in doc at path, compared to t.
+func validate(problems *[]string, path string, doc interface{}, t reflect.Type) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if t.Kind() == reflect.Ptr {
+		if doc == nil {
+			return
+		}
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		s, ok := doc.(string)
+		if !ok {
+			report("expected timestamp string, got %s", jsonType(doc))
+		} else if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			report("invalid timestamp %q", s)
+		}
+		return
+	case t == rawMessageType || t.Kind() == reflect.Interface:
+		return
+	case t.Kind() != reflect.Struct && reflect.PtrTo(t).Implements(unmarshalerType):
+		// Struct types are checked field by field even if they decode
+		// themselves, as the SDK's response types do to keep unknown fields.
+		data, _ := json.Marshal(doc)
+		if err := json.Unmarshal(data, reflect.New(t).Interface()); err != nil {
+			report("%v", err)
+		}
+		return
+	}
+
+	if doc == nil {
+		switch t.Kind() {
+		case reflect.Slice, reflect.Map:
+			return
+		}
+		report("unexpected null for %s", t)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			report("expected object, got %s", jsonType(doc))
+			return
+		}
+		// Like encoding/json, match keys to fields case-insensitively when
+		// there is no exact match.
+		fields := jsonFields(t)
+		seen := make(map[string]bool)
+		for key, value := range obj {
+			name := key
+			f, ok := fields[key]
+			if !ok {
+				for n, candidate := range fields {
+					if strings.EqualFold(n, key) {
+						name, f, ok = n, candidate, true
+						break
+					}
+				}
+			}
+			if !ok {
+				report("unknown field %q", key)
+				continue
+			}
+			seen[name] = true
+			validate(problems, path+"."+key, value, f.typ)
+		}
+		for name, f := range fields {
+			if !seen[name] && !f.optional {
+				report("missing field %q", name)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			report("expected array, got %s", jsonType(doc))
+			return
+		}
+		for i, elem := range arr {
+			validate(problems, fmt.Sprintf("%s[%d]", path, i), elem, t.Elem())
+		}
+	case reflect.Map:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			report("expected object, got %s", jsonType(doc))
+			return
+		}
+		for key, value := range obj {
+			validate(problems, path+"."+key, value, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := doc.(string); !ok {
+			report("expected string, got %s", jsonType(doc))
+		}
+	case reflect.Bool:
+		if _, ok := doc.(bool); !ok {
+			report("expected boolean, got %s", jsonType(doc))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := doc.(json.Number)
+		if !ok {
+			report("expected integer, got %s", jsonType(doc))
+		} else if _, err := n.Int64(); err != nil {
+			report("expected integer, got %s", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := doc.(json.Number); !ok {
+			report("expected number, got %s", jsonType(doc))
+		}
+	}
+}
+
+type jsonField struct {
+	typ      reflect.Type
+	optional bool
+}
+
+// jsonFields returns the JSON fields of struct type t by name, including
+// those of embedded structs.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n, ef := range jsonFields(f.Type) {
+				fields[n] = ef
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = jsonField{typ: f.Type, optional: strings.Contains(opts, "omitempty")}
+	}
+	return fields
+}
+
+func jsonType(doc interface{}) string {
+	switch doc.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,118 @@
+package aiptxtest
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// sampleTypes maps each sample response to the type it decodes into.
+var sampleTypes = map[string]func() interface{}{
+	"health":      func() interface{} { return &aiptx.HealthStatus{} },
+	"diagnostics": func() interface{} { return &aiptx.ComponentDiagnostics{} },
+	"project":     func() interface{} { return &aiptx.Project{} },
+	"projects":    func() interface{} { return &[]aiptx.Project{} },
+	"session":     func() interface{} { return &aiptx.Session{} },
+	"finding":     func() interface{} { return &aiptx.Finding{} },
+	"findings":    func() interface{} { return &[]aiptx.Finding{} },
+	"evidence":    func() interface{} { return &[]aiptx.Evidence{} },
+	"scan_status": func() interface{} { return &aiptx.ScanStatus{} },
+	"report":      func() interface{} { return &aiptx.Report{} },
+	"upload":      func() interface{} { return &aiptx.Upload{} },
+	"tools":       func() interface{} { return &[]aiptx.Tool{} },
+	"events_poll": func() interface{} {
+		return &struct {
+			Events []aiptx.Event `json:"events"`
+		}{}
+	},
+}
+
+// TestGoldenDecode decodes every sample and compares the re-encoded result
+// with its golden file, so changes to how the SDK decodes responses show up
+// in review. Run with -update to accept changes.
+func TestGoldenDecode(t *testing.T) {
+	entries, err := Samples.ReadDir("samples")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		t.Run(name, func(t *testing.T) {
+			newValue, ok := sampleTypes[name]
+			if !ok {
+				t.Fatalf("No type registered for sample %s", name)
+			}
+			v := newValue()
+			if err := json.Unmarshal(Sample(name), v); err != nil {
+				t.Fatalf("Decoding failed: %v", err)
+			}
+			got, err := json.MarshalIndent(v, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Reading golden file: %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Decoded %s differs from %s:\n%s", name, golden, got)
+			}
+		})
+	}
+}
+
+func TestValidateSamples(t *testing.T) {
+	for name, newValue := range sampleTypes {
+		if err := Validate(Sample(name), newValue()); err != nil {
+			t.Errorf("Sample %s: %v", name, err)
+		}
+	}
+}
+
+func TestValidateDrift(t *testing.T) {
+	data := []byte(`{
+		"id": 42,
+		"status": "running",
+		"progress": 12.5,
+		"findings_count": 3,
+		"started_at": "yesterday",
+		"eta_seconds": 600
+	}`)
+
+	err := Validate(data, &aiptx.ScanStatus{})
+	schemaErr, ok := err.(*SchemaError)
+	if !ok {
+		t.Fatalf("Expected *SchemaError, got %v", err)
+	}
+
+	want := []string{
+		`$.id: expected string, got number`,
+		`$.progress: expected integer, got 12.5`,
+		`$.started_at: invalid timestamp "yesterday"`,
+		`$: missing field "phase"`,
+		`$: unknown field "eta_seconds"`,
+	}
+	if len(schemaErr.Problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %v", len(want), schemaErr.Problems)
+	}
+	for i := range want {
+		if schemaErr.Problems[i] != want[i] {
+			t.Errorf("Expected problem %q, got %q", want[i], schemaErr.Problems[i])
+		}
+	}
+}
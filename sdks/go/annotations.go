@@ -0,0 +1,42 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Scan Annotations
+// =============================================================================
+
+// ScanAnnotation is an operator note recorded on a scan, such as "customer
+// asked to pause 14:00-15:00". Annotations appear in the timeline of the
+// scan's reports.
+type ScanAnnotation struct {
+	ID        int64     `json:"id"`
+	Note      string    `json:"note"`
+	Phase     Phase     `json:"phase,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AnnotateScan records a note on a scan. The server stamps it with the
+// current time and the scan's phase.
+func (c *Client) AnnotateScan(scanID, note string) (*ScanAnnotation, error) {
+	return c.AnnotateScanContext(context.Background(), scanID, note)
+}
+
+// AnnotateScanContext is like AnnotateScan but uses ctx for its request.
+func (c *Client) AnnotateScanContext(ctx context.Context, scanID, note string) (*ScanAnnotation, error) {
+	if strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("annotation note is empty")
+	}
+	body := map[string]string{"note": note}
+	var annotation ScanAnnotation
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/scans/%s/annotations", scanID), body, &annotation); err != nil {
+		return nil, err
+	}
+	return &annotation, nil
+}
@@ -0,0 +1,50 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAnnotateScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/scans/scan-1/annotations":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["note"] != "customer asked to pause 14:00-15:00" {
+				t.Errorf("Unexpected note %q", body["note"])
+			}
+			w.Write([]byte(`{"id": 3, "note": "customer asked to pause 14:00-15:00", "phase": "scan",
+				"created_by": "alice", "created_at": "2024-03-12T13:55:00Z"}`))
+		case r.Method == "GET" && r.URL.Path == "/scans/scan-1":
+			w.Write([]byte(`{"id": "scan-1", "status": "running", "annotations": [
+				{"id": 3, "note": "customer asked to pause 14:00-15:00", "created_at": "2024-03-12T13:55:00Z"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	annotation, err := client.AnnotateScan("scan-1", "customer asked to pause 14:00-15:00")
+	if err != nil {
+		t.Fatalf("AnnotateScan failed: %v", err)
+	}
+	if annotation.ID != 3 || annotation.Phase != "scan" || annotation.CreatedBy != "alice" {
+		t.Errorf("Unexpected annotation %+v", annotation)
+	}
+
+	status, err := client.GetScanStatus("scan-1")
+	if err != nil {
+		t.Fatalf("GetScanStatus failed: %v", err)
+	}
+	if len(status.Annotations) != 1 || status.Annotations[0].ID != 3 {
+		t.Errorf("Expected one annotation in scan status, got %+v", status.Annotations)
+	}
+
+	if _, err := client.AnnotateScan("scan-1", "  "); err == nil {
+		t.Errorf("Expected error for empty note")
+	}
+}
@@ -0,0 +1,582 @@
+package aiptx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Declarative Configuration
+// =============================================================================
+
+// Manifest describes the desired projects, schedules, webhooks and
+// suppression rules of an organization. It is usually written in YAML:
+//
+//	projects:
+//	  - name: payments
+//	    target: pay.example.com
+//	    scope: [pay.example.com, api.pay.example.com]
+//	    tags: [production]
+//	    schedules:
+//	      - name: nightly
+//	        cron: "0 2 * * *"
+//	        mode: full
+//	webhooks:
+//	  - url: https://hooks.example.com/aiptx
+//	    events: [finding.created]
+//	    secret: ${AIPTX_WEBHOOK_SECRET}
+//	suppressions:
+//	  - type: ssl_self_signed
+//	    pattern: "*.lab.example.com"
+//	    projects: [payments]
+//	    reason: Lab certificates are self-signed by design
+type Manifest struct {
+	Projects     []ManifestProject     `json:"projects,omitempty"`
+	Webhooks     []ManifestWebhook     `json:"webhooks,omitempty"`
+	Suppressions []ManifestSuppression `json:"suppressions,omitempty"`
+}
+
+// ManifestProject is a project in a manifest, identified by its name. Its
+// schedules are identified by name within the project. Projects missing from
+// a manifest are never deleted; set Delete to delete one.
+type ManifestProject struct {
+	Name        string             `json:"name"`
+	Target      string             `json:"target"`
+	Description string             `json:"description,omitempty"`
+	Scope       []string           `json:"scope,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Schedules   []ManifestSchedule `json:"schedules,omitempty"`
+	Delete      bool               `json:"delete,omitempty"`
+}
+
+// ManifestSchedule is a scan schedule of a manifest project. Enabled
+// defaults to true.
+type ManifestSchedule struct {
+	Name    string   `json:"name"`
+	Cron    string   `json:"cron"`
+	Mode    ScanMode `json:"mode,omitempty"`
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+// ManifestWebhook is a webhook in a manifest, identified by its URL. Secret
+// may reference environment variables as ${NAME}; since the server never
+// returns secrets, it is only sent when the webhook is created or otherwise
+// updated. Active defaults to true.
+type ManifestWebhook struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+	Secret string   `json:"secret,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// ManifestSuppression is a suppression rule in a manifest, identified by its
+// type and pattern. Projects names the projects the rule is limited to.
+// Expiry is an RFC 3339 time.
+type ManifestSuppression struct {
+	Type     string     `json:"type"`
+	Pattern  string     `json:"pattern"`
+	Projects []string   `json:"projects,omitempty"`
+	Expiry   *time.Time `json:"expiry,omitempty"`
+	Reason   string     `json:"reason"`
+}
+
+// ParseManifest reads a YAML or JSON manifest. Unknown fields are rejected,
+// so typos do not silently drop configuration.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		if data, err = yamlToJSON(data, reflect.TypeOf(Manifest{})); err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m Manifest
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return &m, m.validate()
+}
+
+// validate checks that resources are named and identified uniquely.
+func (m *Manifest) validate() error {
+	projects, deleted := map[string]bool{}, map[string]bool{}
+	for _, p := range m.Projects {
+		if p.Name == "" {
+			return fmt.Errorf("manifest project without name")
+		}
+		if projects[p.Name] {
+			return fmt.Errorf("manifest project %q is listed twice", p.Name)
+		}
+		projects[p.Name] = true
+		deleted[p.Name] = p.Delete
+		schedules := map[string]bool{}
+		for _, s := range p.Schedules {
+			if s.Name == "" || s.Cron == "" {
+				return fmt.Errorf("manifest project %q has a schedule without name or cron", p.Name)
+			}
+			if s.Mode != "" && !s.Mode.Valid() {
+				return fmt.Errorf("manifest project %q schedule %q has unknown mode %q", p.Name, s.Name, s.Mode)
+			}
+			if schedules[s.Name] {
+				return fmt.Errorf("manifest project %q lists schedule %q twice", p.Name, s.Name)
+			}
+			schedules[s.Name] = true
+		}
+	}
+	webhooks := map[string]bool{}
+	for _, w := range m.Webhooks {
+		if w.URL == "" || webhooks[w.URL] {
+			return fmt.Errorf("manifest webhook %q is empty or listed twice", w.URL)
+		}
+		webhooks[w.URL] = true
+	}
+	suppressions := map[string]bool{}
+	for _, s := range m.Suppressions {
+		if s.Reason == "" {
+			return fmt.Errorf("manifest suppression for %q has no reason", s.Pattern)
+		}
+		key := s.Type + " " + s.Pattern
+		if suppressions[key] {
+			return fmt.Errorf("manifest suppression %s %q is listed twice", s.Type, s.Pattern)
+		}
+		suppressions[key] = true
+		for _, name := range s.Projects {
+			if !projects[name] {
+				return fmt.Errorf("manifest suppression for %q names unknown project %q", s.Pattern, name)
+			}
+			if deleted[name] {
+				return fmt.Errorf("manifest suppression for %q names deleted project %q", s.Pattern, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Change actions.
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// Change is a change made, or planned in a dry run, by Apply.
+type Change struct {
+	Action string
+	// Kind is "project", "schedule", "webhook" or "suppression".
+	Kind string
+	// Name identifies the resource, e.g. "payments/nightly" for a schedule.
+	Name string
+	// Fields lists the changed fields of an update.
+	Fields []FieldChange
+}
+
+// FieldChange is a changed field of a resource, with its values formatted
+// for display.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// String returns the change as e.g. "create schedule payments/nightly".
+func (c Change) String() string {
+	return c.Action + " " + c.Kind + " " + c.Name
+}
+
+// fieldChanges collects the fields whose live and desired values differ.
+type fieldChanges []FieldChange
+
+func (f *fieldChanges) add(field string, old, new interface{}) {
+	o, n := formatField(old), formatField(new)
+	if o != n {
+		*f = append(*f, FieldChange{Field: field, Old: o, New: n})
+	}
+}
+
+func formatField(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	case *time.Time:
+		if v == nil {
+			return "never"
+		}
+		return v.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+// ApplyOption configures Apply.
+type ApplyOption func(*applyConfig)
+
+type applyConfig struct {
+	planOnly bool
+	validate bool
+	prune    bool
+}
+
+// WithPlanOnly makes Apply report the changes it would make without making
+// them.
+func WithPlanOnly() ApplyOption {
+	return func(c *applyConfig) {
+		c.planOnly = true
+	}
+}
+
+// WithValidation makes Apply send every change to the server in
+// validate-only mode, as with a client created with WithDryRun, so
+// the server checks the changes Apply reports without making them. Unlike
+// WithPlanOnly, it catches changes the server would reject. Schedules and
+// suppression rules of projects that do not exist yet are reported but not
+// validated.
+func WithValidation() ApplyOption {
+	return func(c *applyConfig) {
+		c.validate = true
+	}
+}
+
+// WithPrune makes Apply delete the schedules of manifest projects, webhooks
+// and suppression rules that are not in the manifest. Without it, Apply only
+// creates and updates.
+func WithPrune() ApplyOption {
+	return func(c *applyConfig) {
+		c.prune = true
+	}
+}
+
+// Apply reads a manifest from r and reconciles the server to match it,
+// returning the changes made. With WithPlanOnly it returns the changes it
+// would make instead. Apply stops at the first failing request, so a
+// failed apply can be completed by running it again.
+//
+// Apply is meant for GitOps-style management: keep the manifest in version
+// control and apply it from CI, with a dry run on pull requests.
+func Apply(ctx context.Context, client *Client, r io.Reader, opts ...ApplyOption) ([]Change, error) {
+	m, err := ParseManifest(r)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &applyConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return reconcile(ctx, client, m, cfg)
+}
+
+// Diff reports how the server differs from m without changing anything.
+// Schedules, webhooks and suppression rules missing from the manifest are
+// reported as deletions, as Apply with WithPrune would make them, so Diff
+// also detects resources added by hand. Run it periodically to catch drift:
+//
+//	plan, err := aiptx.Diff(ctx, client, manifest)
+//	if err == nil && !plan.Empty() {
+//	    fmt.Print(plan)
+//	}
+func Diff(ctx context.Context, client *Client, m *Manifest) (*Plan, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	changes, err := reconcile(ctx, client, m, &applyConfig{planOnly: true, prune: true})
+	if err != nil {
+		return nil, err
+	}
+	return &Plan{Changes: changes}, nil
+}
+
+func reconcile(ctx context.Context, client *Client, m *Manifest, cfg *applyConfig) ([]Change, error) {
+	if cfg.validate {
+		client = client.With(WithDryRun())
+	}
+	a := &applier{ctx: ctx, client: client, cfg: cfg, projectIDs: map[string]int64{}}
+	if err := a.projects(m.Projects); err != nil {
+		return a.changes, err
+	}
+	if err := a.webhooks(m.Webhooks); err != nil {
+		return a.changes, err
+	}
+	if err := a.suppressions(m.Suppressions); err != nil {
+		return a.changes, err
+	}
+	return a.changes, nil
+}
+
+// Plan is the difference between a manifest and the server, as the changes
+// that would reconcile them.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the server matches the manifest.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String formats the plan for people, with a line per change, the changed
+// fields of updates and a summary:
+//
+//	~ update project staging
+//	    target: "old.example.com" -> "staging.example.com"
+//	- delete webhook https://old.example.com
+//
+//	0 to create, 1 to update, 1 to delete.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes. The server matches the manifest.\n"
+	}
+	var b strings.Builder
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		symbol := map[string]string{ChangeCreate: "+", ChangeUpdate: "~", ChangeDelete: "-"}[c.Action]
+		fmt.Fprintf(&b, "%s %s\n", symbol, c)
+		for _, f := range c.Fields {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", f.Field, f.Old, f.New)
+		}
+	}
+	fmt.Fprintf(&b, "\n%d to create, %d to update, %d to delete.\n", counts[ChangeCreate], counts[ChangeUpdate], counts[ChangeDelete])
+	return b.String()
+}
+
+type applier struct {
+	ctx     context.Context
+	client  *Client
+	cfg     *applyConfig
+	changes []Change
+	// projectIDs maps project names to IDs, or 0 for projects only
+	// created in a dry run.
+	projectIDs map[string]int64
+}
+
+// change records a change and reports whether to make it.
+func (a *applier) change(action, kind, name string, fields ...FieldChange) bool {
+	a.changes = append(a.changes, Change{Action: action, Kind: kind, Name: name, Fields: fields})
+	return !a.cfg.planOnly
+}
+
+func (a *applier) projects(desired []ManifestProject) error {
+	byName := map[string]Project{}
+	it := a.client.IterateProjects(a.ctx, nil)
+	for it.Next() {
+		byName[it.Project().Name] = it.Project()
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	for _, want := range desired {
+		data := &ProjectCreate{Name: want.Name, Target: want.Target, Description: want.Description, Scope: want.Scope, Tags: want.Tags}
+		have, ok := byName[want.Name]
+		var fields fieldChanges
+		fields.add("target", have.Target, want.Target)
+		fields.add("description", have.Description, want.Description)
+		fields.add("scope", have.Scope, want.Scope)
+		fields.add("tags", have.Tags, want.Tags)
+		switch {
+		case want.Delete:
+			if ok && a.change(ChangeDelete, "project", want.Name) {
+				if err := a.client.DeleteProjectContext(a.ctx, have.ID); err != nil {
+					return err
+				}
+			}
+			continue
+		case !ok:
+			if a.change(ChangeCreate, "project", want.Name) {
+				created, err := a.client.CreateProjectContext(a.ctx, data)
+				if err != nil {
+					return err
+				}
+				have = *created
+			}
+		case len(fields) > 0:
+			if a.change(ChangeUpdate, "project", want.Name, fields...) {
+				if _, err := a.client.UpdateProjectContext(a.ctx, have.ID, data); err != nil {
+					return err
+				}
+			}
+		}
+		a.projectIDs[want.Name] = have.ID
+		if err := a.schedules(want, have.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *applier) schedules(project ManifestProject, projectID int64) error {
+	var existing []Schedule
+	if projectID != 0 {
+		var err error
+		if existing, err = a.client.ListSchedulesContext(a.ctx, projectID); err != nil {
+			return err
+		}
+	}
+	byName := map[string]Schedule{}
+	for _, s := range existing {
+		byName[s.Name] = s
+	}
+
+	for _, want := range project.Schedules {
+		data := &ScheduleCreate{Name: want.Name, Cron: want.Cron, Mode: want.Mode, Enabled: want.Enabled == nil || *want.Enabled}
+		name := project.Name + "/" + want.Name
+		have, ok := byName[want.Name]
+		delete(byName, want.Name)
+		var fields fieldChanges
+		fields.add("cron", have.Cron, data.Cron)
+		fields.add("mode", have.Mode, data.Mode)
+		fields.add("enabled", have.Enabled, data.Enabled)
+		switch {
+		case !ok:
+			if a.change(ChangeCreate, "schedule", name) && projectID != 0 {
+				if _, err := a.client.CreateScheduleContext(a.ctx, projectID, data); err != nil {
+					return err
+				}
+			}
+		case len(fields) > 0:
+			if a.change(ChangeUpdate, "schedule", name, fields...) {
+				if _, err := a.client.UpdateScheduleContext(a.ctx, have.ID, data); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if a.cfg.prune {
+		for _, s := range existing {
+			if _, stale := byName[s.Name]; stale && a.change(ChangeDelete, "schedule", project.Name+"/"+s.Name) {
+				if err := a.client.DeleteScheduleContext(a.ctx, s.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *applier) webhooks(desired []ManifestWebhook) error {
+	existing, err := a.client.ListWebhooksContext(a.ctx)
+	if err != nil {
+		return err
+	}
+	byURL := map[string]Webhook{}
+	for _, w := range existing {
+		byURL[w.URL] = w
+	}
+
+	for _, want := range desired {
+		data := &WebhookCreate{URL: want.URL, Events: want.Events, Secret: os.ExpandEnv(want.Secret), Active: want.Active == nil || *want.Active}
+		have, ok := byURL[want.URL]
+		delete(byURL, want.URL)
+		var fields fieldChanges
+		fields.add("events", have.Events, data.Events)
+		fields.add("active", have.Active, data.Active)
+		switch {
+		case !ok:
+			if a.change(ChangeCreate, "webhook", want.URL) {
+				if _, err := a.client.CreateWebhookContext(a.ctx, data); err != nil {
+					return err
+				}
+			}
+		case len(fields) > 0:
+			if a.change(ChangeUpdate, "webhook", want.URL, fields...) {
+				if _, err := a.client.UpdateWebhookContext(a.ctx, have.ID, data); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if a.cfg.prune {
+		for _, w := range existing {
+			if _, stale := byURL[w.URL]; stale && a.change(ChangeDelete, "webhook", w.URL) {
+				if err := a.client.DeleteWebhookContext(a.ctx, w.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// suppressions reconciles suppression rules. Rules cannot be updated, so a
+// changed rule is replaced.
+func (a *applier) suppressions(desired []ManifestSuppression) error {
+	existing, err := a.client.ListSuppressionRulesContext(a.ctx, nil)
+	if err != nil {
+		return err
+	}
+	byKey := map[string]SuppressionRule{}
+	for _, r := range existing {
+		byKey[r.Type+" "+r.Pattern] = r
+	}
+
+	for _, want := range desired {
+		rule := &SuppressionRule{Type: want.Type, Pattern: want.Pattern, Expiry: want.Expiry, Reason: want.Reason}
+		// Projects created only in a dry run or validation have no ID yet,
+		// so rules scoped to them are reported but not sent.
+		planned := false
+		for _, name := range want.Projects {
+			id := a.projectIDs[name]
+			planned = planned || id == 0
+			rule.Scope.ProjectIDs = append(rule.Scope.ProjectIDs, id)
+		}
+		key := want.Type + " " + want.Pattern
+		have, ok := byKey[key]
+		delete(byKey, key)
+		var fields fieldChanges
+		fields.add("scope", sortedIDs(have.Scope.ProjectIDs), sortedIDs(rule.Scope.ProjectIDs))
+		fields.add("expiry", have.Expiry, rule.Expiry)
+		fields.add("reason", strings.TrimSpace(have.Reason), strings.TrimSpace(rule.Reason))
+		if ok && len(fields) == 0 {
+			continue
+		}
+		action := ChangeUpdate
+		if !ok {
+			action, fields = ChangeCreate, nil
+		}
+		if !a.change(action, "suppression", key, fields...) || planned {
+			continue
+		}
+		if ok {
+			if err := a.client.DeleteSuppressionRuleContext(a.ctx, have.ID, "replaced by manifest"); err != nil {
+				return err
+			}
+		}
+		if _, err := a.client.CreateSuppressionRuleContext(a.ctx, rule); err != nil {
+			return err
+		}
+	}
+	if a.cfg.prune {
+		for _, r := range existing {
+			key := r.Type + " " + r.Pattern
+			if _, stale := byKey[key]; stale && a.change(ChangeDelete, "suppression", key) {
+				if err := a.client.DeleteSuppressionRuleContext(a.ctx, r.ID, "removed from manifest"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func sortedIDs(ids []int64) []int64 {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return ids
+}
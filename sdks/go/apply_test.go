@@ -0,0 +1,192 @@
+package aiptx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testManifest = `
+projects:
+  - name: payments
+    target: pay.example.com
+    tags: [production]
+    schedules:
+      - name: nightly
+        cron: "0 2 * * *"
+      - name: weekly
+        cron: "0 3 * * 0"
+        mode: full
+  - name: staging
+    target: staging.example.com
+  - name: legacy
+    delete: true
+webhooks:
+  - url: https://hooks.example.com/aiptx
+    events: [finding.created]
+    secret: ${TEST_WEBHOOK_SECRET}
+suppressions:
+  - type: ssl_self_signed
+    pattern: "*.lab.example.com"
+    projects: [payments]
+    reason: Lab certificates
+`
+
+func TestApply(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_SECRET", "s3cret")
+	var requests []string
+	var webhook map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /projects":
+			// Projects past the first page exist too.
+			if r.URL.Query().Get("cursor") == "" {
+				w.Header().Set("X-Next-Cursor", "page-2")
+				w.Write([]byte(`[
+					{"id": 1, "name": "payments", "target": "pay.example.com", "tags": ["production"]},
+					{"id": 2, "name": "staging", "target": "old.example.com"}
+				]`))
+				return
+			}
+			w.Write([]byte(`[{"id": 3, "name": "legacy"}]`))
+		case "GET /projects/1/schedules":
+			w.Write([]byte(`[
+				{"id": "s1", "name": "nightly", "cron": "0 2 * * *", "enabled": true},
+				{"id": "s2", "name": "hourly", "cron": "0 * * * *", "enabled": true}
+			]`))
+		case "GET /projects/2/schedules":
+			w.Write([]byte(`[]`))
+		case "GET /webhooks":
+			w.Write([]byte(`[{"id": "w1", "url": "https://old.example.com", "active": true}]`))
+		case "GET /suppressions":
+			w.Write([]byte(`[{"id": "r1", "type": "ssl_self_signed", "pattern": "*.lab.example.com", "reason": "Lab certificates"}]`))
+		case "POST /webhooks":
+			json.NewDecoder(r.Body).Decode(&webhook)
+			w.Write([]byte(`{"id": "w2"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	changes, err := Apply(context.Background(), client, strings.NewReader(testManifest), WithPlanOnly(), WithPrune())
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	var plan []string
+	for _, c := range changes {
+		plan = append(plan, c.String())
+	}
+	want := []string{
+		"create schedule payments/weekly",
+		"delete schedule payments/hourly",
+		"update project staging",
+		"delete project legacy",
+		"create webhook https://hooks.example.com/aiptx",
+		"delete webhook https://old.example.com",
+		"update suppression ssl_self_signed *.lab.example.com",
+	}
+	if strings.Join(plan, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected plan\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(plan, "\n"))
+	}
+	if len(requests) != 0 {
+		t.Fatalf("Expected no changes in a dry run, got %v", requests)
+	}
+
+	if _, err := Apply(context.Background(), client, strings.NewReader(testManifest)); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	wantRequests := []string{
+		"POST /projects/1/schedules",
+		"PUT /projects/2",
+		"DELETE /projects/3",
+		"POST /webhooks",
+		"DELETE /suppressions/r1",
+		"POST /suppressions",
+	}
+	if strings.Join(requests, "\n") != strings.Join(wantRequests, "\n") {
+		t.Errorf("Expected requests\n%s\ngot\n%s", strings.Join(wantRequests, "\n"), strings.Join(requests, "\n"))
+	}
+	if webhook["secret"] != "s3cret" || webhook["active"] != true {
+		t.Errorf("Expected webhook with expanded secret, got %v", webhook)
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	for _, doc := range []string{
+		"projects:\n  - name: a\n    targte: a.example.com\n",
+		"projects:\n  - name: a\n  - name: a\n",
+		"suppressions:\n  - type: x\n    pattern: y\n",
+		"suppressions:\n  - type: x\n    pattern: y\n    reason: z\n    projects: [missing]\n",
+		"suppressions:\n  - type: x\n    pattern: y\n    reason: z\n  - type: x\n    pattern: y\n    reason: w\n",
+		"projects:\n  - name: a\n    delete: true\nsuppressions:\n  - type: x\n    pattern: y\n    reason: z\n    projects: [a]\n",
+	} {
+		if _, err := ParseManifest(strings.NewReader(doc)); err == nil {
+			t.Errorf("Expected error for %q", doc)
+		}
+	}
+
+	m, err := ParseManifest(strings.NewReader(`{"webhooks": [{"url": "https://a.example.com"}]}`))
+	if err != nil || len(m.Webhooks) != 1 {
+		t.Errorf("Expected JSON manifest to parse, got %+v (%v)", m, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/projects":
+			w.Write([]byte(`[{"id": 1, "name": "payments", "target": "pay.example.com", "scope": ["a"]}]`))
+		case "/projects/1/schedules":
+			w.Write([]byte(`[{"id": "s1", "name": "nightly", "cron": "0 4 * * *", "enabled": true}]`))
+		case "/webhooks":
+			w.Write([]byte(`[{"id": "w1", "url": "https://manual.example.com", "active": true}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	m, err := ParseManifest(strings.NewReader(`
+projects:
+  - name: payments
+    target: pay.example.com
+    scope: [a, b]
+    schedules:
+      - name: nightly
+        cron: "0 2 * * *"
+`))
+	if err != nil {
+		t.Fatalf("ParseManifest failed: %v", err)
+	}
+	plan, err := Diff(context.Background(), client, m)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	want := `~ update project payments
+    scope: ["a"] -> ["a", "b"]
+~ update schedule payments/nightly
+    cron: "0 4 * * *" -> "0 2 * * *"
+- delete webhook https://manual.example.com
+
+0 to create, 2 to update, 1 to delete.
+`
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%s\ngot\n%s", want, plan)
+	}
+
+	if plan := (&Plan{}); !plan.Empty() || !strings.HasPrefix(plan.String(), "No changes") {
+		t.Errorf("Expected empty plan, got %q", plan)
+	}
+}
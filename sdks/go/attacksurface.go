@@ -0,0 +1,74 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Attack Surface
+// =============================================================================
+
+// Attack surface factors reported by the server. Servers may report factors
+// not listed here.
+const (
+	FactorOpenAdminPorts  = "open_admin_ports"
+	FactorOutdatedTLS     = "outdated_tls"
+	FactorExposedPanels   = "exposed_panels"
+	FactorExposedServices = "exposed_services"
+	FactorUnpatchedVulns  = "unpatched_vulnerabilities"
+)
+
+// AttackSurfaceScore is a composite exposure score for a project. Higher
+// scores mean more exposure, so projects can be ranked by Score.
+type AttackSurfaceScore struct {
+	ProjectID int64 `json:"project_id"`
+	// Score ranges from 0 (no exposure found) to 100.
+	Score float64 `json:"score"`
+	// Factors are the contributions to Score, largest first.
+	Factors    []ScoreFactor `json:"factors"`
+	ComputedAt time.Time     `json:"computed_at"`
+
+	unknown *rawFields
+}
+
+// ScoreFactor is one contribution to an AttackSurfaceScore, such as the
+// admin ports found open on a target.
+type ScoreFactor struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Count is the number of findings behind the factor.
+	Count int `json:"count"`
+	// Points is how much the factor adds to the score.
+	Points float64 `json:"points"`
+	// FindingIDs lists the findings behind the factor.
+	FindingIDs []int64 `json:"finding_ids,omitempty"`
+}
+
+// Factor returns the factor with the given name, or nil if it did not
+// contribute to the score.
+func (s *AttackSurfaceScore) Factor(name string) *ScoreFactor {
+	for i := range s.Factors {
+		if s.Factors[i].Name == name {
+			return &s.Factors[i]
+		}
+	}
+	return nil
+}
+
+// GetAttackSurfaceScore returns the current attack surface score of a
+// project.
+func (c *Client) GetAttackSurfaceScore(projectID int64) (*AttackSurfaceScore, error) {
+	return c.GetAttackSurfaceScoreContext(context.Background(), projectID)
+}
+
+// GetAttackSurfaceScoreContext is like GetAttackSurfaceScore but uses ctx for its
+// request.
+func (c *Client) GetAttackSurfaceScoreContext(ctx context.Context, projectID int64) (*AttackSurfaceScore, error) {
+	var score AttackSurfaceScore
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/projects/%d/attack-surface", projectID), nil, &score); err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
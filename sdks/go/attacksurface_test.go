@@ -0,0 +1,34 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAttackSurfaceScore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/42/attack-surface" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"project_id": 42, "score": 71.5, "factors": [
+			{"name": "open_admin_ports", "count": 3, "points": 40, "finding_ids": [1, 2, 3]},
+			{"name": "outdated_tls", "count": 1, "points": 31.5}
+		]}`))
+	}))
+	defer server.Close()
+
+	score, err := NewClient(server.URL).Project(42).AttackSurfaceScore()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 71.5 || len(score.Factors) != 2 {
+		t.Errorf("Expected score 71.5 with two factors, got %+v", score)
+	}
+	if f := score.Factor(FactorOpenAdminPorts); f == nil || f.Count != 3 || len(f.FindingIDs) != 3 {
+		t.Errorf("Expected open admin ports factor, got %+v", f)
+	}
+	if score.Factor(FactorExposedPanels) != nil {
+		t.Errorf("Expected no exposed panels factor")
+	}
+}
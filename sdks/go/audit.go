@@ -0,0 +1,129 @@
+package aiptx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Audit Trail
+// =============================================================================
+
+// AuditEntry records one request that could change server state, such as
+// starting a scan or deleting a project.
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	// APIKeyID identifies the API key the request was made with without
+	// revealing it: the first 16 hex digits of its SHA-256 hash. It is
+	// empty if the request was made without a key.
+	APIKeyID     string `json:"api_key_id,omitempty"`
+	Organization string `json:"organization,omitempty"`
+	OwnerTag     string `json:"owner_tag,omitempty"`
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	// PayloadSHA256 is the hex SHA-256 hash of the request body. It is
+	// empty for requests without a body and for streamed bodies, such as
+	// RestoreBackup archives.
+	PayloadSHA256 string `json:"payload_sha256,omitempty"`
+	// DryRun is set for requests of clients created with WithDryRun,
+	// which the server does not apply.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// AuditSink receives the audit trail of a client. Record is called once per
+// call, before the request is first sent, and may be called concurrently.
+type AuditSink interface {
+	Record(entry AuditEntry) error
+}
+
+// WithAuditSink makes the client record every request that could change
+// server state to sink before sending it, so that every action taken
+// against a target is logged client-side, independently of the server. If
+// sink fails, the request is not sent and the error is returned. Reads are
+// not recorded. Clients created with With share the sink.
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(c *Client) {
+		c.auditSink = sink
+	}
+}
+
+// AuditLog is an AuditSink writing each entry as a line of JSON.
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAuditLog returns an AuditLog writing to w.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+// OpenAuditLog opens the file at path for appending, creating it if needed,
+// and returns an AuditLog writing to it. Close the log when done.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuditLog(f), nil
+}
+
+// Record writes entry to the log with a single write.
+func (l *AuditLog) Record(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (l *AuditLog) Close() error {
+	if c, ok := l.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// audit records a request with the client's audit sink, if it has one and
+// the request could change server state. body is nil for requests without
+// a body or with a streamed one.
+func (c *Client) audit(ctx context.Context, method, path string, body []byte) error {
+	if c.auditSink == nil || !mutating(method) {
+		return nil
+	}
+	// Requests a read-only client refuses are never sent.
+	if err := c.checkReadOnly(method, path); err != nil {
+		return err
+	}
+	entry := AuditEntry{
+		Time:         time.Now().UTC(),
+		Organization: c.Organization,
+		OwnerTag:     c.ownerTag,
+		Method:       method,
+		URL:          c.ActiveURL() + path,
+		DryRun:       c.dryRun != nil,
+	}
+	if apiKey := c.apiKey(ctx); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		entry.APIKeyID = hex.EncodeToString(sum[:8])
+	}
+	if body != nil {
+		sum := sha256.Sum256(body)
+		entry.PayloadSHA256 = hex.EncodeToString(sum[:])
+	}
+	if err := c.auditSink.Record(entry); err != nil {
+		return fmt.Errorf("recording %s %s in audit trail: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package aiptx
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type failingSink struct{}
+
+func (failingSink) Record(AuditEntry) error { return errors.New("disk full") }
+
+func TestAuditLog(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client := NewClient(server.URL, WithAPIKey("secret-key"), WithOrganization("acme"), WithAuditSink(log))
+	client.GetProject(1)
+	client.CreateProject(&ProjectCreate{Name: "payments", Target: "pay.example.com"})
+	client.With(WithAPIKey("other")).DeleteProject(1)
+	log.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer f.Close()
+	var entries []AuditEntry
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("Invalid audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", entries)
+	}
+
+	create := entries[0]
+	payload := sha256.Sum256([]byte(bodies[0]))
+	if create.Method != "POST" || create.URL != server.URL+"/projects" || create.Organization != "acme" {
+		t.Errorf("Unexpected entry %+v", create)
+	}
+	if create.PayloadSHA256 != hex.EncodeToString(payload[:]) {
+		t.Errorf("Expected payload hash of %s, got %s", bodies[0], create.PayloadSHA256)
+	}
+	if len(create.APIKeyID) != 16 || strings.Contains(create.APIKeyID, "secret") || create.Time.IsZero() {
+		t.Errorf("Expected key fingerprint and time, got %+v", create)
+	}
+	if del := entries[1]; del.Method != "DELETE" || del.PayloadSHA256 != "" || del.APIKeyID == create.APIKeyID {
+		t.Errorf("Expected delete with the other key, got %+v", del)
+	}
+
+	// Actions that cannot be recorded are not taken.
+	bodies = nil
+	client = client.With(WithAuditSink(failingSink{}))
+	if _, err := client.CreateProject(&ProjectCreate{Name: "x"}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Expected audit error, got %v", err)
+	}
+	if len(bodies) != 0 {
+		t.Errorf("Expected no request to be sent, got %v", bodies)
+	}
+}
@@ -0,0 +1,129 @@
+package aiptx
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// =============================================================================
+// Backups
+// =============================================================================
+
+// Backup and restore statuses.
+const (
+	BackupPending   = "pending"
+	BackupRunning   = "running"
+	BackupCompleted = "completed"
+	BackupFailed    = "failed"
+)
+
+// Backup is a snapshot of the server's data.
+type Backup struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Size        int64     `json:"size,omitempty"`
+	SHA256      string    `json:"sha256,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// Restore is the restoration of a backup archive.
+type Restore struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// CreateBackup starts a backup. Backups are taken asynchronously; poll
+// GetBackup until Status is BackupCompleted before downloading it.
+func (c *Client) CreateBackup() (*Backup, error) {
+	return c.CreateBackupContext(context.Background())
+}
+
+// CreateBackupContext is like CreateBackup but uses ctx for its request.
+func (c *Client) CreateBackupContext(ctx context.Context) (*Backup, error) {
+	var backup Backup
+	if err := c.requestContext(ctx, "POST", "/backups", nil, &backup); err != nil {
+		return nil, c.featureError(ctx, err, FeatureBackups)
+	}
+	return &backup, nil
+}
+
+// GetBackup returns a backup by ID.
+func (c *Client) GetBackup(id string) (*Backup, error) {
+	return c.GetBackupContext(context.Background(), id)
+}
+
+// GetBackupContext is like GetBackup but uses ctx for its request.
+func (c *Client) GetBackupContext(ctx context.Context, id string) (*Backup, error) {
+	var backup Backup
+	if err := c.requestContext(ctx, "GET", "/backups/"+id, nil, &backup); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// ListBackups returns the backups of the server, newest first.
+func (c *Client) ListBackups() ([]Backup, error) {
+	return c.ListBackupsContext(context.Background())
+}
+
+// ListBackupsContext is like ListBackups but uses ctx for its request.
+func (c *Client) ListBackupsContext(ctx context.Context) ([]Backup, error) {
+	var backups []Backup
+	if err := c.requestContext(ctx, "GET", "/backups", nil, &backups); err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
+
+// DownloadBackup writes the archive of a completed backup to w and returns
+// the number of bytes written. Like DownloadReport, it verifies the archive
+// against the server's checksum; pass WithChecksum(backup.SHA256) to verify
+// it against the checksum recorded when the backup was taken as well. The
+// client's timeout does not apply; bound the transfer with ctx.
+func (c *Client) DownloadBackup(ctx context.Context, id string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	return c.download(ctx, "/backups/"+id+"/download", w, opts)
+}
+
+// RestoreBackup uploads a backup archive read from r and starts restoring
+// the server from it, replacing its data. Poll GetRestore until Status is
+// BackupCompleted. The archive is streamed, so the upload is not retried.
+// The client's timeout does not apply; bound the upload with ctx.
+func (c *Client) RestoreBackup(ctx context.Context, r io.Reader) (*Restore, error) {
+	c.checkVersion(ctx)
+	if err := c.audit(ctx, "POST", "/backups/restore", nil); err != nil {
+		return nil, err
+	}
+	_, resp, err := c.With(WithTimeout(0)).attempt(ctx, "POST", "/backups/restore", "application/octet-stream", nil, r)
+	if err != nil {
+		return nil, c.featureError(ctx, err, FeatureBackups)
+	}
+	var restore Restore
+	if err := decodeResponse(resp, &restore, c.strictDecoding); err != nil {
+		return nil, err
+	}
+	return &restore, nil
+}
+
+// GetRestore returns a restore by ID.
+func (c *Client) GetRestore(id string) (*Restore, error) {
+	return c.GetRestoreContext(context.Background(), id)
+}
+
+// GetRestoreContext is like GetRestore but uses ctx for its request.
+func (c *Client) GetRestoreContext(ctx context.Context, id string) (*Restore, error) {
+	var restore Restore
+	if err := c.requestContext(ctx, "GET", "/restores/"+id, nil, &restore); err != nil {
+		return nil, err
+	}
+	return &restore, nil
+}
@@ -0,0 +1,120 @@
+package aiptx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackups(t *testing.T) {
+	var restored []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /backups":
+			w.Write([]byte(`{"id": "b-1", "status": "pending"}`))
+		case "GET /backups":
+			w.Write([]byte(`[{"id": "b-1", "status": "completed", "size": 7}]`))
+		case "GET /backups/b-1/download":
+			w.Write([]byte("archive"))
+		case "POST /backups/restore":
+			if r.Header.Get("Content-Type") != "application/octet-stream" {
+				t.Errorf("Expected octet-stream upload, got %q", r.Header.Get("Content-Type"))
+			}
+			restored, _ = io.ReadAll(r.Body)
+			w.Write([]byte(`{"id": "rs-1", "status": "running"}`))
+		case "GET /restores/rs-1":
+			w.Write([]byte(`{"id": "rs-1", "status": "completed"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	backup, err := client.CreateBackup()
+	if err != nil || backup.Status != BackupPending {
+		t.Fatalf("Expected pending backup, got %+v (%v)", backup, err)
+	}
+	backups, err := client.ListBackups()
+	if err != nil || len(backups) != 1 || backups[0].Status != BackupCompleted {
+		t.Fatalf("Expected completed backup, got %+v (%v)", backups, err)
+	}
+
+	var buf bytes.Buffer
+	n, err := client.DownloadBackup(ctx, "b-1", &buf)
+	if err != nil || n != 7 || buf.String() != "archive" {
+		t.Errorf("Expected archive, got %q (%v)", buf.String(), err)
+	}
+	const sum = "0000000000000000000000000000000000000000000000000000000000000000"
+	if _, err := client.DownloadBackup(ctx, "b-1", io.Discard, WithChecksum(sum)); err == nil {
+		t.Errorf("Expected checksum mismatch")
+	}
+
+	// A reader without a known length is streamed.
+	restore, err := client.RestoreBackup(ctx, io.MultiReader(strings.NewReader("arch"), strings.NewReader("ive")))
+	if err != nil || restore.ID != "rs-1" || string(restored) != "archive" {
+		t.Fatalf("Expected restore of archive, got %+v, %q (%v)", restore, restored, err)
+	}
+	if restore, err = client.GetRestore("rs-1"); err != nil || restore.Status != BackupCompleted {
+		t.Errorf("Expected completed restore, got %+v (%v)", restore, err)
+	}
+}
+
+func TestRestoreBackupError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"detail": "a restore is already running"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).RestoreBackup(context.Background(), strings.NewReader("archive"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "a restore is already running" {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+// slowReader returns its content in small pieces with a delay before each.
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	return s.r.Read(p[:min(len(p), 4)])
+}
+
+func TestBackupTransfersIgnoreTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/backups/restore":
+			io.ReadAll(r.Body)
+			w.Write([]byte(`{"id": "rst-1", "status": "running"}`))
+		case "/backups/b-1/download":
+			for i := 0; i < 4; i++ {
+				w.Write([]byte("arch"))
+				w.(http.Flusher).Flush()
+				time.Sleep(50 * time.Millisecond)
+			}
+		}
+	}))
+	defer server.Close()
+
+	// Both transfers take longer than the client's timeout.
+	client := NewClient(server.URL, WithTimeout(100*time.Millisecond))
+	if n, err := client.DownloadBackup(context.Background(), "b-1", io.Discard); err != nil || n != 16 {
+		t.Errorf("Expected complete download, got %d bytes and %v", n, err)
+	}
+	archive := &slowReader{r: strings.NewReader("archive-archive"), delay: 50 * time.Millisecond}
+	if _, err := client.RestoreBackup(context.Background(), archive); err != nil {
+		t.Errorf("Expected complete upload, got %v", err)
+	}
+}
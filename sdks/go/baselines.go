@@ -0,0 +1,67 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Baselines
+// =============================================================================
+
+// Baseline is the scan a project's findings are compared against. Findings
+// that were already present in the baseline scan are known, so recurring
+// scans can surface only new ones with FindingsFilter.NewSinceBaseline.
+type Baseline struct {
+	ProjectID int64  `json:"project_id"`
+	ScanID    string `json:"scan_id"`
+	// Findings is the number of findings recorded in the baseline.
+	Findings int       `json:"findings"`
+	SetBy    string    `json:"set_by,omitempty"`
+	SetAt    time.Time `json:"set_at"`
+
+	unknown *rawFields
+}
+
+// SetBaseline makes the completed scan with the given ID the baseline of a
+// project, replacing any previous baseline.
+func (c *Client) SetBaseline(projectID int64, scanID string) (*Baseline, error) {
+	return c.SetBaselineContext(context.Background(), projectID, scanID)
+}
+
+// SetBaselineContext is like SetBaseline but uses ctx for its request.
+func (c *Client) SetBaselineContext(ctx context.Context, projectID int64, scanID string) (*Baseline, error) {
+	body := map[string]string{"scan_id": scanID}
+	var baseline Baseline
+	if err := c.requestContext(ctx, "PUT", fmt.Sprintf("/projects/%d/baseline", projectID), body, &baseline); err != nil {
+		return nil, err
+	}
+	return &baseline, nil
+}
+
+// GetBaseline returns the baseline of a project. It returns an error matching
+// ErrNotFound if the project has no baseline.
+func (c *Client) GetBaseline(projectID int64) (*Baseline, error) {
+	return c.GetBaselineContext(context.Background(), projectID)
+}
+
+// GetBaselineContext is like GetBaseline but uses ctx for its request.
+func (c *Client) GetBaselineContext(ctx context.Context, projectID int64) (*Baseline, error) {
+	var baseline Baseline
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/projects/%d/baseline", projectID), nil, &baseline); err != nil {
+		return nil, err
+	}
+	return &baseline, nil
+}
+
+// ClearBaseline removes the baseline of a project, so all of its findings
+// count as new again.
+func (c *Client) ClearBaseline(projectID int64) error {
+	return c.ClearBaselineContext(context.Background(), projectID)
+}
+
+// ClearBaselineContext is like ClearBaseline but uses ctx for its request.
+func (c *Client) ClearBaselineContext(ctx context.Context, projectID int64) error {
+	return c.requestContext(ctx, "DELETE", fmt.Sprintf("/projects/%d/baseline", projectID), nil, nil)
+}
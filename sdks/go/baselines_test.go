@@ -0,0 +1,46 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	var baseline string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			baseline = body["scan_id"]
+			fallthrough
+		case "GET":
+			if baseline == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"project_id": 42, "scan_id": baseline, "findings": 12})
+		case "DELETE":
+			baseline = ""
+		}
+	}))
+	defer server.Close()
+	project := NewClient(server.URL).Project(42)
+
+	b, err := project.SetBaseline("scan-1")
+	if err != nil || b.ScanID != "scan-1" || b.Findings != 12 {
+		t.Fatalf("Expected baseline scan-1, got %+v (%v)", b, err)
+	}
+	if b, err := project.Baseline(); err != nil || b.ProjectID != 42 {
+		t.Errorf("Expected baseline of project 42, got %+v (%v)", b, err)
+	}
+	if err := project.client.ClearBaseline(42); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := project.Baseline(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after clearing, got %v", err)
+	}
+}
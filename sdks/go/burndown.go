@@ -0,0 +1,117 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// =============================================================================
+// Burndown
+// =============================================================================
+
+// Burndown intervals.
+const (
+	BurndownDaily  = "day"
+	BurndownWeekly = "week"
+)
+
+// BurndownOptions selects the period and resolution of a burndown.
+type BurndownOptions struct {
+	// Since and Until bound the period. The server defaults to the last 90
+	// days.
+	Since time.Time
+	Until time.Time
+	// Interval is BurndownDaily or BurndownWeekly, and defaults to daily.
+	Interval string
+}
+
+func (o *BurndownOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if !o.Since.IsZero() {
+		v.Set("since", o.Since.UTC().Format(time.RFC3339))
+	}
+	if !o.Until.IsZero() {
+		v.Set("until", o.Until.UTC().Format(time.RFC3339))
+	}
+	if o.Interval != "" {
+		v.Set("interval", o.Interval)
+	}
+	return v
+}
+
+// Burndown is the number of open findings of a project over time, by
+// severity, ready for charting remediation progress.
+type Burndown struct {
+	ProjectID int64  `json:"project_id"`
+	Interval  string `json:"interval"`
+	// Points are in chronological order, one per interval.
+	Points []BurndownPoint `json:"points"`
+
+	unknown *rawFields
+}
+
+// BurndownPoint counts the findings of a project at the end of an interval.
+type BurndownPoint struct {
+	Time time.Time `json:"time"`
+	// Open counts the findings open at Time by severity. Severities without
+	// open findings may be missing.
+	Open map[Severity]int `json:"open"`
+	// Opened and Closed count the findings discovered and closed during the
+	// interval.
+	Opened int `json:"opened"`
+	Closed int `json:"closed"`
+}
+
+// Total returns the number of findings open at p.Time.
+func (p BurndownPoint) Total() int {
+	total := 0
+	for _, n := range p.Open {
+		total += n
+	}
+	return total
+}
+
+// Series returns the open findings of a severity at each point, as one
+// series of a chart.
+func (b *Burndown) Series(sev Severity) []int {
+	series := make([]int, len(b.Points))
+	for i, p := range b.Points {
+		series[i] = p.Open[sev]
+	}
+	return series
+}
+
+// Histogram returns the open findings by severity at the latest point,
+// with every severity present, or nil if there are no points.
+func (b *Burndown) Histogram() map[Severity]int {
+	if len(b.Points) == 0 {
+		return nil
+	}
+	latest := b.Points[len(b.Points)-1]
+	histogram := make(map[Severity]int, len(severityOrder))
+	for _, sev := range severityOrder {
+		histogram[sev] = latest.Open[sev]
+	}
+	return histogram
+}
+
+// GetBurndown returns the open findings of a project by severity over time.
+// opts may be nil.
+func (c *Client) GetBurndown(projectID int64, opts *BurndownOptions) (*Burndown, error) {
+	return c.GetBurndownContext(context.Background(), projectID, opts)
+}
+
+// GetBurndownContext is like GetBurndown but uses ctx for its request.
+func (c *Client) GetBurndownContext(ctx context.Context, projectID int64, opts *BurndownOptions) (*Burndown, error) {
+	var burndown Burndown
+	path := withQuery(fmt.Sprintf("/projects/%d/burndown", projectID), opts.values())
+	if err := c.requestContext(ctx, "GET", path, nil, &burndown); err != nil {
+		return nil, err
+	}
+	return &burndown, nil
+}
@@ -0,0 +1,46 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetBurndown(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/42/burndown" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"project_id": 42, "interval": "week", "points": [
+			{"time": "2024-01-07T00:00:00Z", "open": {"critical": 3, "high": 5}, "opened": 8},
+			{"time": "2024-01-14T00:00:00Z", "open": {"critical": 1, "high": 4, "low": 2}, "opened": 2, "closed": 3}
+		]}`))
+	}))
+	defer server.Close()
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b, err := NewClient(server.URL).Project(42).Burndown(&BurndownOptions{Since: since, Interval: BurndownWeekly})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query != "interval=week&since=2024-01-01T00%3A00%3A00Z" {
+		t.Errorf("Unexpected query %s", query)
+	}
+	if len(b.Points) != 2 || b.Points[1].Total() != 7 || b.Points[1].Closed != 3 {
+		t.Errorf("Expected two points, got %+v", b.Points)
+	}
+	if series := b.Series(SeverityCritical); len(series) != 2 || series[0] != 3 || series[1] != 1 {
+		t.Errorf("Expected critical series [3 1], got %v", series)
+	}
+
+	histogram := b.Histogram()
+	if len(histogram) != 5 || histogram[SeverityHigh] != 4 || histogram[SeverityMedium] != 0 {
+		t.Errorf("Expected histogram of every severity, got %v", histogram)
+	}
+	if (&Burndown{}).Histogram() != nil {
+		t.Errorf("Expected nil histogram without points")
+	}
+}
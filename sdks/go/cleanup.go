@@ -0,0 +1,196 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Cleanup
+// =============================================================================
+
+// DefaultStaleAge is how long a scan or session may go without progress
+// before CleanupStale considers it abandoned.
+const DefaultStaleAge = 24 * time.Hour
+
+// CleanupPolicy selects the abandoned scans and sessions removed by
+// CleanupStale.
+type CleanupPolicy struct {
+	// MaxScanAge is how long a scan or session may go without progress,
+	// measured from its UpdatedAt, or else StartedAt, or else CreatedAt for
+	// resources that never started. It defaults to DefaultStaleAge.
+	MaxScanAge time.Duration
+	// Statuses are the statuses a resource must be in to be abandoned. The
+	// default is queued and running.
+	Statuses []ScanState
+	// OwnerTag restricts cleanup to resources created with this owner tag.
+	// See WithOwnerTag.
+	OwnerTag string
+	// Delete deletes abandoned resources instead of cancelling them.
+	Delete bool
+	// DryRun only reports abandoned resources, leaving them untouched.
+	DryRun bool
+}
+
+// CleanupReport lists the abandoned resources found by CleanupStale. Unless
+// the cleanup was a dry run, they have been cancelled or deleted, except
+// for those whose error CleanupStale returned.
+type CleanupReport struct {
+	Scans    []ScanStatus
+	Sessions []Session
+	DryRun   bool
+}
+
+// CleanupStale finds scans and sessions that are stuck, such as scans that
+// have been "running" for a day without progress, and cancels or deletes
+// them according to policy. Run it with DryRun first to review what would
+// be removed:
+//
+//	report, err := client.CleanupStale(ctx, aiptx.CleanupPolicy{DryRun: true})
+//
+// The returned error joins the errors of resources that could not be
+// cleaned up; the report is returned with it.
+func (c *Client) CleanupStale(ctx context.Context, policy CleanupPolicy) (*CleanupReport, error) {
+	if policy.MaxScanAge <= 0 {
+		policy.MaxScanAge = DefaultStaleAge
+	}
+	if len(policy.Statuses) == 0 {
+		policy.Statuses = []ScanState{ScanQueued, ScanRunning}
+	}
+	now := time.Now()
+	opts := ListOptions{OwnerTag: policy.OwnerTag}
+	report := &CleanupReport{DryRun: policy.DryRun}
+
+	err := eachPage("/scans", opts, func(path string) (*PageInfo, error) {
+		var scans []ScanStatus
+		page, err := c.listPage(ctx, path, &scans)
+		for _, s := range scans {
+			if policy.stale(s.Status, now, s.UpdatedAt, s.StartedAt, s.CreatedAt) {
+				report.Scans = append(report.Scans, s)
+			}
+		}
+		return page, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projects := c.IterateProjects(ctx, nil)
+	for projects.Next() {
+		err := eachPage(fmt.Sprintf("/projects/%d/sessions", projects.Project().ID), opts, func(path string) (*PageInfo, error) {
+			var sessions []Session
+			page, err := c.listPage(ctx, path, &sessions)
+			for _, s := range sessions {
+				if policy.stale(s.Status, now, s.UpdatedAt, s.StartedAt, s.CreatedAt) {
+					report.Sessions = append(report.Sessions, s)
+				}
+			}
+			return page, err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := projects.Err(); err != nil {
+		return nil, err
+	}
+
+	if policy.DryRun {
+		return report, nil
+	}
+	var errs []error
+	for _, s := range report.Scans {
+		var err error
+		if policy.Delete {
+			err = c.DeleteScanContext(ctx, s.ID)
+		} else {
+			_, err = c.CancelScanContext(ctx, s.ID)
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("cleaning up scan %s: %w", s.ID, err))
+		}
+	}
+	for _, s := range report.Sessions {
+		var err error
+		if policy.Delete {
+			err = c.DeleteSessionContext(ctx, s.ID)
+		} else {
+			_, err = c.CancelSessionContext(ctx, s.ID)
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("cleaning up session %d: %w", s.ID, err))
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// stale reports whether a resource with the given status is abandoned,
+// judged by the first of its activity times that is set. Resources without
+// any are never stale.
+func (p *CleanupPolicy) stale(status ScanState, now time.Time, times ...time.Time) bool {
+	var last time.Time
+	for _, t := range times {
+		if !t.IsZero() {
+			last = t
+			break
+		}
+	}
+	if last.IsZero() || now.Sub(last) < p.MaxScanAge {
+		return false
+	}
+	for _, s := range p.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// eachPage calls fetch with the path of every page of a list endpoint, until
+// fetch returns an error or the last page.
+func eachPage(path string, opts ListOptions, fetch func(path string) (*PageInfo, error)) error {
+	seen := cursorLoop{opts.Cursor: true}
+	for {
+		page, err := fetch(withQuery(path, opts.values()))
+		if err != nil {
+			return err
+		}
+		if !page.HasNext || page.NextCursor == "" {
+			return nil
+		}
+		if err := seen.follow(path, page.NextCursor); err != nil {
+			return err
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// DeleteScan deletes a scan and its results.
+func (c *Client) DeleteScan(scanID string) error {
+	return c.DeleteScanContext(context.Background(), scanID)
+}
+
+// DeleteScanContext is like DeleteScan but uses ctx for its request.
+func (c *Client) DeleteScanContext(ctx context.Context, scanID string) error {
+	if err := c.requestContext(ctx, "DELETE", fmt.Sprintf("/scans/%s", scanID), nil, nil); err != nil {
+		return err
+	}
+	c.instance.scanStatus(scanID, ScanCancelled)
+	return nil
+}
+
+// DeleteSession deletes a session.
+func (c *Client) DeleteSession(id int64) error {
+	return c.DeleteSessionContext(context.Background(), id)
+}
+
+// DeleteSessionContext is like DeleteSession but uses ctx for its request.
+func (c *Client) DeleteSessionContext(ctx context.Context, id int64) error {
+	if err := c.requestContext(ctx, "DELETE", fmt.Sprintf("/sessions/%d", id), nil, nil); err != nil {
+		return err
+	}
+	c.instance.sessionStatus(id, ScanCancelled)
+	return nil
+}
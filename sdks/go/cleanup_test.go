@@ -0,0 +1,105 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCleanupStale(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	var mu sync.Mutex
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			mu.Lock()
+			actions = append(actions, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+			w.Write([]byte(`{}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/scans":
+			if r.URL.Query().Get("owner_tag") != "ci" {
+				t.Errorf("Expected owner_tag filter, got %q", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("cursor") == "" {
+				w.Header().Set("X-Next-Cursor", "p2")
+				fmt.Fprintf(w, `[
+					{"id": "stuck", "status": "running", "started_at": %q},
+					{"id": "active", "status": "running", "started_at": %q, "updated_at": %q},
+					{"id": "waiting", "status": "queued", "created_at": %q}
+				]`, old, old, recent, old)
+				return
+			}
+			fmt.Fprintf(w, `[{"id": "done", "status": "completed", "started_at": %q}]`, old)
+		case "/projects":
+			if r.URL.Query().Get("cursor") == "" {
+				w.Header().Set("X-Next-Cursor", "p2")
+				w.Write([]byte(`[{"id": 1}]`))
+				return
+			}
+			w.Write([]byte(`[{"id": 2}]`))
+		case "/projects/1/sessions":
+			fmt.Fprintf(w, `[{"id": 5, "status": "running", "updated_at": %q}]`, old)
+		case "/projects/2/sessions":
+			fmt.Fprintf(w, `[{"id": 6, "status": "queued", "created_at": %q}]`, recent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	report, err := client.CleanupStale(context.Background(), CleanupPolicy{OwnerTag: "ci", DryRun: true})
+	if err != nil {
+		t.Fatalf("CleanupStale failed: %v", err)
+	}
+	if len(report.Scans) != 2 || report.Scans[0].ID != "stuck" || report.Scans[1].ID != "waiting" {
+		t.Errorf("Expected the stuck and waiting scans, got %+v", report.Scans)
+	}
+	if len(report.Sessions) != 1 || report.Sessions[0].ID != 5 {
+		t.Errorf("Expected session 5, got %+v", report.Sessions)
+	}
+	if !report.DryRun || len(actions) != 0 {
+		t.Errorf("Expected a dry run to change nothing, got %v", actions)
+	}
+
+	if _, err := client.CleanupStale(context.Background(), CleanupPolicy{OwnerTag: "ci"}); err != nil {
+		t.Fatalf("CleanupStale failed: %v", err)
+	}
+	if len(actions) != 3 || actions[0] != "POST /scans/stuck/cancel" || actions[2] != "POST /sessions/5/cancel" {
+		t.Errorf("Expected stale resources to be cancelled, got %v", actions)
+	}
+
+	actions = nil
+	if _, err := client.CleanupStale(context.Background(), CleanupPolicy{OwnerTag: "ci", Delete: true}); err != nil {
+		t.Fatalf("CleanupStale failed: %v", err)
+	}
+	if len(actions) != 3 || actions[0] != "DELETE /scans/stuck" || actions[2] != "DELETE /sessions/5" {
+		t.Errorf("Expected stale resources to be deleted, got %v", actions)
+	}
+}
+
+func TestCleanupPolicyStale(t *testing.T) {
+	now := time.Now()
+	p := CleanupPolicy{MaxScanAge: time.Hour, Statuses: []ScanState{ScanRunning}}
+	if p.stale(ScanRunning, now, time.Time{}, time.Time{}) {
+		t.Errorf("Expected resources without times to never be stale")
+	}
+	if p.stale(ScanQueued, now, time.Time{}, now.Add(-2*time.Hour)) {
+		t.Errorf("Expected other statuses to be ignored")
+	}
+	if !p.stale(ScanRunning, now, time.Time{}, now.Add(-2*time.Hour)) {
+		t.Errorf("Expected old running scan to be stale")
+	}
+	if p.stale(ScanRunning, now, now.Add(-time.Minute), now.Add(-2*time.Hour)) {
+		t.Errorf("Expected recent progress to keep the scan")
+	}
+}
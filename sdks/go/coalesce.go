@@ -0,0 +1,66 @@
+package aiptx
+
+import (
+	"context"
+	"sync"
+)
+
+// =============================================================================
+// Request Coalescing
+// =============================================================================
+
+// WithRequestCoalescing makes concurrent identical GET requests share a single
+// server call. Requests are identical if they have the same URL and
+// credentials. This suits many goroutines polling the same resource, such as
+// the status of one scan.
+//
+// Each caller still honours its own context: a caller that gives up stops
+// waiting, while the shared call continues for the remaining callers, bounded
+// by the HTTP client timeout.
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.coalescer = &coalescer{}
+	}
+}
+
+// coalescer deduplicates in-flight requests by key.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// do calls fn once for all concurrent callers with the same key and returns
+// its result to each of them. fn runs with a context that is not cancelled
+// when ctx is, since other callers may still be waiting for it.
+func (g *coalescer) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*coalescedCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		call = &coalescedCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go func() {
+			call.body, call.err = fn(context.WithoutCancel(ctx))
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
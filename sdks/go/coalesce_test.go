@@ -0,0 +1,99 @@
+package aiptx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestCoalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Write([]byte(`{"id": "scan-1", "status": "running"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRequestCoalescing())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := client.GetScanStatus("scan-1")
+			if err == nil && status.Status != "running" {
+				t.Errorf("Expected status 'running', got '%s'", status.Status)
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetScanStatus failed: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected 1 server call, got %d", n)
+	}
+}
+
+func TestRequestCoalescingCredentials(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("key-a"), WithRequestCoalescing())
+	other := client.With(WithAPIKey("key-b"))
+
+	var wg sync.WaitGroup
+	for _, c := range []*Client{client, other} {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			c.Health()
+		}(c)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("Expected requests with different keys not to be shared, got %d calls", n)
+	}
+}
+
+func TestRequestCoalescingCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, WithRequestCoalescing())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.HealthContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
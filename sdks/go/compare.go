@@ -0,0 +1,191 @@
+package aiptx
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Project Comparison
+// =============================================================================
+
+// riskWeights are the contribution of an open finding of each severity to a
+// project's risk score.
+var riskWeights = map[Severity]float64{
+	SeverityCritical: 10,
+	SeverityHigh:     5,
+	SeverityMedium:   2,
+	SeverityLow:      0.5,
+}
+
+// ProjectComparison compares two engagements, such as the staging and
+// production deployments of the same application. Findings are matched by
+// the vulnerability they report rather than where it was found: by CVE, by
+// type and URL path, or by type and value. False positives and merged
+// findings are left out.
+type ProjectComparison struct {
+	A ProjectSummary
+	B ProjectSummary
+
+	// Shared are the pairs of findings reported in both projects.
+	Shared []FindingPair
+	// OnlyInA and OnlyInB are the findings without a counterpart in the
+	// other project.
+	OnlyInA []Finding
+	OnlyInB []Finding
+}
+
+// FindingPair is a finding of project A and the matching finding of
+// project B.
+type FindingPair struct {
+	A Finding
+	B Finding
+}
+
+// ProjectSummary is the coverage and risk posture of one project.
+type ProjectSummary struct {
+	Project Project
+	// Tools and Phases are those that reported findings, sorted.
+	Tools  []string
+	Phases []string
+	// Findings counts the compared findings, and BySeverity the open ones
+	// by severity.
+	Findings   int
+	BySeverity map[Severity]int
+	// RiskScore sums the weights of open findings: 10 per critical, 5 per
+	// high, 2 per medium and 0.5 per low. Findings whose remediation is
+	// fixed, verified or accepted do not count.
+	RiskScore float64
+}
+
+// Overlap returns the fraction of distinct vulnerabilities found in both
+// projects, from 0 to 1.
+func (c *ProjectComparison) Overlap() float64 {
+	total := len(c.Shared) + len(c.OnlyInA) + len(c.OnlyInB)
+	if total == 0 {
+		return 1
+	}
+	return float64(len(c.Shared)) / float64(total)
+}
+
+// CompareProjects compares the scope coverage, findings and risk posture of
+// two projects.
+func (c *Client) CompareProjects(idA, idB int64) (*ProjectComparison, error) {
+	return c.CompareProjectsContext(context.Background(), idA, idB)
+}
+
+// CompareProjectsContext is like CompareProjects but uses ctx for its request.
+func (c *Client) CompareProjectsContext(ctx context.Context, idA, idB int64) (*ProjectComparison, error) {
+	projectA, findingsA, err := c.projectFindings(ctx, idA)
+	if err != nil {
+		return nil, err
+	}
+	projectB, findingsB, err := c.projectFindings(ctx, idB)
+	if err != nil {
+		return nil, err
+	}
+	return compareProjects(projectA, findingsA, projectB, findingsB), nil
+}
+
+// projectFindings returns a project and all of its findings.
+func (c *Client) projectFindings(ctx context.Context, projectID int64) (*Project, []Finding, error) {
+	project, err := c.GetProjectContext(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var findings []Finding
+	_, err = c.ExportFindingsResumable(ctx, &FindingsFilter{ProjectID: projectID}, &MemoryCursorStore{}, func(page []Finding) error {
+		findings = append(findings, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return project, findings, nil
+}
+
+func compareProjects(projectA *Project, findingsA []Finding, projectB *Project, findingsB []Finding) *ProjectComparison {
+	findingsA, findingsB = comparedFindings(findingsA), comparedFindings(findingsB)
+	cmp := &ProjectComparison{
+		A: summarizeProject(projectA, findingsA),
+		B: summarizeProject(projectB, findingsB),
+	}
+
+	unmatched := map[string][]Finding{}
+	for _, f := range findingsB {
+		key := comparisonKey(f)
+		unmatched[key] = append(unmatched[key], f)
+	}
+	matched := map[int64]bool{}
+	for _, f := range findingsA {
+		key := comparisonKey(f)
+		if candidates := unmatched[key]; len(candidates) > 0 {
+			cmp.Shared = append(cmp.Shared, FindingPair{A: f, B: candidates[0]})
+			matched[candidates[0].ID] = true
+			unmatched[key] = candidates[1:]
+			continue
+		}
+		cmp.OnlyInA = append(cmp.OnlyInA, f)
+	}
+	for _, f := range findingsB {
+		if !matched[f.ID] {
+			cmp.OnlyInB = append(cmp.OnlyInB, f)
+		}
+	}
+	return cmp
+}
+
+// comparedFindings drops false positives and merged findings.
+func comparedFindings(findings []Finding) []Finding {
+	var kept []Finding
+	for _, f := range findings {
+		if !f.FalsePositive && f.MergedInto == 0 {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// comparisonKey identifies the vulnerability a finding reports independently
+// of the environment it was found in.
+func comparisonKey(f Finding) string {
+	if cve := cvePattern.FindString(f.Value + " " + f.Description); cve != "" {
+		return "cve:" + strings.ToUpper(cve)
+	}
+	if u, err := url.Parse(f.Value); err == nil && u.Scheme != "" && u.Host != "" {
+		return f.Type + ":" + u.Path
+	}
+	return f.Type + ":" + f.Value
+}
+
+func summarizeProject(project *Project, findings []Finding) ProjectSummary {
+	s := ProjectSummary{Project: *project, Findings: len(findings), BySeverity: map[Severity]int{}}
+	tools, phases := map[string]bool{}, map[string]bool{}
+	for _, f := range findings {
+		if f.Tool != "" {
+			tools[f.Tool] = true
+		}
+		if f.Phase != "" {
+			phases[string(f.Phase)] = true
+		}
+		if r := f.Remediation; r != nil && (r.Status == RemediationFixed || r.Status.Closed()) {
+			continue
+		}
+		sev := f.Severity
+		s.BySeverity[sev]++
+		s.RiskScore += riskWeights[sev]
+	}
+	s.Tools, s.Phases = sortedKeys(tools), sortedKeys(phases)
+	return s
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
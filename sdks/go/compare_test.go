@@ -0,0 +1,67 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path + "?" + r.URL.Query().Get("project_id") {
+		case "/projects/1?":
+			w.Write([]byte(`{"id": 1, "name": "Staging", "target": "staging.acme.example", "scope": ["staging.acme.example"]}`))
+		case "/projects/2?":
+			w.Write([]byte(`{"id": 2, "name": "Production", "target": "acme.example", "scope": ["acme.example", "api.acme.example"]}`))
+		case "/findings?1":
+			w.Write([]byte(`[
+				{"id": 10, "type": "xss", "value": "https://staging.acme.example/search", "severity": "high", "tool": "dalfox", "phase": "scan"},
+				{"id": 11, "type": "rce", "value": "Log4Shell CVE-2021-44228", "severity": "critical", "tool": "nuclei", "phase": "scan"},
+				{"id": 12, "type": "port", "value": "8080/tcp", "severity": "info", "tool": "nmap", "phase": "recon"},
+				{"id": 13, "type": "sqli", "value": "id", "severity": "high", "false_positive": true}
+			]`))
+		case "/findings?2":
+			w.Write([]byte(`[
+				{"id": 20, "type": "xss", "value": "https://acme.example/search", "severity": "high", "tool": "dalfox",
+					"remediation": {"status": "fixed"}},
+				{"id": 21, "type": "rce", "value": "log4j", "description": "Affected by cve-2021-44228", "severity": "critical", "tool": "nuclei"},
+				{"id": 22, "type": "vulnerability", "value": "TLS 1.0 enabled", "severity": "medium", "tool": "sslyze"}
+			]`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	cmp, err := client.CompareProjects(1, 2)
+	if err != nil {
+		t.Fatalf("CompareProjects failed: %v", err)
+	}
+
+	if len(cmp.Shared) != 2 || cmp.Shared[0].A.ID != 10 || cmp.Shared[0].B.ID != 20 || cmp.Shared[1].B.ID != 21 {
+		t.Errorf("Expected XSS and Log4Shell to be shared, got %+v", cmp.Shared)
+	}
+	if len(cmp.OnlyInA) != 1 || cmp.OnlyInA[0].ID != 12 {
+		t.Errorf("Expected only the open port in staging, got %+v", cmp.OnlyInA)
+	}
+	if len(cmp.OnlyInB) != 1 || cmp.OnlyInB[0].ID != 22 {
+		t.Errorf("Expected only TLS 1.0 in production, got %+v", cmp.OnlyInB)
+	}
+	if cmp.Overlap() != 0.5 {
+		t.Errorf("Expected overlap 0.5, got %v", cmp.Overlap())
+	}
+
+	if cmp.A.Findings != 3 || cmp.A.RiskScore != 15 || cmp.A.BySeverity[SeverityHigh] != 1 {
+		t.Errorf("Unexpected staging summary %+v", cmp.A)
+	}
+	if cmp.B.RiskScore != 12 || cmp.B.BySeverity[SeverityHigh] != 0 {
+		t.Errorf("Expected the fixed XSS not to count in production, got %+v", cmp.B)
+	}
+	if len(cmp.A.Tools) != 3 || cmp.A.Tools[0] != "dalfox" || len(cmp.A.Phases) != 2 {
+		t.Errorf("Unexpected staging coverage %v %v", cmp.A.Tools, cmp.A.Phases)
+	}
+	if len(cmp.B.Project.Scope) != 2 {
+		t.Errorf("Expected production scope, got %v", cmp.B.Project.Scope)
+	}
+}
@@ -0,0 +1,62 @@
+package aiptx
+
+import (
+	"net/http"
+	"sync"
+)
+
+// =============================================================================
+// Concurrency
+// =============================================================================
+
+// SetBaseURL changes the server of a client that may be in use by other
+// goroutines. Requests already in flight finish against the old server.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.lock().Lock()
+	defer c.lock().Unlock()
+	// Switching to or from a unix socket reconfigures the transport, so
+	// copy it rather than change the one in-flight requests are using.
+	if c.HTTPClient != nil {
+		httpClient := *c.HTTPClient
+		c.HTTPClient = &httpClient
+		c.ownsTransport = false
+	}
+	c.setBaseURL(baseURL)
+}
+
+// SetAPIKey changes the API key of a client that may be in use by other
+// goroutines, for example to rotate a key without recreating the client.
+func (c *Client) SetAPIKey(apiKey string) {
+	c.lock().Lock()
+	defer c.lock().Unlock()
+	c.APIKey = apiKey
+}
+
+// primaryURL returns BaseURL.
+func (c *Client) primaryURL() string {
+	c.lock().RLock()
+	defer c.lock().RUnlock()
+	return c.BaseURL
+}
+
+// httpClient returns HTTPClient.
+func (c *Client) httpClient() *http.Client {
+	c.lock().RLock()
+	defer c.lock().RUnlock()
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// literalMu guards the fields of clients created as struct literals rather
+// than by NewClient, which have no mutex of their own.
+var literalMu sync.RWMutex
+
+// lock returns the mutex guarding BaseURL, APIKey and HTTPClient.
+func (c *Client) lock() *sync.RWMutex {
+	if c.mu == nil {
+		return &literalMu
+	}
+	return c.mu
+}
@@ -0,0 +1,144 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// These tests are most useful under the race detector: go test -race.
+
+func TestConcurrentRequests(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`[{"id": 1, "name": "p"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"), WithRequestCoalescing())
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := client
+			if i%2 == 0 {
+				c = client.With(WithOrganization(fmt.Sprintf("org-%d", i)))
+			}
+			for j := 0; j < 20; j++ {
+				if _, err := c.ListProjects(); err != nil {
+					t.Errorf("ListProjects failed: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if requests.Load() == 0 {
+		t.Errorf("Expected requests to reach the server")
+	}
+}
+
+func TestConcurrentSetters(t *testing.T) {
+	keys := map[string]bool{"Bearer key-a": true, "Bearer key-b": true}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); !keys[auth] {
+			t.Errorf("Unexpected Authorization header %q", auth)
+		}
+		w.Write([]byte(`{"status": "healthy"}`))
+	})
+	serverA := httptest.NewServer(handler)
+	defer serverA.Close()
+	serverB := httptest.NewServer(handler)
+	defer serverB.Close()
+
+	client := NewClient(serverA.URL, WithAPIKey("key-a"))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := client.Health(); err != nil {
+					t.Errorf("Health failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				client.SetAPIKey("key-b")
+				client.SetBaseURL(serverB.URL)
+			} else {
+				client.SetAPIKey("key-a")
+				client.SetBaseURL(serverA.URL)
+			}
+			client.With(WithMaxIdleConns(10))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSetBaseURLCopiesTransport(t *testing.T) {
+	client := NewClient("http://localhost:8000", WithAPIKey("test-key"))
+	transport := client.HTTPClient.Transport
+
+	client.SetBaseURL("unix:///var/run/aiptx.sock")
+	if client.BaseURL != unixBaseURL {
+		t.Errorf("Expected base URL %s, got %s", unixBaseURL, client.BaseURL)
+	}
+	if client.HTTPClient.Transport == transport {
+		t.Errorf("Expected a copy of the transport for the socket")
+	}
+	if transport.(*http.Transport).Proxy == nil {
+		t.Errorf("Expected the original transport to be unchanged")
+	}
+
+	client.SetAPIKey("rotated")
+	if client.APIKey != "rotated" {
+		t.Errorf("Expected API key 'rotated', got '%s'", client.APIKey)
+	}
+}
+
+func TestStructLiteralClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer rotated" {
+			t.Errorf("Expected rotated API key, got %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, APIKey: "key", HTTPClient: http.DefaultClient}
+	client.SetAPIKey("rotated")
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Errorf("StartScan failed: %v", err)
+	}
+	if _, err := client.With(WithOrganization("org")).GetScanStatus("scan-1"); err != nil {
+		t.Errorf("GetScanStatus failed: %v", err)
+	}
+	if err := (&Client{BaseURL: server.URL}).Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+
+	// Without an HTTPClient, WithTimeout sets the timeout on a copy of the
+	// default client.
+	literal := &Client{BaseURL: server.URL, APIKey: "rotated"}
+	timed := literal.With(WithTimeout(time.Second))
+	if timed.HTTPClient == nil || timed.HTTPClient.Timeout != time.Second || http.DefaultClient.Timeout != 0 {
+		t.Errorf("Expected a copy of the default client with a timeout, got %+v", timed.HTTPClient)
+	}
+	if _, err := timed.GetScanStatus("scan-1"); err != nil {
+		t.Errorf("GetScanStatus failed: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package aiptx
+
+import "context"
+
+// =============================================================================
+// Context
+// =============================================================================
+
+type contextKey int
+
+const apiKeyContextKey contextKey = iota
+
+// WithContextAPIKey returns a context carrying an API key that overrides the
+// client's own key for requests made with it. Every method that sends a
+// request either takes a context or has a Context variant that does. A
+// service using one shared client can forward each end user's token this way:
+//
+//	ctx := aiptx.WithContextAPIKey(r.Context(), userToken)
+//	findings, err := client.ListFindingsContext(ctx, nil)
+func WithContextAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey, apiKey)
+}
+
+// ContextAPIKey returns the API key stored in ctx by WithContextAPIKey.
+func ContextAPIKey(ctx context.Context) (string, bool) {
+	apiKey, ok := ctx.Value(apiKeyContextKey).(string)
+	return apiKey, ok
+}
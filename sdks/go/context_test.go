@@ -0,0 +1,56 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextAPIKey(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"events": [{"id": "1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("service-key"))
+	ctx := WithContextAPIKey(context.Background(), "user-token")
+
+	client.ListToolsContext(ctx)
+	if auth != "Bearer user-token" {
+		t.Errorf("Expected user token, got %q", auth)
+	}
+
+	client.ListTools()
+	if auth != "Bearer service-key" {
+		t.Errorf("Expected service key, got %q", auth)
+	}
+
+	client.FindSimilarFindingsContext(ctx, 1, nil)
+	if auth != "Bearer user-token" {
+		t.Errorf("Expected user token for FindSimilarFindingsContext, got %q", auth)
+	}
+
+	stream := client.PollEvents(ctx, "")
+	stream.Next()
+	if auth != "Bearer user-token" {
+		t.Errorf("Expected user token for context methods, got %q", auth)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.HealthContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, err := client.CompareProjectsContext(ctx, 1, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from CompareProjectsContext, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Cursor Stores
+// =============================================================================
+
+// CursorStore persists the ID of the last processed event so an event stream
+// can resume after a restart. Implementations backed by a database or key
+// value store can be shared by several processes consuming the same stream.
+type CursorStore interface {
+	// Load returns the stored cursor, or "" if none has been saved.
+	Load(ctx context.Context) (string, error)
+	// Save stores cursor, replacing any previous value.
+	Save(ctx context.Context, cursor string) error
+}
+
+// MemoryCursorStore keeps the cursor in memory. It is mostly useful in tests.
+type MemoryCursorStore struct {
+	mu     sync.Mutex
+	cursor string
+}
+
+// Load returns the stored cursor.
+func (m *MemoryCursorStore) Load(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursor, nil
+}
+
+// Save stores cursor.
+func (m *MemoryCursorStore) Save(ctx context.Context, cursor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursor = cursor
+	return nil
+}
+
+// FileCursorStore keeps the cursor in a file. Writes are atomic, so a crash
+// never leaves a truncated cursor behind.
+type FileCursorStore struct {
+	Path string
+}
+
+// NewFileCursorStore returns a store that keeps the cursor at path.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{Path: path}
+}
+
+// Load reads the cursor from the file. A missing file yields "".
+func (f *FileCursorStore) Load(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Save writes cursor to a temporary file and renames it over the store file.
+func (f *FileCursorStore) Save(ctx context.Context, cursor string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(cursor + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
@@ -0,0 +1,62 @@
+package aiptx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileCursorStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileCursorStore(filepath.Join(t.TempDir(), "cursor"))
+
+	if cursor, err := store.Load(ctx); err != nil || cursor != "" {
+		t.Errorf("Expected empty cursor, got %q (%v)", cursor, err)
+	}
+	if err := store.Save(ctx, "evt-42"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cursor, _ := store.Load(ctx); cursor != "evt-42" {
+		t.Errorf("Expected evt-42, got %q", cursor)
+	}
+}
+
+func TestEventsResumeFromStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "1":
+			w.Write([]byte(`{"events": [{"id": "2"}, {"id": "3"}]}`))
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store := &MemoryCursorStore{}
+	store.Save(ctx, "1")
+
+	client := NewClient(server.URL)
+	stream := client.Events(ctx, WithCursorStore(store))
+
+	if !stream.Next() || stream.Event().ID != "2" {
+		t.Fatalf("Expected event 2, got %+v", stream.Event())
+	}
+	if cursor, _ := store.Load(ctx); cursor != "1" {
+		t.Errorf("Expected cursor to be saved only after processing, got %q", cursor)
+	}
+
+	if !stream.Next() || stream.Event().ID != "3" {
+		t.Fatalf("Expected event 3, got %+v", stream.Event())
+	}
+	if cursor, _ := store.Load(ctx); cursor != "2" {
+		t.Errorf("Expected cursor 2, got %q", cursor)
+	}
+
+	stream.Next()
+	if cursor, _ := store.Load(ctx); cursor != "3" {
+		t.Errorf("Expected cursor 3, got %q", cursor)
+	}
+}
@@ -0,0 +1,211 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Deprecations
+// =============================================================================
+
+// Deprecation describes an endpoint the server has marked as deprecated with
+// the Deprecation (RFC 9745) and Sunset (RFC 8594) response headers.
+type Deprecation struct {
+	Method string
+	Path   string
+	// Route is Path with its IDs replaced by "{id}", such as
+	// "/projects/{id}/sessions".
+	Route string
+	// Caller is the Client method that made the request, such as
+	// "StartScan", if it could be determined.
+	Caller string
+
+	// Date is when the endpoint was or will be deprecated. It is zero if the
+	// server only reported that the endpoint is deprecated.
+	Date time.Time
+	// Sunset is when the endpoint is expected to stop working, or zero if
+	// unknown.
+	Sunset time.Time
+	// Successor is the URL of the replacement endpoint from a Link header
+	// with rel="successor-version", if any.
+	Successor string
+	// Info is the URL of documentation from a Link header with
+	// rel="deprecation" or rel="sunset", if any.
+	Info string
+}
+
+func (d Deprecation) String() string {
+	var b strings.Builder
+	if d.Caller != "" {
+		b.WriteString("Client." + d.Caller + ": ")
+	}
+	b.WriteString(d.Method + " " + d.Path + " is deprecated")
+	if !d.Sunset.IsZero() {
+		b.WriteString(" and will be removed on " + d.Sunset.Format("2006-01-02"))
+	}
+	if d.Successor != "" {
+		b.WriteString("; use " + d.Successor)
+	}
+	if d.Info != "" {
+		b.WriteString(" (see " + d.Info + ")")
+	}
+	return b.String()
+}
+
+// deprecations records the endpoints a client has reported, so each is
+// reported once. It is shared by clients created with With.
+type deprecations struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// checkDeprecation reports an endpoint to the OnDeprecation hook and the
+// client's logger the first time a response marks it as deprecated, once
+// per method and route.
+func (c *Client) checkDeprecation(req *http.Request, resp *http.Response) {
+	if c.Hooks.OnDeprecation == nil && c.logger == nil {
+		return
+	}
+	d, ok := parseDeprecation(resp.Header)
+	if !ok {
+		return
+	}
+	d.Method = req.Method
+	d.Path = strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(c.basePath(), "/"))
+	d.Route = route(d.Path)
+
+	if c.deprecations != nil {
+		key := d.Method + " " + d.Route
+		c.deprecations.mu.Lock()
+		seen := c.deprecations.seen[key]
+		c.deprecations.seen[key] = true
+		c.deprecations.mu.Unlock()
+		if seen {
+			return
+		}
+	}
+	d.Caller = clientCaller()
+	c.logf("aiptx: %v", d)
+	if c.Hooks.OnDeprecation != nil {
+		c.Hooks.OnDeprecation(d)
+	}
+}
+
+// route replaces the IDs in path with "{id}". Segments containing a digit
+// are taken to be IDs, apart from version prefixes such as "v2".
+func route(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.ContainsAny(s, "0123456789") && !isVersionSegment(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isVersionSegment(s string) bool {
+	digits, ok := strings.CutPrefix(s, "v")
+	_, err := strconv.Atoi(digits)
+	return ok && err == nil
+}
+
+// clientCaller returns the name of the outermost exported Client method on
+// the call stack, such as "StartScan", or "" if there is none.
+func clientCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	// The first frame is clientCaller itself, whose name gives the package
+	// prefix, "github.com/aiptx/aiptx-go.".
+	first, more := frames.Next()
+	slash := strings.LastIndex(first.Function, "/")
+	pkg := first.Function[:slash+strings.Index(first.Function[slash:], ".")+1]
+	prefix := pkg + "(*Client)."
+
+	caller := ""
+	for more {
+		var frame runtime.Frame
+		frame, more = frames.Next()
+		if !strings.HasPrefix(frame.Function, pkg) {
+			break
+		}
+		name, ok := strings.CutPrefix(frame.Function, prefix)
+		if ok && !strings.Contains(name, ".") && name != "" && name[0] >= 'A' && name[0] <= 'Z' {
+			caller = name
+		}
+	}
+	return caller
+}
+
+// basePath returns the path component of BaseURL.
+func (c *Client) basePath() string {
+	u, err := url.Parse(c.primaryURL())
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// parseDeprecation reads the Deprecation, Sunset and Link headers of a
+// response. A Sunset header alone also marks the endpoint as deprecated.
+func parseDeprecation(h http.Header) (Deprecation, bool) {
+	var d Deprecation
+	deprecation := strings.TrimSpace(h.Get("Deprecation"))
+	sunset := strings.TrimSpace(h.Get("Sunset"))
+	if deprecation == "" && sunset == "" || strings.EqualFold(deprecation, "false") {
+		return d, false
+	}
+
+	d.Date = parseHeaderDate(deprecation)
+	d.Sunset = parseHeaderDate(sunset)
+	for _, link := range h.Values("Link") {
+		for _, l := range strings.Split(link, ",") {
+			target, rel := parseLink(l)
+			switch rel {
+			case "successor-version":
+				d.Successor = target
+			case "deprecation", "sunset":
+				if d.Info == "" {
+					d.Info = target
+				}
+			}
+		}
+	}
+	return d, true
+}
+
+// parseHeaderDate parses a structured field date ("@1688169599") or an HTTP
+// date, returning the zero time for anything else, such as "true".
+func parseHeaderDate(s string) time.Time {
+	if unix, ok := strings.CutPrefix(s, "@"); ok {
+		if sec, err := strconv.ParseInt(unix, 10, 64); err == nil {
+			return time.Unix(sec, 0).UTC()
+		}
+		return time.Time{}
+	}
+	t, _ := http.ParseTime(s)
+	return t
+}
+
+// parseLink parses one link-value of a Link header, such as
+// `</v2/scans>; rel="successor-version"`.
+func parseLink(s string) (target, rel string) {
+	parts := strings.Split(s, ";")
+	target = strings.TrimSpace(parts[0])
+	if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+		return "", ""
+	}
+	target = target[1 : len(target)-1]
+	for _, p := range parts[1:] {
+		name, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+		if strings.EqualFold(strings.TrimSpace(name), "rel") {
+			rel = strings.ToLower(strings.Trim(strings.TrimSpace(value), `"`))
+		}
+	}
+	return target, rel
+}
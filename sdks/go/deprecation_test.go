@@ -0,0 +1,117 @@
+package aiptx
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	h := http.Header{}
+	h.Set("Deprecation", "@1735689600")
+	h.Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+	h.Add("Link", `</v2/scans>; rel="successor-version", <https://docs.aiptx.io/migrate>; rel="deprecation"`)
+
+	d, ok := parseDeprecation(h)
+	if !ok {
+		t.Fatal("Expected deprecation")
+	}
+	if !d.Date.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected deprecation date 2025-01-01, got %v", d.Date)
+	}
+	if !d.Sunset.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected sunset 2026-07-01, got %v", d.Sunset)
+	}
+	if d.Successor != "/v2/scans" || d.Info != "https://docs.aiptx.io/migrate" {
+		t.Errorf("Expected links, got successor %q and info %q", d.Successor, d.Info)
+	}
+
+	h = http.Header{}
+	h.Set("Deprecation", "true")
+	if d, ok := parseDeprecation(h); !ok || !d.Date.IsZero() {
+		t.Errorf("Expected undated deprecation, got %+v, %v", d, ok)
+	}
+	if _, ok := parseDeprecation(http.Header{}); ok {
+		t.Error("Expected no deprecation without headers")
+	}
+}
+
+func TestDeprecationHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/scan" {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", `</api/v2/scans>; rel="successor-version"`)
+		}
+		w.Write([]byte(`{"scan_id": "s1"}`))
+	}))
+	defer server.Close()
+
+	var got []Deprecation
+	client := NewClient(server.URL + "/api")
+	client.Hooks.OnDeprecation = func(d Deprecation) { got = append(got, d) }
+
+	client.StartScan(&ScanRequest{Target: "example.com"})
+	client.StartScan(&ScanRequest{Target: "example.com"})
+	client.Health()
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one deprecation, got %d", len(got))
+	}
+	if got[0].Method != "POST" || got[0].Path != "/scan" || got[0].Successor != "/api/v2/scans" {
+		t.Errorf("Expected POST /scan deprecation, got %+v", got[0])
+	}
+}
+
+func TestDeprecationRoute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/projects/") {
+			w.Header().Set("Deprecation", "true")
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	var got []Deprecation
+	var out bytes.Buffer
+	client := NewClient(server.URL, WithLogger(log.New(&out, "", 0)))
+	client.Hooks.OnDeprecation = func(d Deprecation) { got = append(got, d) }
+
+	client.GetProject(1)
+	client.GetProject(2)
+	client.DeleteProject(1)
+
+	if len(got) != 2 {
+		t.Fatalf("Expected one deprecation per method and route, got %d", len(got))
+	}
+	if got[0].Route != "/projects/{id}" || got[0].Caller != "GetProject" {
+		t.Errorf("Expected GetProject on /projects/{id}, got %+v", got[0])
+	}
+	if got[1].Method != "DELETE" || got[1].Caller != "DeleteProject" {
+		t.Errorf("Expected DeleteProject, got %+v", got[1])
+	}
+	if !strings.Contains(out.String(), "Client.GetProject: GET /projects/1 is deprecated") {
+		t.Errorf("Expected per-method warning in log, got %q", out.String())
+	}
+	if got := fmt.Sprint(got[1]); !strings.HasPrefix(got, "Client.DeleteProject: DELETE") {
+		t.Errorf("Expected String to be used, got %q", got)
+	}
+}
+
+func TestRoute(t *testing.T) {
+	tests := map[string]string{
+		"/projects/42/sessions": "/projects/{id}/sessions",
+		"/scans/scan-1/cancel":  "/scans/{id}/cancel",
+		"/v2/scans":             "/v2/scans",
+		"/health/ready":         "/health/ready",
+	}
+	for path, want := range tests {
+		if got := route(path); got != want {
+			t.Errorf("route(%q): expected %q, got %q", path, want, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package aiptx
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// Component Diagnostics
+// =============================================================================
+
+// Component types.
+const (
+	ComponentDatabase = "database"
+	ComponentLLM      = "llm"
+	ComponentScanner  = "scanner"
+)
+
+// ComponentStatus is the health of one server component. Statuses derived
+// from HealthStatus only set Name, Type and Available; those returned by
+// GetComponentDiagnostics also explain failures.
+type ComponentStatus struct {
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Available bool      `json:"available"`
+	Version   string    `json:"version,omitempty"`
+	LastCheck time.Time `json:"last_check,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMS float64   `json:"latency_ms,omitempty"`
+}
+
+// Latency returns the duration of the component's last health check.
+func (s ComponentStatus) Latency() time.Duration {
+	return time.Duration(s.LatencyMS * float64(time.Millisecond))
+}
+
+// ComponentStatuses returns the database, LLM and scanner availability of
+// the health status as typed statuses, with scanners sorted by name.
+func (h *HealthStatus) ComponentStatuses() []ComponentStatus {
+	statuses := []ComponentStatus{
+		{Name: ComponentDatabase, Type: ComponentDatabase, Available: h.Components.Database},
+		{Name: ComponentLLM, Type: ComponentLLM, Available: h.Components.LLM},
+	}
+	names := make([]string, 0, len(h.Components.Scanners))
+	for name := range h.Components.Scanners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		statuses = append(statuses, ComponentStatus{Name: name, Type: ComponentScanner, Available: h.Components.Scanners[name]})
+	}
+	return statuses
+}
+
+// ComponentDiagnostics is the detailed health of every server component.
+type ComponentDiagnostics struct {
+	Status     string            `json:"status"`
+	CheckedAt  time.Time         `json:"checked_at"`
+	Components []ComponentStatus `json:"components"`
+
+	unknown *rawFields
+}
+
+// Component returns the status of the named component, or nil if the
+// server did not report it.
+func (d *ComponentDiagnostics) Component(name string) *ComponentStatus {
+	for i := range d.Components {
+		if d.Components[i].Name == name {
+			return &d.Components[i]
+		}
+	}
+	return nil
+}
+
+// Unavailable returns the components that are not available.
+func (d *ComponentDiagnostics) Unavailable() []ComponentStatus {
+	var down []ComponentStatus
+	for _, s := range d.Components {
+		if !s.Available {
+			down = append(down, s)
+		}
+	}
+	return down
+}
+
+// GetComponentDiagnostics returns the detailed health of the server's
+// components, including scanner versions, the time and latency of each
+// component's last check and the error that made it unavailable.
+func (c *Client) GetComponentDiagnostics() (*ComponentDiagnostics, error) {
+	return c.GetComponentDiagnosticsContext(context.Background())
+}
+
+// GetComponentDiagnosticsContext is like GetComponentDiagnostics but uses ctx for its
+// request.
+func (c *Client) GetComponentDiagnosticsContext(ctx context.Context) (*ComponentDiagnostics, error) {
+	var diagnostics ComponentDiagnostics
+	if err := c.requestContext(ctx, "GET", "/health/components", nil, &diagnostics); err != nil {
+		return nil, err
+	}
+	return &diagnostics, nil
+}
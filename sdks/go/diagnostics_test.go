@@ -0,0 +1,72 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetComponentDiagnostics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health/components" {
+			t.Errorf("Expected path /health/components, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"status": "degraded",
+			"checked_at": "2024-03-12T09:15:00Z",
+			"components": [
+				{"name": "nuclei", "type": "scanner", "available": true, "version": "3.1.4", "latency_ms": 41.5},
+				{"name": "sqlmap", "type": "scanner", "available": false, "last_check": "2024-03-12T09:14:55Z", "error": "executable not found in PATH"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	diagnostics, err := client.GetComponentDiagnostics()
+	if err != nil {
+		t.Fatalf("GetComponentDiagnostics failed: %v", err)
+	}
+	if diagnostics.Status != "degraded" {
+		t.Errorf("Expected status 'degraded', got '%s'", diagnostics.Status)
+	}
+
+	nuclei := diagnostics.Component("nuclei")
+	if nuclei == nil || nuclei.Version != "3.1.4" {
+		t.Fatalf("Expected nuclei 3.1.4, got %+v", nuclei)
+	}
+	if nuclei.Latency() != 41500*time.Microsecond {
+		t.Errorf("Expected latency 41.5ms, got %v", nuclei.Latency())
+	}
+
+	down := diagnostics.Unavailable()
+	if len(down) != 1 || down[0].Name != "sqlmap" || down[0].Error != "executable not found in PATH" {
+		t.Errorf("Expected sqlmap to be unavailable with its error, got %+v", down)
+	}
+	if diagnostics.Component("nmap") != nil {
+		t.Errorf("Expected no status for unreported component")
+	}
+}
+
+func TestComponentStatuses(t *testing.T) {
+	var health HealthStatus
+	health.Components.Database = true
+	health.Components.Scanners = map[string]bool{"sqlmap": false, "nmap": true}
+
+	statuses := health.ComponentStatuses()
+	want := []ComponentStatus{
+		{Name: "database", Type: ComponentDatabase, Available: true},
+		{Name: "llm", Type: ComponentLLM, Available: false},
+		{Name: "nmap", Type: ComponentScanner, Available: true},
+		{Name: "sqlmap", Type: ComponentScanner, Available: false},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("Expected %d statuses, got %d", len(want), len(statuses))
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Errorf("Expected status %+v, got %+v", want[i], statuses[i])
+		}
+	}
+}
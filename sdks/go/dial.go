@@ -0,0 +1,78 @@
+package aiptx
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Dialing
+// =============================================================================
+
+// unixBaseURL replaces unix:// base URLs. Its host is never resolved, since
+// connections go to the socket.
+const unixBaseURL = "http://localhost"
+
+// WithDialer makes the client open connections with dial, for example to
+// tunnel them through another host. It takes precedence over unix socket base
+// URLs, WithResolver and WithDNSCache, and over the HTTP proxy of the
+// environment, which would otherwise route requests around dial.
+func WithDialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {
+	return func(c *Client) {
+		c.dial = dial
+		c.configureDialer()
+	}
+}
+
+// setBaseURL sets the base URL, routing connections to a unix domain socket
+// for unix:// URLs.
+func (c *Client) setBaseURL(baseURL string) {
+	socketPath := ""
+	if path, ok := strings.CutPrefix(baseURL, "unix://"); ok {
+		baseURL, socketPath = unixBaseURL, path
+	}
+	c.BaseURL = baseURL
+	c.versionCheck = &versionCheck{}
+	if socketPath != c.socketPath {
+		c.socketPath = socketPath
+		c.configureDialer()
+	}
+}
+
+// configureDialer installs a dial function on the client's transport that
+// honours its dialer, socket, resolver and DNS cache settings.
+func (c *Client) configureDialer() {
+	t := c.transport()
+	if t == nil {
+		return
+	}
+
+	if c.dial != nil {
+		t.Proxy = nil
+		t.DialContext = c.dial
+		return
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Resolver: c.resolver}
+	if path := c.socketPath; path != "" {
+		// Requests must not be sent to an HTTP proxy instead of the socket.
+		t.Proxy = nil
+		t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		}
+		return
+	}
+	if c.dnsTTL <= 0 {
+		t.DialContext = dialer.DialContext
+		return
+	}
+
+	resolver := c.resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	cache := &dnsCache{lookupHost: resolver.LookupHost, ttl: c.dnsTTL, dialer: dialer}
+	t.DialContext = cache.DialContext
+}
@@ -0,0 +1,92 @@
+package aiptx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "aiptx.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			t.Errorf("Expected path /health, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client := NewClient("unix://" + socket)
+	if client.BaseURL != unixBaseURL {
+		t.Errorf("Expected base URL %s, got %s", unixBaseURL, client.BaseURL)
+	}
+	health, err := client.Health()
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("Expected status 'ok', got '%s'", health.Status)
+	}
+}
+
+func TestWithDialer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	var dialed []string
+	client := NewClient("http://aiptx.invalid", WithDialer(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return net.Dial("tcp", server.Listener.Addr().String())
+	}))
+
+	if _, err := client.Health(); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if len(dialed) != 1 || dialed[0] != "aiptx.invalid:80" {
+		t.Errorf("Expected dial of aiptx.invalid:80, got %v", dialed)
+	}
+	if client.HTTPClient.Transport.(*http.Transport).Proxy != nil {
+		t.Errorf("Expected no HTTP proxy with a dialer")
+	}
+}
+
+func TestWithDialerCustomHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	var dialed int
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed++
+		return net.Dial("tcp", server.Listener.Addr().String())
+	}
+	for _, opts := range [][]ClientOption{
+		{WithDialer(dial), WithHTTPClient(&http.Client{})},
+		{WithHTTPClient(&http.Client{}), WithDialer(dial)},
+		{WithDialer(dial), WithHTTPClient(nil)},
+	} {
+		dialed = 0
+		client := NewClient("http://aiptx.invalid", opts...)
+		if _, err := client.Health(); err != nil {
+			t.Fatalf("Health failed: %v", err)
+		}
+		if dialed != 1 {
+			t.Errorf("Expected the dialer to be used, got %d dials", dialed)
+		}
+	}
+	if http.DefaultTransport.(*http.Transport).Proxy == nil {
+		t.Errorf("http.DefaultTransport must not be modified")
+	}
+}
@@ -0,0 +1,102 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// =============================================================================
+// Digests
+// =============================================================================
+
+// Digest frequencies.
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestSubscription sends a scheduled summary of new findings to an email
+// address, which does not need an AIPTX account. A ProjectID of 0 subscribes
+// to the whole organization.
+type DigestSubscription struct {
+	ID          string    `json:"id,omitempty"`
+	Email       string    `json:"email"`
+	ProjectID   int64     `json:"project_id,omitempty"`
+	Frequency   string    `json:"frequency,omitempty"`
+	MinSeverity Severity  `json:"min_severity,omitempty"`
+	Hour        int       `json:"hour"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	LastSentAt  time.Time `json:"last_sent_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// SubscribeDigest creates a digest subscription. Frequency defaults to
+// DigestDaily and Hour, the UTC hour the digest is sent at, to midnight.
+// Only findings of at least MinSeverity are included, if it is set.
+func (c *Client) SubscribeDigest(sub *DigestSubscription) (*DigestSubscription, error) {
+	return c.SubscribeDigestContext(context.Background(), sub)
+}
+
+// SubscribeDigestContext is like SubscribeDigest but uses ctx for its request.
+func (c *Client) SubscribeDigestContext(ctx context.Context, sub *DigestSubscription) (*DigestSubscription, error) {
+	if sub.Email == "" {
+		return nil, fmt.Errorf("digest subscription has no email")
+	}
+	if sub.Hour < 0 || sub.Hour > 23 {
+		return nil, fmt.Errorf("digest hour %d is not between 0 and 23", sub.Hour)
+	}
+	body := map[string]interface{}{
+		"email":     sub.Email,
+		"frequency": sub.Frequency,
+		"hour":      sub.Hour,
+	}
+	if sub.Frequency == "" {
+		body["frequency"] = DigestDaily
+	}
+	if sub.ProjectID != 0 {
+		body["project_id"] = sub.ProjectID
+	}
+	if sub.MinSeverity != "" {
+		body["min_severity"] = sub.MinSeverity
+	}
+	var created DigestSubscription
+	if err := c.requestContext(ctx, "POST", "/digests", body, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ListDigestSubscriptions returns the digest subscriptions of a project, or
+// of the whole organization if projectID is 0.
+func (c *Client) ListDigestSubscriptions(projectID int64) ([]DigestSubscription, error) {
+	return c.ListDigestSubscriptionsContext(context.Background(), projectID)
+}
+
+// ListDigestSubscriptionsContext is like ListDigestSubscriptions but uses ctx for its
+// request.
+func (c *Client) ListDigestSubscriptionsContext(ctx context.Context, projectID int64) ([]DigestSubscription, error) {
+	params := url.Values{}
+	if projectID != 0 {
+		params.Set("project_id", strconv.FormatInt(projectID, 10))
+	}
+	var subs []DigestSubscription
+	if err := c.requestContext(ctx, "GET", withQuery("/digests", params), nil, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// UnsubscribeDigest deletes a digest subscription.
+func (c *Client) UnsubscribeDigest(id string) error {
+	return c.UnsubscribeDigestContext(context.Background(), id)
+}
+
+// UnsubscribeDigestContext is like UnsubscribeDigest but uses ctx for its
+// request.
+func (c *Client) UnsubscribeDigestContext(ctx context.Context, id string) error {
+	return c.requestContext(ctx, "DELETE", "/digests/"+id, nil, nil)
+}
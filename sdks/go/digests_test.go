@@ -0,0 +1,80 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendReport(t *testing.T) {
+	var body map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/reports/r-1/send" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	if err := client.SendReport("r-1", []string{"ciso@acme.example", "dev@acme.example"}); err != nil {
+		t.Fatalf("SendReport failed: %v", err)
+	}
+	if len(body["recipients"]) != 2 || body["recipients"][0] != "ciso@acme.example" {
+		t.Errorf("Expected recipients, got %v", body)
+	}
+	if err := client.SendReport("r-1", nil); err == nil {
+		t.Errorf("Expected error without recipients")
+	}
+}
+
+func TestDigestSubscriptions(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /digests":
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"id": "d-1", "email": "ciso@acme.example", "frequency": "daily", "hour": 8, "min_severity": "high"}`))
+		case "GET /digests":
+			if r.URL.Query().Get("project_id") != "42" {
+				t.Errorf("Expected project_id=42, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"id": "d-1", "email": "ciso@acme.example", "project_id": 42}]`))
+		case "DELETE /digests/d-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	sub, err := client.SubscribeDigest(&DigestSubscription{Email: "ciso@acme.example", Hour: 8, MinSeverity: SeverityHigh})
+	if err != nil {
+		t.Fatalf("SubscribeDigest failed: %v", err)
+	}
+	if body["frequency"] != DigestDaily || body["hour"] != 8.0 || body["min_severity"] != "high" {
+		t.Errorf("Expected daily digest at 08:00, got %v", body)
+	}
+	if _, ok := body["project_id"]; ok {
+		t.Errorf("Expected organization digest, got %v", body)
+	}
+	if sub.ID != "d-1" || sub.Frequency != DigestDaily {
+		t.Errorf("Expected subscription, got %+v", sub)
+	}
+
+	subs, err := client.ListDigestSubscriptions(42)
+	if err != nil || len(subs) != 1 || subs[0].ProjectID != 42 {
+		t.Errorf("Expected one subscription, got %+v (%v)", subs, err)
+	}
+	if err := client.UnsubscribeDigest("d-1"); err != nil {
+		t.Errorf("UnsubscribeDigest failed: %v", err)
+	}
+
+	if _, err := client.SubscribeDigest(&DigestSubscription{Email: "a@b.example", Hour: 24}); err == nil {
+		t.Errorf("Expected error for hour 24")
+	}
+}
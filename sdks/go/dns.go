@@ -0,0 +1,124 @@
+package aiptx
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// DNS
+// =============================================================================
+
+// WithResolver resolves the server host name with r instead of the system
+// resolver, e.g. to query a specific DNS server.
+func WithResolver(r *net.Resolver) ClientOption {
+	return func(c *Client) {
+		c.resolver = r
+		c.configureDialer()
+	}
+}
+
+// WithDNSCache caches resolved server addresses for ttl. Clients in
+// Kubernetes otherwise resolve the server name on every new connection,
+// which adds up to heavy load on cluster DNS. Cached addresses are tried in
+// turn, and an entry is dropped early if none of its addresses accept a
+// connection.
+func WithDNSCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.dnsTTL = ttl
+		c.configureDialer()
+	}
+}
+
+// dnsCache resolves host names through a cache and dials the results.
+type dnsCache struct {
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	ttl        time.Duration
+	dialer     *net.Dialer
+
+	mu      sync.Mutex
+	entries map[string]*dnsEntry
+}
+
+type dnsEntry struct {
+	ready   chan struct{}
+	addrs   []string
+	err     error
+	expires time.Time
+}
+
+// DialContext dials addr, resolving its host through the cache.
+func (d *dnsCache) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+
+	entry, err := d.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range entry.addrs {
+		conn, dialErr := d.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if dialErr == nil {
+			return conn, nil
+		}
+		err = dialErr
+	}
+	// The addresses may be stale, so resolve afresh next time.
+	d.forget(host, entry)
+	return nil, err
+}
+
+// lookup returns the cached entry of host, resolving it if the entry is
+// missing or expired. Concurrent lookups of the same host share one query,
+// which is not cancelled if the caller that started it gives up.
+func (d *dnsCache) lookup(ctx context.Context, host string) (*dnsEntry, error) {
+	d.mu.Lock()
+	if d.entries == nil {
+		d.entries = make(map[string]*dnsEntry)
+	}
+	entry, ok := d.entries[host]
+	if !ok || entryExpired(entry) {
+		entry = &dnsEntry{ready: make(chan struct{})}
+		d.entries[host] = entry
+		go func() {
+			entry.addrs, entry.err = d.lookupHost(context.WithoutCancel(ctx), host)
+			entry.expires = time.Now().Add(d.ttl)
+			if entry.err != nil {
+				d.forget(host, entry)
+			}
+			close(entry.ready)
+		}()
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-entry.ready:
+		return entry, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// entryExpired reports whether a resolved entry is past its TTL. Entries
+// still being resolved are not expired.
+func entryExpired(e *dnsEntry) bool {
+	select {
+	case <-e.ready:
+		return time.Now().After(e.expires)
+	default:
+		return false
+	}
+}
+
+// forget drops the entry of host, unless a newer lookup has replaced it.
+func (d *dnsCache) forget(host string, entry *dnsEntry) {
+	d.mu.Lock()
+	if d.entries[host] == entry {
+		delete(d.entries, host)
+	}
+	d.mu.Unlock()
+}
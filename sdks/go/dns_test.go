@@ -0,0 +1,109 @@
+package aiptx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	_, port, _ := net.SplitHostPort(u.Host)
+
+	var lookups int32
+	cache := &dnsCache{
+		lookupHost: func(ctx context.Context, host string) ([]string, error) {
+			atomic.AddInt32(&lookups, 1)
+			if host != "aiptx.test" {
+				t.Errorf("Expected lookup of aiptx.test, got %s", host)
+			}
+			return []string{"127.0.0.1"}, nil
+		},
+		ttl:    50 * time.Millisecond,
+		dialer: &net.Dialer{},
+	}
+
+	for i := 0; i < 3; i++ {
+		conn, err := cache.DialContext(context.Background(), "tcp", net.JoinHostPort("aiptx.test", port))
+		if err != nil {
+			t.Fatalf("DialContext failed: %v", err)
+		}
+		conn.Close()
+	}
+	if n := atomic.LoadInt32(&lookups); n != 1 {
+		t.Errorf("Expected 1 lookup, got %d", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	conn, err := cache.DialContext(context.Background(), "tcp", net.JoinHostPort("aiptx.test", port))
+	if err != nil {
+		t.Fatalf("DialContext failed: %v", err)
+	}
+	conn.Close()
+	if n := atomic.LoadInt32(&lookups); n != 2 {
+		t.Errorf("Expected expired entry to be resolved again, got %d lookups", n)
+	}
+}
+
+func TestDNSCacheDialFailure(t *testing.T) {
+	var lookups int32
+	cache := &dnsCache{
+		lookupHost: func(ctx context.Context, host string) ([]string, error) {
+			atomic.AddInt32(&lookups, 1)
+			return []string{"127.0.0.1"}, nil
+		},
+		ttl:    time.Hour,
+		dialer: &net.Dialer{},
+	}
+
+	// Find a port with nothing listening on it.
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := l.Addr().(*net.TCPAddr)
+	l.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.DialContext(context.Background(), "tcp", net.JoinHostPort("aiptx.test", strconv.Itoa(addr.Port))); err == nil {
+			t.Fatal("Expected dial error")
+		}
+	}
+	if n := atomic.LoadInt32(&lookups); n != 2 {
+		t.Errorf("Expected entry to be dropped after failed dial, got %d lookups", n)
+	}
+
+	// A failure with a stale entry keeps the entry that replaced it.
+	stale := &dnsEntry{}
+	fresh := &dnsEntry{}
+	cache.entries["aiptx.test"] = fresh
+	cache.forget("aiptx.test", stale)
+	if cache.entries["aiptx.test"] != fresh {
+		t.Error("Expected newer entry to be kept")
+	}
+}
+
+func TestWithDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	baseURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	client := NewClient(baseURL, WithResolver(&net.Resolver{PreferGo: true}), WithDNSCache(time.Minute))
+	health, err := client.Health()
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("Expected status 'ok', got '%s'", health.Status)
+	}
+}
@@ -0,0 +1,150 @@
+package aiptx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// =============================================================================
+// Downloads
+// =============================================================================
+
+// ErrChecksumMismatch is returned when downloaded content does not match its
+// SHA-256 checksum. The content has already been written to the destination
+// and must be discarded.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// DownloadOption configures a download.
+type DownloadOption func(*downloadConfig)
+
+type downloadConfig struct {
+	progress     func(done, total int64)
+	checksum     string
+	skipChecksum bool
+}
+
+// WithChecksum verifies the content against a known hex-encoded SHA-256
+// checksum, such as Report.SHA256 or Evidence.SHA256, in addition to any
+// checksum sent by the server.
+func WithChecksum(sha256Hex string) DownloadOption {
+	return func(cfg *downloadConfig) {
+		cfg.checksum = strings.ToLower(sha256Hex)
+	}
+}
+
+// SkipChecksum disables checksum verification.
+func SkipChecksum() DownloadOption {
+	return func(cfg *downloadConfig) {
+		cfg.skipChecksum = true
+	}
+}
+
+// WithProgress calls fn as content is received with the bytes written so far
+// and the total size, which is -1 if the server did not report it.
+func WithProgress(fn func(done, total int64)) DownloadOption {
+	return func(cfg *downloadConfig) {
+		cfg.progress = fn
+	}
+}
+
+// DownloadReport writes the content of a completed report to w and returns
+// the number of bytes written. Cancelling ctx aborts the transfer. The
+// client's timeout does not apply, as large downloads take longer; bound
+// them with ctx instead.
+//
+// Downloads are verified against the SHA-256 checksum the server sends in the
+// X-Checksum-SHA256 or Digest header, and against any WithChecksum value; a
+// mismatch returns an error wrapping ErrChecksumMismatch.
+func (c *Client) DownloadReport(ctx context.Context, reportID string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	return c.download(ctx, "/reports/"+reportID+"/download", w, opts)
+}
+
+// DownloadEvidence writes the content of an evidence artifact to w and
+// returns the number of bytes written. Cancelling ctx aborts the transfer.
+func (c *Client) DownloadEvidence(ctx context.Context, evidenceID int64, w io.Writer, opts ...DownloadOption) (int64, error) {
+	return c.download(ctx, fmt.Sprintf("/evidence/%d/download", evidenceID), w, opts)
+}
+
+func (c *Client) download(ctx context.Context, path string, w io.Writer, opts []DownloadOption) (int64, error) {
+	cfg := &downloadConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	resp, err := c.With(WithTimeout(0)).do(ctx, "GET", path, "", nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if cfg.progress != nil {
+		w = &progressWriter{w: w, total: resp.ContentLength, fn: cfg.progress}
+	}
+
+	var expected []string
+	if !cfg.skipChecksum {
+		if sum := serverChecksum(resp.Header); sum != "" {
+			expected = append(expected, sum)
+		}
+		if cfg.checksum != "" {
+			expected = append(expected, cfg.checksum)
+		}
+	}
+	if len(expected) == 0 {
+		return io.Copy(w, resp.Body)
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), resp.Body)
+	if err != nil {
+		return n, err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	for _, sum := range expected {
+		if sum != actual {
+			return n, fmt.Errorf("%w: expected sha256 %s, got %s", ErrChecksumMismatch, sum, actual)
+		}
+	}
+	return n, nil
+}
+
+// serverChecksum returns the hex-encoded SHA-256 checksum from the response
+// headers, or "" if there is none.
+func serverChecksum(h http.Header) string {
+	if sum := h.Get("X-Checksum-SHA256"); sum != "" {
+		return strings.ToLower(sum)
+	}
+	// RFC 3230 Digest header: "SHA-256=<base64>", possibly among others.
+	for _, part := range strings.Split(h.Get("Digest"), ",") {
+		alg, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || !strings.EqualFold(alg, "SHA-256") {
+			continue
+		}
+		if raw, err := base64.StdEncoding.DecodeString(value); err == nil {
+			return hex.EncodeToString(raw)
+		}
+	}
+	return ""
+}
+
+// progressWriter reports the bytes written through it.
+type progressWriter struct {
+	w     io.Writer
+	done  int64
+	total int64
+	fn    func(done, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.fn(p.done, p.total)
+	return n, err
+}
@@ -0,0 +1,119 @@
+package aiptx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadReportProgress(t *testing.T) {
+	content := bytes.Repeat([]byte("report"), 10000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reports/r-1/download" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	var last, total int64
+	var buf bytes.Buffer
+	client := NewClient(server.URL)
+	n, err := client.DownloadReport(context.Background(), "r-1", &buf, WithProgress(func(done, size int64) {
+		last, total = done, size
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n != int64(len(content)) || !bytes.Equal(buf.Bytes(), content) {
+		t.Errorf("Expected %d bytes, got %d", len(content), n)
+	}
+	if last != n || total != n {
+		t.Errorf("Expected progress %d/%d, got %d/%d", n, n, last, total)
+	}
+}
+
+func TestDownloadCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 1024))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(server.URL)
+	_, err := client.DownloadEvidence(ctx, 1, &bytes.Buffer{}, WithProgress(func(done, total int64) {
+		cancel()
+	}))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation error, got %v", err)
+	}
+}
+
+func TestDownloadSlowBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 4; i++ {
+			w.Write([]byte("part"))
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	// The transfer takes longer than the client's timeout.
+	client := NewClient(server.URL, WithTimeout(100*time.Millisecond))
+	var buf bytes.Buffer
+	n, err := client.DownloadReport(context.Background(), "r-1", &buf)
+	if err != nil || n != 16 || buf.String() != "partpartpartpart" {
+		t.Errorf("Expected complete download, got %d bytes and %v", n, err)
+	}
+}
+
+func TestDownloadChecksum(t *testing.T) {
+	content := []byte("evidence")
+	sum := sha256.Sum256(content)
+	header := "X-Checksum-SHA256"
+	value := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(header, value)
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.DownloadEvidence(context.Background(), 1, &bytes.Buffer{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	header, value = "Digest", "SHA-256="+base64.StdEncoding.EncodeToString(sum[:])
+	if _, err := client.DownloadEvidence(context.Background(), 1, &bytes.Buffer{}); err != nil {
+		t.Errorf("Unexpected error with Digest header: %v", err)
+	}
+
+	header, value = "X-Checksum-SHA256", strings.Repeat("0", 64)
+	_, err := client.DownloadEvidence(context.Background(), 1, &bytes.Buffer{})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected ErrChecksumMismatch, got %v", err)
+	}
+	if _, err := client.DownloadEvidence(context.Background(), 1, &bytes.Buffer{}, SkipChecksum()); err != nil {
+		t.Errorf("Expected verification to be skipped, got %v", err)
+	}
+
+	header = "X-Unrelated"
+	_, err = client.DownloadEvidence(context.Background(), 1, &bytes.Buffer{}, WithChecksum(strings.Repeat("f", 64)))
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected ErrChecksumMismatch for WithChecksum, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Dry-Run Mode
+// =============================================================================
+
+// ErrDryRunUnsupported is returned for requests of a client created with
+// WithDryRun when the server does not support validate-only
+// requests. Such requests are never sent, so nothing is changed.
+var ErrDryRunUnsupported = errors.New("server does not support validate-only requests")
+
+// dryRunSupport caches whether the server supports validate-only requests.
+// It is shared by clients created with With.
+type dryRunSupport struct {
+	mu      sync.Mutex
+	checked bool
+	err     error
+}
+
+// WithDryRun makes the client send requests that could change server
+// state, such as POST, PUT, PATCH and DELETE, in the server's validate-only
+// mode: the server checks each request as usual and responds with what it
+// would have done, such as the project it would have created, without
+// changing anything. Use it to preflight automation against a production
+// server. Created resources come back without IDs.
+//
+// Before the first such request, the client checks that the server
+// advertises the FeatureDryRun feature flag, and returns
+// ErrDryRunUnsupported otherwise, as older servers would ignore the mode
+// and make the change.
+//
+// To preflight a manifest, use Apply with WithValidation.
+func WithDryRun() ClientOption {
+	return func(c *Client) {
+		c.dryRun = &dryRunSupport{}
+	}
+}
+
+// DryRun reports whether the client was created with WithDryRun.
+func (c *Client) DryRun() bool {
+	return c.dryRun != nil
+}
+
+// dryRunPath returns the path to send a request to, in validate-only mode if
+// the client uses it and the request could change server state.
+func (c *Client) dryRunPath(ctx context.Context, method, path string) (string, error) {
+	if c.dryRun == nil || !mutating(method) {
+		return path, nil
+	}
+	if err := c.checkDryRun(ctx); err != nil {
+		return "", err
+	}
+	if strings.Contains(path, "?") {
+		return path + "&dry_run=true", nil
+	}
+	return path + "?dry_run=true", nil
+}
+
+// checkDryRun returns ErrDryRunUnsupported unless the server reports the
+// FeatureDryRun flag as enabled. Unlike FeatureFlags.Enabled, a missing flag
+// counts as disabled.
+func (c *Client) checkDryRun(ctx context.Context) error {
+	d := c.dryRun
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.checked {
+		return d.err
+	}
+	var flags FeatureFlags
+	if err := c.requestContext(ctx, "GET", "/features", nil, &flags); err != nil {
+		return err
+	}
+	d.checked = true
+	if !flags.Flags[FeatureDryRun] {
+		d.err = ErrDryRunUnsupported
+	}
+	return d.err
+}
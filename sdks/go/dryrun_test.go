@@ -0,0 +1,132 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var requests []string
+	features := `{"flags": {"dry_run": true}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/features":
+			w.Write([]byte(features))
+		case "/projects":
+			w.Write([]byte(`{"name": "payments", "target": "pay.example.com"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithDryRun())
+	if !client.DryRun() || NewClient(server.URL).DryRun() {
+		t.Errorf("Expected only the WithDryRun client to be in dry-run mode")
+	}
+
+	project, err := client.CreateProject(&ProjectCreate{Name: "payments", Target: "pay.example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if project.Name != "payments" || project.ID != 0 {
+		t.Errorf("Expected the project that would be created, got %+v", project)
+	}
+	client.GetProject(1)
+	client.With(WithAPIKey("other")).DeleteProject(1)
+
+	want := []string{
+		"GET /features",
+		"POST /projects?dry_run=true",
+		"GET /projects/1",
+		"DELETE /projects/1?dry_run=true",
+	}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+
+	// Servers that do not advertise support never receive the request.
+	requests, features = nil, `{"flags": {}}`
+	client = NewClient(server.URL, WithDryRun())
+	if _, err := client.CreateProject(&ProjectCreate{Name: "x"}); !errors.Is(err, ErrDryRunUnsupported) {
+		t.Errorf("Expected ErrDryRunUnsupported, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected only the feature check, got %v", requests)
+	}
+}
+
+func TestApplyWithValidation(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_SECRET", "s3cret")
+	var mutations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			mutations = append(mutations, r.Method+" "+r.URL.RequestURI())
+		}
+		switch r.URL.Path {
+		case "/features":
+			w.Write([]byte(`{"flags": {"dry_run": true}}`))
+		case "/projects", "/webhooks", "/suppressions":
+			if r.Method == "GET" {
+				w.Write([]byte(`[]`))
+				return
+			}
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	changes, err := Apply(context.Background(), client, strings.NewReader(testManifest), WithValidation())
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	// Two projects, two schedules, a webhook and a suppression rule.
+	if len(changes) != 6 {
+		t.Errorf("Expected 6 changes, got %v", changes)
+	}
+	for _, m := range mutations {
+		if !strings.HasSuffix(m, "dry_run=true") {
+			t.Errorf("Expected validate-only request, got %s", m)
+		}
+	}
+	if len(mutations) != 3 {
+		t.Errorf("Expected schedules and suppressions of new projects not to be sent, got %v", mutations)
+	}
+	if client.DryRun() {
+		t.Errorf("Apply must not modify the caller's client")
+	}
+}
+
+func TestDryRunShutdown(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/features" {
+			w.Write([]byte(`{"flags": {"dry_run": true}}`))
+			return
+		}
+		w.Write([]byte(`{"status": "queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithDryRun())
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+	if _, err := client.CreateSession(1, &SessionCreate{Name: "s"}); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	// Nothing was started, so there is nothing to cancel.
+	requests = nil
+	if err := client.Shutdown(context.Background()); err != nil || len(requests) != 0 {
+		t.Errorf("Expected no cancellations, got %v (%v)", requests, err)
+	}
+}
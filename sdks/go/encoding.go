@@ -0,0 +1,90 @@
+package aiptx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// =============================================================================
+// Encoding
+// =============================================================================
+
+// maxPooledBuffer is the largest buffer returned to the pool, so that one
+// huge response does not pin its memory for the life of the process.
+const maxPooledBuffer = 4 << 20
+
+// jsonBuffer is a reusable buffer with an encoder writing into it.
+type jsonBuffer struct {
+	bytes.Buffer
+	enc *json.Encoder
+}
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := &jsonBuffer{}
+		b.enc = json.NewEncoder(&b.Buffer)
+		return b
+	},
+}
+
+func getBuffer() *jsonBuffer {
+	return bufferPool.Get().(*jsonBuffer)
+}
+
+func putBuffer(b *jsonBuffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+	b.Reset()
+	bufferPool.Put(b)
+}
+
+// encode writes v to the buffer as JSON.
+func (b *jsonBuffer) encode(v interface{}) error {
+	return b.enc.Encode(v)
+}
+
+// decodeResponse decodes the JSON body of resp into out, which may be nil,
+// and closes the body. The body is decoded as it is read rather than loaded
+// into memory first, except in strict mode, which needs the raw value to
+// find unknown fields. An empty body leaves out unchanged.
+func decodeResponse(resp *http.Response, out interface{}, strict bool) error {
+	defer resp.Body.Close()
+	if out == nil {
+		_, err := io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if strict {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			return errors.New("aiptx: unexpected data after JSON response")
+		}
+		return unmarshalStrict(raw, out)
+	}
+	if err := dec.Decode(out); err != nil && err != io.EOF {
+		return err
+	}
+	// Drain the rest so the connection can be reused.
+	_, err := io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// unmarshal decodes data into out, in strict mode if strict is set.
+func unmarshal(data []byte, out interface{}, strict bool) error {
+	if strict {
+		return unmarshalStrict(data, out)
+	}
+	return json.Unmarshal(data, out)
+}
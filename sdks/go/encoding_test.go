@@ -0,0 +1,127 @@
+package aiptx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleFindings returns n findings with realistically sized raw output.
+func sampleFindings(n int) []Finding {
+	findings := make([]Finding, n)
+	for i := range findings {
+		findings[i] = Finding{
+			ID:           int64(i + 1),
+			ProjectID:    1,
+			SessionID:    2,
+			Type:         "vulnerability",
+			Value:        fmt.Sprintf("CVE-2024-%04d", i),
+			Description:  "Reflected cross-site scripting in search parameter",
+			Severity:     "high",
+			Phase:        "exploit",
+			Tool:         "nuclei",
+			RawOutput:    strings.Repeat("HTTP/1.1 200 OK\r\nContent-Type: text/html\r\n", 20),
+			DiscoveredAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		}
+	}
+	return findings
+}
+
+func response(body []byte) *http.Response {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	body, _ := json.Marshal(sampleFindings(3))
+
+	var findings []Finding
+	if err := decodeResponse(response(body), &findings, false); err != nil {
+		t.Fatalf("decodeResponse failed: %v", err)
+	}
+	if len(findings) != 3 || findings[2].Value != "CVE-2024-0002" {
+		t.Errorf("Unexpected findings: %+v", findings)
+	}
+
+	// An empty body leaves out unchanged.
+	if err := decodeResponse(response(nil), &findings, false); err != nil {
+		t.Errorf("Expected no error for empty body, got %v", err)
+	}
+	if len(findings) != 3 {
+		t.Errorf("Expected findings to be unchanged, got %d", len(findings))
+	}
+
+	if err := decodeResponse(response([]byte(`[] []`)), &findings, true); err == nil {
+		t.Error("Expected error for trailing data in strict mode")
+	}
+}
+
+func TestEncodeBuffer(t *testing.T) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := buf.encode(&ScanRequest{Target: "example.com", Mode: "quick"}); err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	want, _ := json.Marshal(&ScanRequest{Target: "example.com", Mode: "quick"})
+	if got := bytes.TrimSpace(buf.Bytes()); !bytes.Equal(got, want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func BenchmarkDecodeFindings(b *testing.B) {
+	body, _ := json.Marshal(sampleFindings(500))
+
+	b.Run("ReadAll", func(b *testing.B) {
+		b.SetBytes(int64(len(body)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, _ := io.ReadAll(bytes.NewReader(body))
+			var findings []Finding
+			if err := json.Unmarshal(data, &findings); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Stream", func(b *testing.B) {
+		b.SetBytes(int64(len(body)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var findings []Finding
+			if err := decodeResponse(response(body), &findings, false); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkEncodeScanRequest(b *testing.B) {
+	req := &ScanRequest{Target: "example.com", Mode: "full", AI: true, Phases: []Phase{PhaseRecon, PhaseScan, PhaseExploit}}
+
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := getBuffer()
+			if err := buf.encode(req); err != nil {
+				b.Fatal(err)
+			}
+			putBuffer(buf)
+		}
+	})
+}
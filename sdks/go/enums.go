@@ -0,0 +1,144 @@
+package aiptx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Enumerations
+// =============================================================================
+
+// ErrInvalidValue is returned before a request is sent if a typed value,
+// such as a Severity in a FindingsFilter or the Mode of a ScanRequest, is
+// not one the SDK knows. The server would otherwise ignore it or match
+// nothing.
+var ErrInvalidValue = errors.New("invalid value")
+
+// Phase is a phase of a scan.
+type Phase string
+
+// Scan phases, in the order they run. These are the only phases the server
+// accepts.
+const (
+	PhaseRecon   Phase = "recon"
+	PhaseScan    Phase = "scan"
+	PhaseExploit Phase = "exploit"
+	PhaseReport  Phase = "report"
+)
+
+var phases = []Phase{PhaseRecon, PhaseScan, PhaseExploit, PhaseReport}
+
+// String returns the phase as sent to the server.
+func (p Phase) String() string {
+	return string(p)
+}
+
+// Valid reports whether p is one of the phases above.
+func (p Phase) Valid() bool {
+	for _, phase := range phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePhase returns the phase named s, ignoring case, or an error wrapping
+// ErrInvalidValue.
+func ParsePhase(s string) (Phase, error) {
+	p := Phase(strings.ToLower(s))
+	if !p.Valid() {
+		return "", fmt.Errorf("%w: unknown phase %q", ErrInvalidValue, s)
+	}
+	return p, nil
+}
+
+// ScanMode is the depth of a scan.
+type ScanMode string
+
+// Scan modes.
+const (
+	// ModeQuick runs essential checks only, in about five minutes.
+	ModeQuick ScanMode = "quick"
+	// ModeStandard balances coverage and time. It is the server's default.
+	ModeStandard ScanMode = "standard"
+	// ModeFull runs every check, including exploitation, and takes hours.
+	ModeFull ScanMode = "full"
+)
+
+// String returns the mode as sent to the server.
+func (m ScanMode) String() string {
+	return string(m)
+}
+
+// Valid reports whether m is one of the modes above.
+func (m ScanMode) Valid() bool {
+	switch m {
+	case ModeQuick, ModeStandard, ModeFull:
+		return true
+	}
+	return false
+}
+
+// ParseScanMode returns the mode named s, ignoring case, or an error
+// wrapping ErrInvalidValue.
+func ParseScanMode(s string) (ScanMode, error) {
+	m := ScanMode(strings.ToLower(s))
+	if !m.Valid() {
+		return "", fmt.Errorf("%w: unknown scan mode %q", ErrInvalidValue, s)
+	}
+	return m, nil
+}
+
+// ScanState is the status of a scan or session.
+type ScanState string
+
+// Scan statuses.
+const (
+	ScanQueued    ScanState = "queued"
+	ScanRunning   ScanState = "running"
+	ScanCompleted ScanState = "completed"
+	ScanFailed    ScanState = "failed"
+	// ScanAbortedTimeout is the status of a scan that ran longer than its
+	// MaxDuration, or had a phase time out with AbortOnPhaseTimeout set.
+	ScanAbortedTimeout ScanState = "aborted_timeout"
+	ScanCancelled      ScanState = "cancelled"
+	// SessionPending is the status of a session that has not started yet.
+	// Scans are queued instead and never report it.
+	SessionPending ScanState = "pending"
+)
+
+// String returns the status as sent by the server.
+func (s ScanState) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is one of the scan or session statuses.
+func (s ScanState) Valid() bool {
+	switch s {
+	case ScanQueued, ScanRunning, ScanCompleted, ScanFailed, ScanAbortedTimeout, ScanCancelled, SessionPending:
+		return true
+	}
+	return false
+}
+
+// Finished reports whether a scan with status s has stopped for good.
+func (s ScanState) Finished() bool {
+	switch s {
+	case ScanCompleted, ScanFailed, ScanAbortedTimeout, ScanCancelled:
+		return true
+	}
+	return false
+}
+
+// ParseScanState returns the status named s, ignoring case, or an error
+// wrapping ErrInvalidValue.
+func ParseScanState(s string) (ScanState, error) {
+	state := ScanState(strings.ToLower(s))
+	if !state.Valid() {
+		return "", fmt.Errorf("%w: unknown scan status %q", ErrInvalidValue, s)
+	}
+	return state, nil
+}
@@ -0,0 +1,79 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseEnums(t *testing.T) {
+	if sev, err := ParseSeverity("High"); err != nil || sev != SeverityHigh || sev.String() != "high" {
+		t.Errorf("Expected SeverityHigh, got %q (%v)", sev, err)
+	}
+	if _, err := ParseSeverity("hgih"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	if phase, err := ParsePhase("EXPLOIT"); err != nil || phase != PhaseExploit {
+		t.Errorf("Expected PhaseExploit, got %q (%v)", phase, err)
+	}
+	if _, err := ParsePhase("post_exploit"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue for a phase the server does not run, got %v", err)
+	}
+	if state, err := ParseScanState("pending"); err != nil || state != SessionPending || state.Finished() {
+		t.Errorf("Expected unfinished SessionPending, got %q (%v)", state, err)
+	}
+	if mode, err := ParseScanMode("full"); err != nil || mode != ModeFull {
+		t.Errorf("Expected ModeFull, got %q (%v)", mode, err)
+	}
+	if _, err := ParseScanMode("aggressive"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	if state, err := ParseScanState("Running"); err != nil || state != ScanRunning || state.Finished() {
+		t.Errorf("Expected unfinished ScanRunning, got %q (%v)", state, err)
+	}
+	if !ScanAbortedTimeout.Finished() || ScanState("paused").Valid() {
+		t.Errorf("Unexpected scan state validation")
+	}
+}
+
+func TestInvalidValuesNotSent(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"id": 1, "severity": "catastrophic", "phase": "cleanup"}]`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	filter := &FindingsFilter{Severities: []Severity{SeverityHigh, "critcal"}}
+	if _, err := client.ListFindings(filter); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	it := client.IterateFindings(context.Background(), &FindingsFilter{MinSeverity: "hi"})
+	if it.Next() || !errors.Is(it.Err(), ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", it.Err())
+	}
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com", Mode: "ful"}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com", Phases: []Phase{PhaseRecon, "exploitation"}}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	if _, err := client.CreateSchedule(1, &ScheduleCreate{Name: "nightly", Cron: "0 2 * * *", Mode: "nightly"}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+
+	// Values the SDK does not know are still decoded from responses.
+	findings, err := client.ListFindings(&FindingsFilter{Severity: SeverityCritical})
+	if err != nil || requests != 1 {
+		t.Fatalf("Expected valid filter to be sent, got %v", err)
+	}
+	if len(findings) != 1 || findings[0].Severity != "catastrophic" || findings[0].Phase.Valid() {
+		t.Errorf("Expected unknown values to be kept, got %+v", findings)
+	}
+}
@@ -0,0 +1,202 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Errors
+// =============================================================================
+
+// Errors returned for API error responses match these with errors.Is,
+// according to their status code:
+//
+//	if errors.Is(err, aiptx.ErrNotFound) {
+//	    ...
+//	}
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+// APIError represents an API error response. Rate limiting and validation
+// failures are returned as the more specific *RateLimitError and
+// *ValidationError, which wrap an *APIError.
+type APIError struct {
+	StatusCode int
+	// Message is the detail reported by the server, or the response body
+	// if it has none.
+	Message string
+	// Response is the decoded JSON response body, if it is JSON.
+	Response interface{}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("AIPTX API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether target is the sentinel error for e's status code.
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return target == ErrBadRequest
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	case http.StatusUnprocessableEntity:
+		return target == ErrValidation
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+	return e.StatusCode >= 500 && target == ErrServer
+}
+
+// RateLimitError is returned when the server rejects a request with 429 Too
+// Many Requests.
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is how long the server asked the client to wait, or zero if
+	// it did not say.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
+// ValidationError is returned when the server rejects a request body or
+// parameters as invalid. It matches ErrValidation whether the server
+// answered 400 Bad Request or 422 Unprocessable Entity, and ErrBadRequest
+// for the former.
+type ValidationError struct {
+	*APIError
+	Fields []FieldError
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.APIError
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// FieldError is a problem with one field of a request.
+type FieldError struct {
+	// Field is the dotted path of the field, such as "scope.0".
+	Field   string
+	Message string
+	// Type is the server's machine-readable error type, such as
+	// "missing" or "value_error".
+	Type string
+}
+
+// TransportError is returned when a request fails before a complete response
+// is received, such as when the connection is refused or times out. It wraps
+// the underlying error, so errors.Is(err, context.DeadlineExceeded) and
+// similar checks still work.
+type TransportError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("AIPTX transport error (%s %s): %v", e.Method, e.URL, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError converts an error response into the most specific error type.
+func newAPIError(resp *http.Response, body []byte) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: string(body)}
+
+	var detail struct {
+		Detail json.RawMessage `json:"detail"`
+	}
+	if json.Unmarshal(body, &apiErr.Response) == nil && json.Unmarshal(body, &detail) == nil {
+		var message string
+		if json.Unmarshal(detail.Detail, &message) == nil {
+			apiErr.Message = message
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitError{APIError: apiErr, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	case resp.StatusCode == http.StatusUnprocessableEntity || resp.StatusCode == http.StatusBadRequest:
+		if fields := parseFieldErrors(detail.Detail); fields != nil {
+			return &ValidationError{APIError: apiErr, Fields: fields}
+		}
+	}
+	return apiErr
+}
+
+// parseFieldErrors parses a list of field errors in the form
+// [{"loc": ["body", "name"], "msg": "...", "type": "..."}].
+func parseFieldErrors(detail json.RawMessage) []FieldError {
+	var items []struct {
+		Loc  []interface{} `json:"loc"`
+		Msg  string        `json:"msg"`
+		Type string        `json:"type"`
+	}
+	if json.Unmarshal(detail, &items) != nil || len(items) == 0 {
+		return nil
+	}
+	fields := make([]FieldError, len(items))
+	for i, item := range items {
+		loc := item.Loc
+		if len(loc) > 1 {
+			switch loc[0] {
+			case "body", "query", "path", "header":
+				loc = loc[1:]
+			}
+		}
+		parts := make([]string, len(loc))
+		for j, p := range loc {
+			parts[j] = fmt.Sprint(p)
+		}
+		fields[i] = FieldError{Field: strings.Join(parts, "."), Message: item.Msg, Type: item.Type}
+	}
+	return fields
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date, returning zero if it is missing or invalid.
+func parseRetryAfter(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(s); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(s); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
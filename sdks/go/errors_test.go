@@ -0,0 +1,106 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestErrorTaxonomy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail": "Project not found"}`))
+		case "/projects":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"detail": [{"loc": ["body", "scope", 0], "msg": "invalid host", "type": "value_error"}]}`))
+		case "/webhooks":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"detail": [{"loc": ["body", "url"], "msg": "invalid url", "type": "value_error"}]}`))
+		case "/tools":
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("boom"))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}))
+
+	_, err := client.GetProject(1)
+	var apiErr *APIError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &apiErr) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	if apiErr.Message != "Project not found" {
+		t.Errorf("Expected server detail as message, got %q", apiErr.Message)
+	}
+
+	_, err = client.CreateProject(&ProjectCreate{Name: "x"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != "scope.0" || validationErr.Fields[0].Type != "value_error" {
+		t.Errorf("Expected field error for scope.0, got %+v", validationErr.Fields)
+	}
+
+	_, err = client.CreateWebhook(&WebhookCreate{URL: "x"})
+	if !errors.As(err, &validationErr) || !errors.Is(err, ErrValidation) || !errors.Is(err, ErrBadRequest) {
+		t.Errorf("Expected 400 *ValidationError to match ErrValidation and ErrBadRequest, got %v", err)
+	}
+
+	_, err = client.ListTools()
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected *RateLimitError, got %v", err)
+	}
+	if rateErr.RetryAfter != 7*time.Second {
+		t.Errorf("Expected RetryAfter 7s, got %v", rateErr.RetryAfter)
+	}
+
+	_, err = client.Health()
+	if !errors.Is(err, ErrServer) || errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrServer, got %v", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.Message != "boom" {
+		t.Errorf("Expected raw body as message, got %v", err)
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.Health()
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) || transportErr.Method != "GET" {
+		t.Fatalf("Expected *TransportError, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.HealthContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("120"); d != 2*time.Minute {
+		t.Errorf("Expected 2m, got %v", d)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(date); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("Expected about 1h, got %v", d)
+	}
+	if d := parseRetryAfter("soon"); d != 0 {
+		t.Errorf("Expected 0 for invalid header, got %v", d)
+	}
+}
@@ -0,0 +1,368 @@
+package aiptx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// =============================================================================
+// Events
+// =============================================================================
+
+// Event types emitted by the server.
+const (
+	EventFindingCreated   = "finding.created"
+	EventFindingUpdated   = "finding.updated"
+	EventScanStarted      = "scan.started"
+	EventScanPhaseChanged = "scan.phase_changed"
+	EventScanProgress     = "scan.progress"
+	EventScanCompleted    = "scan.completed"
+	EventScanFailed       = "scan.failed"
+	EventSessionCompleted = "session.completed"
+
+	// EventReconnected is generated by the SDK, not the server, when a
+	// stream recovers from a dropped or stalled connection. Its data is a
+	// Reconnected value.
+	EventReconnected = "stream.reconnected"
+)
+
+// Reconnected is the payload of an EventReconnected event.
+type Reconnected struct {
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
+}
+
+// Event is a server event such as a new finding or a scan phase change.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	ProjectID int64     `json:"project_id,omitempty"`
+	Time      time.Time `json:"time"`
+	// Version is the schema version of Data. Servers predating payload
+	// versioning omit it; see ParseEvent.
+	Version int             `json:"version,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+
+	unknown *rawFields
+}
+
+// Decode unmarshals the event payload into v.
+func (e Event) Decode(v interface{}) error {
+	if len(e.Data) == 0 {
+		return fmt.Errorf("event %s has no data", e.ID)
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+// eventBatch is the result of a single long-poll request.
+type eventBatch struct {
+	Events []Event `json:"events"`
+}
+
+// DefaultPollWait is how long the server holds a long-poll request open when
+// no events are available. It must be shorter than the HTTP client timeout.
+const DefaultPollWait = 25 * time.Second
+
+// pollEvents performs one long-poll request for events after cursor.
+func (c *Client) pollEvents(ctx context.Context, cursor string, wait time.Duration, filter url.Values) ([]Event, error) {
+	params := url.Values{}
+	for k, v := range filter {
+		params[k] = v
+	}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	params.Set("wait", fmt.Sprintf("%d", int(wait/time.Second)))
+
+	var batch eventBatch
+	if err := c.requestContext(ctx, "GET", withQuery("/events/poll", params), nil, &batch); err != nil {
+		return nil, err
+	}
+	return batch.Events, nil
+}
+
+// EventStream iterates over server events. Call Next until it returns
+// false, then check Err:
+//
+//	stream := client.PollEvents(ctx, "")
+//	for stream.Next() {
+//	    ev := stream.Event()
+//	    ...
+//	}
+//	if err := stream.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// An EventStream is not safe for concurrent use.
+type EventStream struct {
+	ctx       context.Context
+	client    *Client
+	wait      time.Duration
+	store     CursorStore
+	keepalive Keepalive
+	filter    url.Values
+
+	cursor    string
+	saved     string
+	lastErr   string
+	reconnect reconnector
+	pending   []Event
+	current   Event
+	err       error
+}
+
+// Keepalive configures liveness checking and reconnection for streams. Long
+// scans routinely outlive NAT and proxy idle timeouts, so streams detect
+// silent connections and reconnect transparently, resuming from the last
+// event and emitting an EventReconnected event.
+type Keepalive struct {
+	// PingInterval is the longest the server may stay silent. Long-poll
+	// requests are answered at least this often, with an empty batch if
+	// there are no events.
+	PingInterval time.Duration
+
+	// StallTimeout is how long past PingInterval the stream waits for data
+	// before it drops the connection and reconnects.
+	StallTimeout time.Duration
+
+	// ReconnectDelay is the delay before the first reconnection attempt. It
+	// doubles after each failed attempt up to MaxReconnectDelay.
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+
+	// MaxReconnects is the number of consecutive failed attempts after which
+	// the stream gives up. Zero means never give up.
+	MaxReconnects int
+}
+
+// DefaultKeepalive is the keepalive configuration used by event streams.
+var DefaultKeepalive = Keepalive{
+	PingInterval:      DefaultPollWait,
+	StallTimeout:      15 * time.Second,
+	ReconnectDelay:    time.Second,
+	MaxReconnectDelay: 30 * time.Second,
+	MaxReconnects:     10,
+}
+
+// EventOption configures an event stream created by Events.
+type EventOption func(*EventStream)
+
+// WithCursor starts the stream after the event with the given ID. It is
+// overridden by a cursor loaded from a CursorStore.
+func WithCursor(cursor string) EventOption {
+	return func(s *EventStream) {
+		s.cursor = cursor
+	}
+}
+
+// WithCursorStore persists the stream position in store. The stream resumes
+// from the stored cursor and records each event once the consumer has
+// processed it, which is signalled by the following call to Next. Events are
+// therefore delivered at least once: an event being processed when the
+// process dies is delivered again after a restart.
+func WithCursorStore(store CursorStore) EventOption {
+	return func(s *EventStream) {
+		s.store = store
+	}
+}
+
+// WithPollWait sets how long the server holds each long-poll request open.
+func WithPollWait(wait time.Duration) EventOption {
+	return func(s *EventStream) {
+		s.wait = wait
+	}
+}
+
+// WithKeepalive replaces DefaultKeepalive for the stream.
+func WithKeepalive(k Keepalive) EventOption {
+	return func(s *EventStream) {
+		s.keepalive = k
+	}
+}
+
+// WithEventTypes asks the server to send only events of the given types.
+func WithEventTypes(types ...string) EventOption {
+	return func(s *EventStream) {
+		for _, typ := range types {
+			s.addFilter("event_type", typ)
+		}
+	}
+}
+
+// WithEventFilter asks the server to send only events about findings that
+// match filter, and events that are not about findings. Its ListOptions are
+// ignored, and a nil filter matches every event.
+func WithEventFilter(filter *FindingsFilter) EventOption {
+	return func(s *EventStream) {
+		if filter == nil {
+			return
+		}
+		f := *filter
+		f.ListOptions = ListOptions{}
+		for k, v := range f.values() {
+			for _, value := range v {
+				s.addFilter(k, value)
+			}
+		}
+	}
+}
+
+func (s *EventStream) addFilter(key, value string) {
+	if s.filter == nil {
+		s.filter = url.Values{}
+	}
+	s.filter.Add(key, value)
+}
+
+// Events returns a stream of server events configured by opts.
+func (c *Client) Events(ctx context.Context, opts ...EventOption) *EventStream {
+	s := &EventStream{ctx: ctx, client: c, wait: DefaultPollWait, keepalive: DefaultKeepalive}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.keepalive.PingInterval > 0 && s.keepalive.PingInterval < s.wait {
+		s.wait = s.keepalive.PingInterval
+	}
+	s.reconnect = newReconnector(s.keepalive)
+	if s.store != nil {
+		cursor, err := s.store.Load(ctx)
+		if err != nil {
+			s.err = fmt.Errorf("loading event cursor: %w", err)
+		} else if cursor != "" {
+			s.cursor = cursor
+		}
+		s.saved = s.cursor
+	}
+	return s
+}
+
+// PollEvents returns a stream of the events following the event with ID
+// cursor, which may be empty to start from the oldest retained event. Events
+// are fetched by long-polling, which works through proxies that block
+// WebSockets and streaming responses. The stream advances the cursor
+// automatically and ends when ctx is cancelled or a request fails.
+func (c *Client) PollEvents(ctx context.Context, cursor string) *EventStream {
+	return c.Events(ctx, WithCursor(cursor))
+}
+
+// Next blocks until the next event is available and reports whether one was
+// read.
+func (s *EventStream) Next() bool {
+	if s.err == nil && s.store != nil && s.cursor != s.saved {
+		if err := s.store.Save(s.ctx, s.cursor); err != nil {
+			s.err = fmt.Errorf("saving event cursor: %w", err)
+			return false
+		}
+		s.saved = s.cursor
+	}
+
+	for len(s.pending) == 0 {
+		if s.err != nil {
+			return false
+		}
+		if err := s.ctx.Err(); err != nil {
+			s.err = err
+			return false
+		}
+
+		events, err := s.poll()
+		if err != nil {
+			s.err = err
+			return false
+		}
+		s.pending = events
+	}
+
+	s.current = s.pending[0]
+	s.pending = s.pending[1:]
+	if s.current.Type != EventReconnected {
+		s.cursor = s.current.ID
+	}
+	return true
+}
+
+// poll fetches the next batch of events, reconnecting after stalls and
+// transient failures as configured by the stream's Keepalive.
+func (s *EventStream) poll() ([]Event, error) {
+	for {
+		ctx, cancel := s.ctx, context.CancelFunc(func() {})
+		if s.keepalive.StallTimeout > 0 {
+			ctx, cancel = context.WithTimeout(s.ctx, s.wait+s.keepalive.StallTimeout)
+		}
+		events, err := s.client.pollEvents(ctx, s.cursor, s.wait, s.filter)
+		cancel()
+
+		if err == nil {
+			if failures := s.reconnect.failures; failures > 0 {
+				data, _ := json.Marshal(Reconnected{Attempts: failures, LastError: s.lastErr})
+				reconnected := Event{Type: EventReconnected, Time: time.Now(), Data: data}
+				events = append([]Event{reconnected}, events...)
+			}
+			s.reconnect.reset()
+			return events, nil
+		}
+		s.lastErr = err.Error()
+		if err := s.reconnect.wait(s.ctx, err, 0); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// reconnector paces the reconnection attempts of a stream as configured by
+// its Keepalive.
+type reconnector struct {
+	keepalive Keepalive
+	delay     time.Duration
+	// failures counts the attempts since the last successful connection.
+	failures int
+}
+
+func newReconnector(k Keepalive) reconnector {
+	return reconnector{keepalive: k, delay: k.ReconnectDelay}
+}
+
+// reset records a successful connection.
+func (r *reconnector) reset() {
+	r.delay, r.failures = r.keepalive.ReconnectDelay, 0
+}
+
+// wait sleeps before reconnecting after err, for the current backoff delay
+// but at least floor. It returns the error that ends the stream instead if
+// ctx is done, err is not transient or the attempts are exhausted.
+func (r *reconnector) wait(ctx context.Context, err error, floor time.Duration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	k := r.keepalive
+	if !isTransient(err) || (k.MaxReconnects > 0 && r.failures >= k.MaxReconnects) {
+		return err
+	}
+	r.failures++
+	if err := sleepContext(ctx, max(r.delay, floor)); err != nil {
+		return err
+	}
+	if r.delay *= 2; k.MaxReconnectDelay > 0 && r.delay > k.MaxReconnectDelay {
+		r.delay = k.MaxReconnectDelay
+	}
+	return nil
+}
+
+// Event returns the event read by the last call to Next.
+func (s *EventStream) Event() Event {
+	return s.current
+}
+
+// Err returns the error that ended the stream, if any.
+func (s *EventStream) Err() error {
+	return s.err
+}
+
+// Cursor returns the ID of the last event read by Next, or the starting
+// cursor if none has been read. Passing it to PollEvents resumes the stream
+// after that event.
+func (s *EventStream) Cursor() string {
+	return s.cursor
+}
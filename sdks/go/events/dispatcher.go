@@ -0,0 +1,162 @@
+// Package events routes AIPTX server events to typed handlers.
+//
+// Register handlers per event type, then feed the dispatcher events from a
+// stream or webhook deliveries:
+//
+//	d := events.NewDispatcher()
+//	d.OnFindingCreated(func(f aiptx.Finding) {
+//	    log.Printf("new %s finding: %s", f.Severity, f.Value)
+//	})
+//	d.OnScanCompleted(func(s aiptx.ScanStatus) {
+//	    log.Printf("scan %s completed", s.ID)
+//	})
+//	err := d.Run(client.Events(ctx))
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Handler handles a raw event.
+type Handler func(aiptx.Event) error
+
+// Dispatcher decodes events and calls the handlers registered for their
+// type. Handlers for the same type run in registration order. A Dispatcher is
+// safe for concurrent use, but handlers are called synchronously from
+// Dispatch.
+type Dispatcher struct {
+	mu        sync.RWMutex
+	handlers  map[string][]Handler
+	unhandled Handler
+}
+
+// NewDispatcher creates a dispatcher with no handlers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[string][]Handler)}
+}
+
+// On registers h for events of the given type.
+func (d *Dispatcher) On(eventType string, h Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = append(d.handlers[eventType], h)
+}
+
+// OnUnhandled registers h for events with no type-specific handler.
+func (d *Dispatcher) OnUnhandled(h Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unhandled = h
+}
+
+// OnFindingCreated registers h for new findings.
+func (d *Dispatcher) OnFindingCreated(h func(aiptx.Finding)) {
+	d.On(aiptx.EventFindingCreated, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.FindingCreatedEvent).Finding)
+	}))
+}
+
+// OnFindingUpdated registers h for updated findings.
+func (d *Dispatcher) OnFindingUpdated(h func(aiptx.Finding)) {
+	d.On(aiptx.EventFindingUpdated, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.FindingUpdatedEvent).Finding)
+	}))
+}
+
+// OnScanStarted registers h for scans that have started.
+func (d *Dispatcher) OnScanStarted(h func(aiptx.ScanStatus)) {
+	d.On(aiptx.EventScanStarted, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.ScanStartedEvent).Scan)
+	}))
+}
+
+// OnScanPhaseChanged registers h for scans entering a new phase.
+func (d *Dispatcher) OnScanPhaseChanged(h func(aiptx.ScanStatus)) {
+	d.On(aiptx.EventScanPhaseChanged, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.ScanPhaseChangedEvent).Scan)
+	}))
+}
+
+// OnScanCompleted registers h for scans that completed successfully.
+func (d *Dispatcher) OnScanCompleted(h func(aiptx.ScanStatus)) {
+	d.On(aiptx.EventScanCompleted, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.ScanCompletedEvent).Scan)
+	}))
+}
+
+// OnScanFailed registers h for scans that ended with an error.
+func (d *Dispatcher) OnScanFailed(h func(aiptx.ScanStatus)) {
+	d.On(aiptx.EventScanFailed, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.ScanFailedEvent).Scan)
+	}))
+}
+
+// OnSessionCompleted registers h for completed sessions.
+func (d *Dispatcher) OnSessionCompleted(h func(aiptx.Session)) {
+	d.On(aiptx.EventSessionCompleted, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.SessionCompletedEvent).Session)
+	}))
+}
+
+// OnReconnected registers h for stream reconnections generated by the SDK.
+func (d *Dispatcher) OnReconnected(h func(aiptx.Reconnected)) {
+	d.On(aiptx.EventReconnected, typedHandler(func(ev aiptx.TypedEvent) {
+		h(ev.(*aiptx.ReconnectedEvent).Reconnected)
+	}))
+}
+
+// typedHandler decodes events with aiptx.ParseEvent before calling h, which
+// receives the typed event for the type it was registered for.
+func typedHandler(h func(aiptx.TypedEvent)) Handler {
+	return func(ev aiptx.Event) error {
+		typed, err := aiptx.ParseEvent(ev)
+		if err != nil {
+			return err
+		}
+		h(typed)
+		return nil
+	}
+}
+
+// Dispatch calls the handlers registered for ev's type. It stops at the first
+// handler error and returns it annotated with the event.
+func (d *Dispatcher) Dispatch(ev aiptx.Event) error {
+	d.mu.RLock()
+	handlers := d.handlers[ev.Type]
+	if len(handlers) == 0 && d.unhandled != nil {
+		handlers = []Handler{d.unhandled}
+	}
+	d.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ev); err != nil {
+			return fmt.Errorf("handling %s event %s: %w", ev.Type, ev.ID, err)
+		}
+	}
+	return nil
+}
+
+// DispatchJSON decodes a JSON-encoded event, such as a webhook request body,
+// and dispatches it.
+func (d *Dispatcher) DispatchJSON(data []byte) error {
+	var ev aiptx.Event
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return fmt.Errorf("decoding event: %w", err)
+	}
+	return d.Dispatch(ev)
+}
+
+// Run dispatches every event from stream until the stream ends or a handler
+// fails, and returns the error that stopped it.
+func (d *Dispatcher) Run(stream *aiptx.EventStream) error {
+	for stream.Next() {
+		if err := d.Dispatch(stream.Event()); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
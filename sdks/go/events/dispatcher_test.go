@@ -0,0 +1,55 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestDispatcher(t *testing.T) {
+	d := NewDispatcher()
+
+	var findings []aiptx.Finding
+	d.OnFindingCreated(func(f aiptx.Finding) { findings = append(findings, f) })
+
+	var completed string
+	d.OnScanCompleted(func(s aiptx.ScanStatus) { completed = s.ID })
+
+	var unhandled []string
+	d.OnUnhandled(func(ev aiptx.Event) error {
+		unhandled = append(unhandled, ev.Type)
+		return nil
+	})
+
+	for _, body := range []string{
+		`{"id": "1", "type": "finding.created", "data": {"id": 7, "severity": "high"}}`,
+		`{"id": "2", "type": "scan.completed", "data": {"id": "scan-1", "status": "completed"}}`,
+		`{"id": "3", "type": "scan.paused"}`,
+	} {
+		if err := d.DispatchJSON([]byte(body)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(findings) != 1 || findings[0].ID != 7 {
+		t.Errorf("Expected finding 7, got %+v", findings)
+	}
+	if completed != "scan-1" {
+		t.Errorf("Expected scan-1 completion, got %q", completed)
+	}
+	if len(unhandled) != 1 || unhandled[0] != "scan.paused" {
+		t.Errorf("Expected unhandled scan.paused, got %v", unhandled)
+	}
+}
+
+func TestDispatcherHandlerError(t *testing.T) {
+	d := NewDispatcher()
+	boom := errors.New("boom")
+	d.On(aiptx.EventScanFailed, func(aiptx.Event) error { return boom })
+
+	err := d.Dispatch(aiptx.Event{ID: "9", Type: aiptx.EventScanFailed})
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected handler error, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package aiptx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPollEvents(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		switch cursor {
+		case "":
+			w.Write([]byte(`{"events": [{"id": "1", "type": "scan.started"}, {"id": "2", "type": "finding.created", "data": {"id": 7}}]}`))
+		case "2":
+			w.Write([]byte(`{"events": [{"id": "3", "type": "scan.completed"}]}`))
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	stream := client.PollEvents(context.Background(), "")
+
+	var ids []string
+	for stream.Next() {
+		ev := stream.Event()
+		ids = append(ids, ev.ID)
+		if ev.Type == EventFindingCreated {
+			var f Finding
+			if err := ev.Decode(&f); err != nil || f.ID != 7 {
+				t.Errorf("Expected finding 7, got %+v (%v)", f, err)
+			}
+		}
+	}
+
+	if len(ids) != 3 || ids[2] != "3" {
+		t.Errorf("Expected events 1-3, got %v", ids)
+	}
+	if len(cursors) != 3 || cursors[1] != "2" {
+		t.Errorf("Expected cursor to advance, got %v", cursors)
+	}
+	if stream.Err() == nil {
+		t.Errorf("Expected error from failed poll")
+	}
+	if stream.Cursor() != "3" {
+		t.Errorf("Expected cursor 3, got %s", stream.Cursor())
+	}
+}
+
+func TestEventsReconnect(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case calls == 1:
+			w.Write([]byte(`{"events": [{"id": "1"}]}`))
+		case calls <= 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Query().Get("cursor") == "1":
+			w.Write([]byte(`{"events": [{"id": "2"}]}`))
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}))
+	stream := client.Events(context.Background(), WithKeepalive(Keepalive{ReconnectDelay: time.Millisecond}))
+
+	var types []string
+	for stream.Next() {
+		types = append(types, stream.Event().Type)
+		if stream.Event().Type == EventReconnected {
+			var info Reconnected
+			stream.Event().Decode(&info)
+			if info.Attempts != 2 {
+				t.Errorf("Expected 2 attempts, got %d", info.Attempts)
+			}
+		}
+	}
+
+	if len(types) != 3 || types[1] != EventReconnected {
+		t.Errorf("Expected reconnection between events, got %v", types)
+	}
+	if stream.Cursor() != "2" {
+		t.Errorf("Expected cursor 2, got %s", stream.Cursor())
+	}
+}
+
+func TestEventsStall(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"events": [{"id": "1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	stream := client.Events(context.Background(), WithPollWait(0), WithKeepalive(Keepalive{
+		StallTimeout:   50 * time.Millisecond,
+		ReconnectDelay: time.Millisecond,
+	}))
+
+	if !stream.Next() || stream.Event().Type != EventReconnected {
+		t.Fatalf("Expected reconnection after stall, got %+v (%v)", stream.Event(), stream.Err())
+	}
+	if !stream.Next() || stream.Event().ID != "1" {
+		t.Errorf("Expected event 1, got %+v", stream.Event())
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	s := NewClient("http://localhost").Events(context.Background(),
+		WithEventFilter(nil),
+		WithEventFilter(&FindingsFilter{MinSeverity: SeverityHigh, ListOptions: ListOptions{Limit: 10}}))
+
+	if s.filter.Get("min_severity") != "high" || s.filter.Has("limit") {
+		t.Errorf("Expected only the finding filter, got %v", s.filter)
+	}
+}
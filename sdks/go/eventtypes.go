@@ -0,0 +1,165 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Typed Events
+// =============================================================================
+
+// EventSchemaVersion is the newest event payload schema the SDK knows.
+// Payloads of a newer version are still decoded, keeping the fields the SDK
+// knows, so receivers can check Version before relying on them.
+const EventSchemaVersion = 1
+
+// TypedEvent is an event with a decoded payload, as returned by ParseEvent
+// and ParseWebhookEvent. Its concrete type is one of the *...Event types in
+// this file; use a type switch to handle it:
+//
+//	switch ev := ev.(type) {
+//	case *aiptx.FindingCreatedEvent:
+//	    triage(ev.Finding)
+//	case *aiptx.ScanCompletedEvent:
+//	    report(ev.Scan)
+//	}
+type TypedEvent interface {
+	Header() EventHeader
+}
+
+// EventHeader holds the fields common to every event.
+type EventHeader struct {
+	ID        string
+	Type      string
+	Version   int
+	ProjectID int64
+	Time      time.Time
+}
+
+// Header returns h.
+func (h EventHeader) Header() EventHeader {
+	return h
+}
+
+// FindingCreatedEvent reports a new finding.
+type FindingCreatedEvent struct {
+	EventHeader
+	Finding Finding
+}
+
+// FindingUpdatedEvent reports a change to a finding.
+type FindingUpdatedEvent struct {
+	EventHeader
+	Finding Finding
+}
+
+// ScanStartedEvent reports a scan that has started.
+type ScanStartedEvent struct {
+	EventHeader
+	Scan ScanStatus
+}
+
+// ScanPhaseChangedEvent reports a scan entering Scan.Phase.
+type ScanPhaseChangedEvent struct {
+	EventHeader
+	Scan ScanStatus
+}
+
+// ScanProgressEvent reports the progress of a running scan, such as
+// Scan.Progress or Scan.FindingsCount changing.
+type ScanProgressEvent struct {
+	EventHeader
+	Scan ScanStatus
+}
+
+// ScanCompletedEvent reports a scan that completed successfully.
+type ScanCompletedEvent struct {
+	EventHeader
+	Scan ScanStatus
+}
+
+// ScanFailedEvent reports a scan that ended with an error.
+type ScanFailedEvent struct {
+	EventHeader
+	Scan ScanStatus
+}
+
+// SessionCompletedEvent reports a completed session.
+type SessionCompletedEvent struct {
+	EventHeader
+	Session Session
+}
+
+// ReconnectedEvent reports that a stream recovered from a dropped
+// connection. It is generated by the SDK and never delivered by webhooks.
+type ReconnectedEvent struct {
+	EventHeader
+	Reconnected Reconnected
+}
+
+// UnknownEvent is an event of a type the SDK does not know, such as one
+// added in a newer server.
+type UnknownEvent struct {
+	EventHeader
+	Data json.RawMessage
+}
+
+// ParseWebhookEvent decodes the body of a webhook delivery into a typed
+// event. Verify the delivery before parsing it.
+func ParseWebhookEvent(body []byte) (TypedEvent, error) {
+	var ev Event
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return nil, fmt.Errorf("decoding webhook event: %w", err)
+	}
+	return ParseEvent(ev)
+}
+
+// ParseEvent decodes the payload of an event, such as one read from an
+// EventStream, into a typed event. Events without a version are treated as
+// version 1, and events of unknown types are returned as *UnknownEvent.
+func ParseEvent(ev Event) (TypedEvent, error) {
+	h := EventHeader{ID: ev.ID, Type: ev.Type, Version: ev.Version, ProjectID: ev.ProjectID, Time: ev.Time}
+	if h.Version == 0 {
+		h.Version = 1
+	}
+
+	var typed TypedEvent
+	var payload interface{}
+	switch ev.Type {
+	case EventFindingCreated:
+		e := &FindingCreatedEvent{EventHeader: h}
+		typed, payload = e, &e.Finding
+	case EventFindingUpdated:
+		e := &FindingUpdatedEvent{EventHeader: h}
+		typed, payload = e, &e.Finding
+	case EventScanStarted:
+		e := &ScanStartedEvent{EventHeader: h}
+		typed, payload = e, &e.Scan
+	case EventScanPhaseChanged:
+		e := &ScanPhaseChangedEvent{EventHeader: h}
+		typed, payload = e, &e.Scan
+	case EventScanProgress:
+		e := &ScanProgressEvent{EventHeader: h}
+		typed, payload = e, &e.Scan
+	case EventScanCompleted:
+		e := &ScanCompletedEvent{EventHeader: h}
+		typed, payload = e, &e.Scan
+	case EventScanFailed:
+		e := &ScanFailedEvent{EventHeader: h}
+		typed, payload = e, &e.Scan
+	case EventSessionCompleted:
+		e := &SessionCompletedEvent{EventHeader: h}
+		typed, payload = e, &e.Session
+	case EventReconnected:
+		e := &ReconnectedEvent{EventHeader: h}
+		typed, payload = e, &e.Reconnected
+	default:
+		return &UnknownEvent{EventHeader: h, Data: ev.Data}, nil
+	}
+	if err := ev.Decode(payload); err != nil {
+		return nil, fmt.Errorf("decoding %s event %s: %w", ev.Type, ev.ID, err)
+	}
+	return typed, nil
+}
@@ -0,0 +1,78 @@
+package aiptx
+
+import (
+	"testing"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	body := []byte(`{
+		"id": "evt-1",
+		"type": "finding.created",
+		"project_id": 7,
+		"time": "2024-03-12T09:15:00Z",
+		"version": 1,
+		"data": {"id": 42, "project_id": 7, "type": "vuln", "value": "SQL injection", "severity": "high"}
+	}`)
+	ev, err := ParseWebhookEvent(body)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent failed: %v", err)
+	}
+	created, ok := ev.(*FindingCreatedEvent)
+	if !ok {
+		t.Fatalf("Expected *FindingCreatedEvent, got %T", ev)
+	}
+	if created.ID != "evt-1" || created.ProjectID != 7 || created.Version != 1 {
+		t.Errorf("Unexpected header %+v", created.Header())
+	}
+	if created.Finding.ID != 42 || created.Finding.Severity != "high" {
+		t.Errorf("Unexpected finding %+v", created.Finding)
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		event Event
+		check func(TypedEvent) bool
+	}{
+		{Event{Type: EventScanPhaseChanged, Data: []byte(`{"id": "scan-1", "phase": "exploit"}`)}, func(ev TypedEvent) bool {
+			e, ok := ev.(*ScanPhaseChangedEvent)
+			return ok && e.Scan.Phase == "exploit"
+		}},
+		{Event{Type: EventScanFailed, Data: []byte(`{"id": "scan-1", "error": "timeout"}`)}, func(ev TypedEvent) bool {
+			e, ok := ev.(*ScanFailedEvent)
+			return ok && e.Scan.Error == "timeout"
+		}},
+		{Event{Type: EventSessionCompleted, Data: []byte(`{"id": 3, "status": "completed"}`)}, func(ev TypedEvent) bool {
+			e, ok := ev.(*SessionCompletedEvent)
+			return ok && e.Session.ID == 3
+		}},
+		{Event{Type: EventReconnected, Data: []byte(`{"attempts": 2}`)}, func(ev TypedEvent) bool {
+			e, ok := ev.(*ReconnectedEvent)
+			return ok && e.Reconnected.Attempts == 2
+		}},
+		{Event{Type: "asset.discovered", Version: 2, Data: []byte(`{"host": "a"}`)}, func(ev TypedEvent) bool {
+			e, ok := ev.(*UnknownEvent)
+			return ok && e.Version == 2 && string(e.Data) == `{"host": "a"}`
+		}},
+	}
+	for _, tt := range tests {
+		ev, err := ParseEvent(tt.event)
+		if err != nil {
+			t.Errorf("ParseEvent(%s) failed: %v", tt.event.Type, err)
+			continue
+		}
+		if !tt.check(ev) {
+			t.Errorf("Unexpected %s event %+v", tt.event.Type, ev)
+		}
+		if tt.event.Version == 0 && ev.Header().Version != 1 {
+			t.Errorf("Expected unversioned event to be version 1, got %d", ev.Header().Version)
+		}
+	}
+
+	if _, err := ParseEvent(Event{ID: "evt-2", Type: EventScanStarted}); err == nil {
+		t.Errorf("Expected error for event without data")
+	}
+	if _, err := ParseWebhookEvent([]byte(`not json`)); err == nil {
+		t.Errorf("Expected error for malformed body")
+	}
+}
@@ -0,0 +1,116 @@
+package aiptx
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// =============================================================================
+// Evidence Integrity
+// =============================================================================
+
+// ErrEvidenceIntegrity is wrapped by EvidenceVerification.Err when an
+// artifact has no recorded hash or its signature cannot be verified.
+// Content that does not match its hash wraps ErrChecksumMismatch instead.
+var ErrEvidenceIntegrity = errors.New("evidence integrity check failed")
+
+// VerifyOption configures VerifyEvidenceIntegrity.
+type VerifyOption func(*verifyConfig)
+
+type verifyConfig struct {
+	keys map[string]ed25519.PublicKey
+}
+
+// WithSigningKey trusts an Ed25519 public key for evidence signed by keyID,
+// matching Evidence.SignedBy. Once any key is given, every artifact must
+// carry a valid signature by a trusted key.
+func WithSigningKey(keyID string, key ed25519.PublicKey) VerifyOption {
+	return func(cfg *verifyConfig) {
+		if cfg.keys == nil {
+			cfg.keys = map[string]ed25519.PublicKey{}
+		}
+		cfg.keys[keyID] = key
+	}
+}
+
+// EvidenceVerification is the result of verifying one evidence artifact.
+type EvidenceVerification struct {
+	Evidence Evidence
+	// Err explains why the artifact failed verification, or is nil if it
+	// passed.
+	Err error
+}
+
+// OK reports whether the artifact passed verification.
+func (v EvidenceVerification) OK() bool {
+	return v.Err == nil
+}
+
+// VerifyEvidenceIntegrity downloads every evidence artifact of a finding and
+// checks that its content matches the recorded SHA-256 hash. When trusted
+// keys are given with WithSigningKey, the signature of each hash is checked
+// too. The returned error reports failures to list or download evidence;
+// artifacts that fail verification are reported in their result.
+func (c *Client) VerifyEvidenceIntegrity(findingID int64, opts ...VerifyOption) ([]EvidenceVerification, error) {
+	return c.VerifyEvidenceIntegrityContext(context.Background(), findingID, opts...)
+}
+
+// VerifyEvidenceIntegrityContext is like VerifyEvidenceIntegrity but uses ctx for its
+// request.
+func (c *Client) VerifyEvidenceIntegrityContext(ctx context.Context, findingID int64, opts ...VerifyOption) ([]EvidenceVerification, error) {
+	cfg := &verifyConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	evidence, err := c.ListEvidenceContext(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]EvidenceVerification, 0, len(evidence))
+	for _, e := range evidence {
+		v := EvidenceVerification{Evidence: e}
+		if e.SHA256 == "" {
+			v.Err = fmt.Errorf("%w: evidence %d has no recorded hash", ErrEvidenceIntegrity, e.ID)
+			results = append(results, v)
+			continue
+		}
+		_, err := c.DownloadEvidence(ctx, e.ID, io.Discard, WithChecksum(e.SHA256))
+		switch {
+		case errors.Is(err, ErrChecksumMismatch):
+			v.Err = fmt.Errorf("evidence %d: %w", e.ID, err)
+		case err != nil:
+			return nil, err
+		case cfg.keys != nil:
+			v.Err = verifySignature(e, cfg.keys)
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// verifySignature checks the signature of an artifact's SHA-256 digest
+// against the trusted keys.
+func verifySignature(e Evidence, keys map[string]ed25519.PublicKey) error {
+	if e.Signature == "" {
+		return fmt.Errorf("%w: evidence %d is not signed", ErrEvidenceIntegrity, e.ID)
+	}
+	key, ok := keys[e.SignedBy]
+	if !ok {
+		return fmt.Errorf("%w: evidence %d is signed by untrusted key %q", ErrEvidenceIntegrity, e.ID, e.SignedBy)
+	}
+	digest, err := hex.DecodeString(e.SHA256)
+	if err != nil {
+		return fmt.Errorf("%w: evidence %d has a malformed hash", ErrEvidenceIntegrity, e.ID)
+	}
+	sig, err := base64.StdEncoding.DecodeString(e.Signature)
+	if err != nil || !ed25519.Verify(key, digest, sig) {
+		return fmt.Errorf("%w: evidence %d has an invalid signature", ErrEvidenceIntegrity, e.ID)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package aiptx
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyEvidenceIntegrity(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("GET /login HTTP/1.1")
+	digest := sha256.Sum256(content)
+	sum := hex.EncodeToString(digest[:])
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, digest[:]))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/findings/7/evidence":
+			fmt.Fprintf(w, `[
+				{"id": 1, "sha256": %q, "collected_by": "nuclei", "collected_at": "2024-03-12T09:15:00Z", "signed_by": "k1", "signature": %q},
+				{"id": 2, "sha256": %q, "signed_by": "k2", "signature": %q},
+				{"id": 3, "sha256": %q},
+				{"id": 4}
+			]`, sum, sig, sum, sig, sha256Hex("tampered"))
+		case "/evidence/1/download", "/evidence/2/download", "/evidence/3/download":
+			w.Write(content)
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	results, err := client.VerifyEvidenceIntegrity(7)
+	if err != nil {
+		t.Fatalf("VerifyEvidenceIntegrity failed: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+	if !results[0].OK() || !results[1].OK() {
+		t.Errorf("Expected matching hashes to pass without keys, got %v, %v", results[0].Err, results[1].Err)
+	}
+	if results[0].Evidence.CollectedBy != "nuclei" {
+		t.Errorf("Expected collector 'nuclei', got '%s'", results[0].Evidence.CollectedBy)
+	}
+	if !errors.Is(results[2].Err, ErrChecksumMismatch) {
+		t.Errorf("Expected checksum mismatch, got %v", results[2].Err)
+	}
+	if !errors.Is(results[3].Err, ErrEvidenceIntegrity) {
+		t.Errorf("Expected missing hash to fail, got %v", results[3].Err)
+	}
+
+	results, err = client.VerifyEvidenceIntegrity(7, WithSigningKey("k1", pub))
+	if err != nil {
+		t.Fatalf("VerifyEvidenceIntegrity failed: %v", err)
+	}
+	if !results[0].OK() {
+		t.Errorf("Expected valid signature to pass, got %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrEvidenceIntegrity) {
+		t.Errorf("Expected untrusted key to fail, got %v", results[1].Err)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	digest := sha256.Sum256([]byte("evidence"))
+	keys := map[string]ed25519.PublicKey{"k1": pub}
+	e := Evidence{ID: 1, SHA256: hex.EncodeToString(digest[:]), SignedBy: "k1"}
+
+	if err := verifySignature(e, keys); !errors.Is(err, ErrEvidenceIntegrity) {
+		t.Errorf("Expected unsigned evidence to fail, got %v", err)
+	}
+	other := sha256.Sum256([]byte("other"))
+	e.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, other[:]))
+	if err := verifySignature(e, keys); !errors.Is(err, ErrEvidenceIntegrity) {
+		t.Errorf("Expected signature of another digest to fail, got %v", err)
+	}
+	e.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, digest[:]))
+	if err := verifySignature(e, keys); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
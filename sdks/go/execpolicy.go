@@ -0,0 +1,381 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Execution Policies
+// =============================================================================
+
+// ExecutionPolicy controls which shell commands the autonomous agent may
+// run. Commands are matched against regular expressions in the syntax
+// shared by Go and Python, such as `^rm\b`.
+type ExecutionPolicy struct {
+	// AllowCommands, if not empty, are the only commands the agent may run:
+	// a command must match one of them.
+	AllowCommands []string `json:"allow_commands,omitempty"`
+	// DenyCommands are commands the agent must never run, even if allowed.
+	DenyCommands []string `json:"deny_commands,omitempty"`
+	// DenyFlags are the flags the agent must not pass to each tool, such as
+	// {"sqlmap": {"--os-shell"}}.
+	DenyFlags map[string][]string `json:"deny_flags,omitempty"`
+	// EnforceScope blocks commands that would send traffic to hosts
+	// outside the project scope.
+	EnforceScope bool `json:"enforce_scope"`
+
+	unknown *rawFields
+}
+
+// Allows reports whether the policy lets the agent run command, checking
+// the command and flag lists. Scope is only enforced by the server. Invalid
+// expressions match nothing; UpdateExecutionPolicy rejects them.
+//
+// The check is conservative. The command is split into the simple commands
+// it runs, at ;, &, &&, |, || and newlines and around subshells and command
+// substitutions, and every one of them must pass: AllowCommands must match
+// each as written, while DenyCommands match the whole command, each simple
+// command and each without wrappers such as sudo or env and with the
+// program's directory removed, so "ls; sudo /bin/rm -rf /" matches
+// `^rm\b`. Scripts run by sh -c, eval and xargs are checked the same way.
+// DenyFlags apply wherever the tool appears in a simple command, whether
+// given by path or behind a wrapper, and short flags also match with their
+// value attached, as in "-pVALUE".
+func (p *ExecutionPolicy) Allows(command string) bool {
+	return p.allows(command, 0)
+}
+
+// maxScriptDepth bounds the nesting of scripts Allows checks. Deeper
+// commands are not allowed.
+const maxScriptDepth = 8
+
+func (p *ExecutionPolicy) allows(command string, depth int) bool {
+	if depth > maxScriptDepth {
+		return false
+	}
+	command = strings.TrimSpace(command)
+	if matchAny(p.DenyCommands, command) {
+		return false
+	}
+	for _, simple := range shellCommands(command) {
+		if !p.allowsSimple(simple, depth) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsSimple checks a simple command, one without control operators.
+func (p *ExecutionPolicy) allowsSimple(command string, depth int) bool {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return true
+	}
+	if len(p.AllowCommands) > 0 && !matchAny(p.AllowCommands, command) {
+		return false
+	}
+	args := shellWords(command)
+	unwrapped := unwrapCommand(args)
+	if matchAny(p.DenyCommands, command) || matchAny(p.DenyCommands, strings.Join(unwrapped, " ")) {
+		return false
+	}
+	for i, arg := range args {
+		flags := p.DenyFlags[filepath.Base(arg)]
+		for _, arg := range args[i+1:] {
+			for _, flag := range flags {
+				if deniedFlag(arg, flag) {
+					return false
+				}
+			}
+		}
+	}
+	if script, ok := innerScript(unwrapped); ok {
+		return p.allows(script, depth+1)
+	}
+	return true
+}
+
+// shellCommands splits command into its simple commands at control
+// operators and newlines outside quotes, and around subshells and command
+// substitutions, including those inside double quotes.
+func shellCommands(command string) []string {
+	var commands []string
+	var cur strings.Builder
+	flush := func() {
+		commands = append(commands, cur.String())
+		cur.Reset()
+	}
+	var quote byte
+	substitutions := 0 // open $( inside double quotes
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			}
+			cur.WriteByte(c)
+		case c == '\\' && i+1 < len(command):
+			cur.WriteByte(c)
+			cur.WriteByte(command[i+1])
+			i++
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+				cur.WriteByte(c)
+			case c == '`':
+				flush()
+			case c == '$' && i+1 < len(command) && command[i+1] == '(':
+				substitutions++
+				i++
+				flush()
+			case c == ')' && substitutions > 0:
+				substitutions--
+				flush()
+			default:
+				cur.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			cur.WriteByte(c)
+		case strings.IndexByte(";&|\n()`", c) >= 0:
+			flush()
+		case c == '$' && i+1 < len(command) && command[i+1] == '(':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return commands
+}
+
+// shellWords splits a simple command into words, removing quotes and
+// escapes.
+func shellWords(command string) []string {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote byte
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == '\'':
+			cur.WriteByte(c)
+		case c == '\\' && i+1 < len(command):
+			i++
+			cur.WriteByte(command[i])
+			inWord = true
+		case quote == '"':
+			cur.WriteByte(c)
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\r':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words
+}
+
+// wrapperCommands run the command that follows their own options.
+var wrapperCommands = map[string]bool{
+	"sudo": true, "doas": true, "env": true, "nice": true, "nohup": true,
+	"time": true, "timeout": true, "stdbuf": true, "ionice": true,
+	"exec": true, "command": true, "builtin": true,
+}
+
+// sudoValueOptions are the options of sudo that take a separate value.
+var sudoValueOptions = map[string]bool{
+	"-u": true, "-g": true, "-h": true, "-p": true, "-C": true, "-D": true,
+	"-r": true, "-t": true, "-U": true,
+}
+
+// unwrapCommand returns args without leading wrapper commands, their
+// options and environment assignments, and with the directory of the
+// program removed.
+func unwrapCommand(args []string) []string {
+	for len(args) > 0 {
+		wrapper := filepath.Base(args[0])
+		if !wrapperCommands[wrapper] {
+			break
+		}
+		args = skipWrapperOptions(wrapper, args[1:])
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return append([]string{filepath.Base(args[0])}, args[1:]...)
+}
+
+// skipWrapperOptions returns args without the leading options of wrapper.
+func skipWrapperOptions(wrapper string, args []string) []string {
+	for len(args) > 0 {
+		arg := args[0]
+		switch {
+		case wrapper == "sudo" && sudoValueOptions[arg] && len(args) > 1:
+			args = args[1:]
+		case strings.HasPrefix(arg, "-"):
+		case wrapper == "env" && strings.Contains(arg, "="):
+		case wrapper == "timeout" && arg[0] >= '0' && arg[0] <= '9':
+		default:
+			return args
+		}
+		args = args[1:]
+	}
+	return args
+}
+
+// shellPrograms are the shells whose -c option runs a script.
+var shellPrograms = map[string]bool{
+	"sh": true, "bash": true, "dash": true, "zsh": true, "ksh": true, "ash": true,
+}
+
+// xargsValueOptions are the options of xargs that take a separate value.
+var xargsValueOptions = map[string]bool{
+	"-I": true, "-n": true, "-P": true, "-L": true, "-d": true, "-E": true,
+	"-s": true, "-a": true,
+}
+
+// innerScript returns the script run by an unwrapped command, such as the
+// argument of sh -c or the command xargs runs.
+func innerScript(args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	switch {
+	case shellPrograms[args[0]]:
+		for i := 1; i < len(args); i++ {
+			arg := args[i]
+			if !strings.HasPrefix(arg, "-") || arg == "--" {
+				break
+			}
+			if !strings.HasPrefix(arg, "--") && strings.Contains(arg, "c") && i+1 < len(args) {
+				return args[i+1], true
+			}
+		}
+	case args[0] == "eval":
+		return strings.Join(args[1:], " "), true
+	case args[0] == "xargs":
+		rest := args[1:]
+		for len(rest) > 0 && strings.HasPrefix(rest[0], "-") {
+			if xargsValueOptions[rest[0]] {
+				rest = rest[1:]
+			}
+			if len(rest) > 0 {
+				rest = rest[1:]
+			}
+		}
+		if len(rest) > 0 {
+			return strings.Join(rest, " "), true
+		}
+	}
+	return "", false
+}
+
+// deniedFlag reports whether arg is flag, flag with an "=value" or, for a
+// short flag such as "-p", flag with its value attached.
+func deniedFlag(arg, flag string) bool {
+	if arg == flag || strings.HasPrefix(arg, flag+"=") {
+		return true
+	}
+	short := len(flag) == 2 && flag[0] == '-' && flag[1] != '-'
+	return short && strings.HasPrefix(arg, flag)
+}
+
+// compiledPatterns caches the compiled command patterns, or nil for invalid
+// ones, so that Allows does not compile them on every call.
+var compiledPatterns sync.Map
+
+func matchAny(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if re := compilePattern(pattern); re != nil && re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, _ := regexp.Compile(pattern)
+	compiledPatterns.Store(pattern, re)
+	return re
+}
+
+func (p *ExecutionPolicy) validate() error {
+	for _, patterns := range [][]string{p.AllowCommands, p.DenyCommands} {
+		for _, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid command pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// executionPolicyPath returns the path of the execution policy of a
+// project, or of the organization if projectID is zero.
+func executionPolicyPath(projectID int64) string {
+	if projectID == 0 {
+		return "/execution-policy"
+	}
+	return fmt.Sprintf("/projects/%d/execution-policy", projectID)
+}
+
+// GetExecutionPolicy returns the execution policy of a project, or the
+// organization's default policy if projectID is zero. Projects without
+// their own policy return the default.
+func (c *Client) GetExecutionPolicy(projectID int64) (*ExecutionPolicy, error) {
+	return c.GetExecutionPolicyContext(context.Background(), projectID)
+}
+
+// GetExecutionPolicyContext is like GetExecutionPolicy but uses ctx for its
+// request.
+func (c *Client) GetExecutionPolicyContext(ctx context.Context, projectID int64) (*ExecutionPolicy, error) {
+	var policy ExecutionPolicy
+	if err := c.requestContext(ctx, "GET", executionPolicyPath(projectID), nil, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// UpdateExecutionPolicy sets the execution policy of a project, or the
+// organization's default policy if projectID is zero. Scans already running
+// pick up the new policy before their next command.
+func (c *Client) UpdateExecutionPolicy(projectID int64, policy *ExecutionPolicy) (*ExecutionPolicy, error) {
+	return c.UpdateExecutionPolicyContext(context.Background(), projectID, policy)
+}
+
+// UpdateExecutionPolicyContext is like UpdateExecutionPolicy but uses ctx for its
+// request.
+func (c *Client) UpdateExecutionPolicyContext(ctx context.Context, projectID int64, policy *ExecutionPolicy) (*ExecutionPolicy, error) {
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	var updated ExecutionPolicy
+	if err := c.requestContext(ctx, "PUT", executionPolicyPath(projectID), policy, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
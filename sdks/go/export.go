@@ -0,0 +1,72 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+)
+
+// =============================================================================
+// Exports
+// =============================================================================
+
+// DefaultExportPageSize is the page size used by ExportFindingsResumable when
+// the filter does not set a Limit.
+const DefaultExportPageSize = 500
+
+// ExportFindingsResumable passes the findings matching filter to fn one page
+// at a time, checkpointing the cursor in store after fn accepts each page.
+// If the export is interrupted, calling it again with the same store resumes
+// after the last accepted page instead of starting over. Pages are therefore
+// delivered at least once: a page fn was processing when the process died is
+// delivered again. The store is reset once the export completes.
+//
+// It returns the number of findings passed to fn. filter may be nil, and its
+// Cursor is ignored in favour of the stored one.
+func (c *Client) ExportFindingsResumable(ctx context.Context, filter *FindingsFilter, store CursorStore, fn func(findings []Finding) error) (int, error) {
+	if err := filter.validate(); err != nil {
+		return 0, err
+	}
+	f := FindingsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	if f.Limit == 0 {
+		f.Limit = DefaultExportPageSize
+	}
+	cursor, err := store.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("loading export cursor: %w", err)
+	}
+
+	exported := 0
+	seen := cursorLoop{cursor: true}
+	for {
+		f.Cursor = cursor
+		var findings []Finding
+		page, err := c.listPage(ctx, withQuery("/findings", f.values()), &findings)
+		if err != nil {
+			return exported, err
+		}
+		if len(findings) > 0 {
+			if err := fn(findings); err != nil {
+				return exported, err
+			}
+			exported += len(findings)
+		}
+		if !page.HasNext || page.NextCursor == "" {
+			break
+		}
+		if err := seen.follow("/findings", page.NextCursor); err != nil {
+			return exported, err
+		}
+		cursor = page.NextCursor
+		if err := store.Save(ctx, cursor); err != nil {
+			return exported, fmt.Errorf("saving export cursor: %w", err)
+		}
+	}
+
+	if err := store.Save(ctx, ""); err != nil {
+		return exported, fmt.Errorf("saving export cursor: %w", err)
+	}
+	return exported, nil
+}
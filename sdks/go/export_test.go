@@ -0,0 +1,75 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestExportFindingsResumable(t *testing.T) {
+	// Serves findings 1-7 in pages of limit, with the next ID as cursor.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start := int64(1)
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			start, _ = strconv.ParseInt(cursor, 10, 64)
+		}
+		end := start + int64(limit)
+		if end > 8 {
+			end = 8
+		} else {
+			w.Header().Set("X-Next-Cursor", strconv.FormatInt(end, 10))
+		}
+		w.Write([]byte("["))
+		for id := start; id < end; id++ {
+			if id > start {
+				w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"id": %d}`, id)
+		}
+		w.Write([]byte("]"))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	store := &MemoryCursorStore{}
+	filter := &FindingsFilter{ListOptions: ListOptions{Limit: 3}}
+
+	var ids []int64
+	interrupt := errors.New("interrupted")
+	n, err := client.ExportFindingsResumable(ctx, filter, store, func(findings []Finding) error {
+		if findings[0].ID == 4 {
+			return interrupt
+		}
+		for _, f := range findings {
+			ids = append(ids, f.ID)
+		}
+		return nil
+	})
+	if !errors.Is(err, interrupt) || n != 3 {
+		t.Fatalf("Expected interruption after 3 findings, got %d, %v", n, err)
+	}
+	if cursor, _ := store.Load(ctx); cursor != "4" {
+		t.Errorf("Expected checkpoint 4, got %q", cursor)
+	}
+
+	n, err = client.ExportFindingsResumable(ctx, filter, store, func(findings []Finding) error {
+		for _, f := range findings {
+			ids = append(ids, f.ID)
+		}
+		return nil
+	})
+	if err != nil || n != 4 {
+		t.Fatalf("Expected 4 more findings, got %d, %v", n, err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5 6 7]" {
+		t.Errorf("Expected each finding once, got %v", ids)
+	}
+	if cursor, _ := store.Load(ctx); cursor != "" {
+		t.Errorf("Expected store reset after completion, got %q", cursor)
+	}
+}
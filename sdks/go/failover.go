@@ -0,0 +1,141 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Failover
+// =============================================================================
+
+// DefaultProbeInterval is how often a client that has failed over checks
+// whether its primary server is healthy again.
+const DefaultProbeInterval = 30 * time.Second
+
+// probeTimeout bounds a health probe of the primary server.
+const probeTimeout = 5 * time.Second
+
+// WithFallbackURLs sets standby servers that the client fails over to, in
+// order, when the server at its base URL is unreachable. Requests stay on a
+// standby once they have failed over; the primary is probed in the
+// background every DefaultProbeInterval and used again as soon as its
+// /health endpoint responds. Only failures to connect cause failover, so a
+// request is never sent to two servers: error responses, timeouts and
+// connections dropped after the request was sent do not.
+//
+// Clients derived with With or WithContext share the failover state.
+func WithFallbackURLs(urls ...string) ClientOption {
+	return func(c *Client) {
+		c.failover = &failover{fallbacks: urls, interval: DefaultProbeInterval}
+	}
+}
+
+// failover tracks which of a client's servers requests are sent to.
+type failover struct {
+	fallbacks []string
+	interval  time.Duration
+
+	mu        sync.Mutex
+	active    int // 0 is the primary, i is fallbacks[i-1]
+	lastProbe time.Time
+	probing   bool
+}
+
+// ActiveURL returns the base URL requests are currently sent to, which is
+// a fallback URL if the client has failed over.
+func (c *Client) ActiveURL() string {
+	if c.failover == nil {
+		return c.primaryURL()
+	}
+	f := c.failover
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.url(c.primaryURL(), f.active)
+}
+
+// baseURL returns the base URL for the next request and starts a probe of
+// the primary if it is due.
+func (c *Client) baseURL() string {
+	if c.failover == nil {
+		return c.primaryURL()
+	}
+	primary := c.primaryURL()
+	f := c.failover
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active != 0 && !f.probing && time.Since(f.lastProbe) >= f.interval {
+		f.probing = true
+		f.lastProbe = time.Now()
+		go c.probe(primary)
+	}
+	return f.url(primary, f.active)
+}
+
+func (f *failover) url(primary string, i int) string {
+	if i == 0 {
+		return primary
+	}
+	return f.fallbacks[i-1]
+}
+
+// failed moves requests to the server after base, if base is still the
+// active server. Another request may already have moved on.
+func (c *Client) failed(base string) {
+	f := c.failover
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.url(c.primaryURL(), f.active) != base {
+		return
+	}
+	f.active = (f.active + 1) % (len(f.fallbacks) + 1)
+	if f.active != 0 {
+		// The primary was just found unreachable, so wait a full interval
+		// before probing it.
+		f.lastProbe = time.Now()
+	}
+}
+
+// probe checks the health of the primary server and moves requests back to
+// it if it responds.
+func (c *Client) probe(primary string) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	healthy := false
+	req, err := http.NewRequestWithContext(ctx, "GET", primary+"/health", nil)
+	if err == nil {
+		if resp, err := c.httpClient().Do(req); err == nil {
+			resp.Body.Close()
+			healthy = resp.StatusCode < 300
+		}
+	}
+
+	f := c.failover
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probing = false
+	if healthy {
+		f.active = 0
+	}
+}
+
+// shouldFailover reports whether err means the server could not be reached,
+// so the request cannot have been sent, rather than that the request was
+// canceled or failed after it was sent.
+func shouldFailover(ctx context.Context, err error) bool {
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) || ctx.Err() != nil {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
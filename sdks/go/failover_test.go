@@ -0,0 +1,127 @@
+package aiptx
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFailover(t *testing.T) {
+	var primaryHits, standbyHits atomic.Int32
+	handler := func(hits *atomic.Int32) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/health" {
+				hits.Add(1)
+			}
+			w.Write([]byte(`{"status": "ok", "id": 1, "name": "Test"}`))
+		}
+	}
+
+	standby := httptest.NewServer(handler(&standbyHits))
+	defer standby.Close()
+
+	// Reserve an address for the primary and leave it closed.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+	primaryURL := "http://" + addr
+
+	client := NewClient(primaryURL, WithAPIKey("test-key"), WithFallbackURLs(standby.URL))
+	if _, err := client.GetProject(1); err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	if standbyHits.Load() != 1 {
+		t.Errorf("Expected 1 request to the standby, got %d", standbyHits.Load())
+	}
+	if client.ActiveURL() != standby.URL {
+		t.Errorf("Expected active URL %s, got %s", standby.URL, client.ActiveURL())
+	}
+
+	// Requests stick to the standby until a probe finds the primary healthy.
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("cannot reuse primary address: %v", err)
+	}
+	primary := httptest.NewUnstartedServer(handler(&primaryHits))
+	primary.Listener = listener
+	primary.Start()
+	defer primary.Close()
+
+	if _, err := client.GetProject(1); err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	if standbyHits.Load() != 2 {
+		t.Errorf("Expected 2 requests to the standby, got %d", standbyHits.Load())
+	}
+
+	client.failover.mu.Lock()
+	client.failover.interval = 0
+	client.failover.mu.Unlock()
+	client.GetProject(1)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for client.ActiveURL() != primaryURL && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if client.ActiveURL() != primaryURL {
+		t.Fatalf("Expected active URL %s after probe, got %s", primaryURL, client.ActiveURL())
+	}
+	if _, err := client.GetProject(1); err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	if primaryHits.Load() != 1 {
+		t.Errorf("Expected 1 request to the primary, got %d", primaryHits.Load())
+	}
+}
+
+func TestFailoverAllDown(t *testing.T) {
+	var urls []string
+	for i := 0; i < 2; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		urls = append(urls, "http://"+listener.Addr().String())
+		listener.Close()
+	}
+
+	client := NewClient(urls[0], WithAPIKey("test-key"), WithFallbackURLs(urls[1]))
+	_, err := client.GetProject(1)
+	if _, ok := err.(*TransportError); !ok {
+		t.Errorf("Expected *TransportError, got %T: %v", err, err)
+	}
+}
+
+func TestNoFailoverAfterSend(t *testing.T) {
+	var standbyHits atomic.Int32
+	standby := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		standbyHits.Add(1)
+		w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+	}))
+	defer standby.Close()
+
+	// The primary receives the request and drops the connection, so the
+	// scan may have started there.
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer primary.Close()
+
+	client := NewClient(primary.URL, WithAPIKey("test-key"), WithFallbackURLs(standby.URL))
+	_, err := client.StartScan(&ScanRequest{Target: "example.com"})
+	if _, ok := err.(*TransportError); !ok {
+		t.Errorf("Expected *TransportError, got %T: %v", err, err)
+	}
+	if standbyHits.Load() != 0 || client.ActiveURL() != primary.URL {
+		t.Errorf("Expected no failover, got %d requests to the standby", standbyHits.Load())
+	}
+}
@@ -0,0 +1,92 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// =============================================================================
+// Feature Flags
+// =============================================================================
+
+// Feature flags checked by the SDK.
+const (
+	FeatureExploitation = "exploitation"
+	FeatureAI           = "ai"
+	FeatureBackups      = "backups"
+	FeatureDryRun       = "dry_run"
+)
+
+// ErrFeatureDisabled is matched by errors returned when a request needs a
+// feature that is disabled on the server.
+var ErrFeatureDisabled = errors.New("feature disabled")
+
+// FeatureDisabledError is returned instead of a 403 Forbidden response when
+// the request needs a feature that the server has disabled, such as
+// exploitation. It matches ErrFeatureDisabled and wraps the *APIError.
+type FeatureDisabledError struct {
+	Feature string
+	Err     error
+}
+
+func (e *FeatureDisabledError) Error() string {
+	return fmt.Sprintf("AIPTX feature %q is disabled on the server", e.Feature)
+}
+
+func (e *FeatureDisabledError) Is(target error) bool {
+	return target == ErrFeatureDisabled
+}
+
+func (e *FeatureDisabledError) Unwrap() error {
+	return e.Err
+}
+
+// FeatureFlags are the features enabled or disabled on the server.
+type FeatureFlags struct {
+	Flags map[string]bool `json:"flags"`
+
+	unknown *rawFields
+}
+
+// Enabled reports whether the named feature is enabled. Features the server
+// does not report are considered enabled, as servers predating a flag
+// always have the feature.
+func (f *FeatureFlags) Enabled(name string) bool {
+	enabled, ok := f.Flags[name]
+	return enabled || !ok
+}
+
+// GetFeatureFlags returns the server's feature flags.
+func (c *Client) GetFeatureFlags() (*FeatureFlags, error) {
+	return c.GetFeatureFlagsContext(context.Background())
+}
+
+// GetFeatureFlagsContext is like GetFeatureFlags but uses ctx for its request.
+func (c *Client) GetFeatureFlagsContext(ctx context.Context) (*FeatureFlags, error) {
+	var flags FeatureFlags
+	if err := c.requestContext(ctx, "GET", "/features", nil, &flags); err != nil {
+		return nil, err
+	}
+	return &flags, nil
+}
+
+// featureError explains a 403 Forbidden response to a request needing
+// features, returning a *FeatureDisabledError if one of them is disabled.
+// Other errors, and 403s when the flags cannot be read or all features are
+// enabled, are returned unchanged.
+func (c *Client) featureError(ctx context.Context, err error, features ...string) error {
+	if len(features) == 0 || !errors.Is(err, ErrForbidden) {
+		return err
+	}
+	flags, flagsErr := c.GetFeatureFlagsContext(ctx)
+	if flagsErr != nil {
+		return err
+	}
+	for _, feature := range features {
+		if !flags.Enabled(feature) {
+			return &FeatureDisabledError{Feature: feature, Err: err}
+		}
+	}
+	return err
+}
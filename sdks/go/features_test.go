@@ -0,0 +1,54 @@
+package aiptx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFeatureDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/features":
+			w.Write([]byte(`{"flags": {"exploitation": false, "ai": true}}`))
+		case "/scan":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"detail": "Forbidden"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	flags, err := client.GetFeatureFlags()
+	if err != nil {
+		t.Fatalf("GetFeatureFlags failed: %v", err)
+	}
+	if flags.Enabled(FeatureExploitation) || !flags.Enabled(FeatureAI) || !flags.Enabled("unreported") {
+		t.Errorf("Unexpected flags %v", flags.Flags)
+	}
+
+	_, err = client.StartScan(&ScanRequest{Target: "example.com", AI: true, Exploit: true})
+	var disabled *FeatureDisabledError
+	if !errors.As(err, &disabled) {
+		t.Fatalf("Expected *FeatureDisabledError, got %T: %v", err, err)
+	}
+	if disabled.Feature != FeatureExploitation {
+		t.Errorf("Expected feature exploitation, got %s", disabled.Feature)
+	}
+	if !errors.Is(err, ErrFeatureDisabled) || !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected error to match ErrFeatureDisabled and ErrForbidden")
+	}
+
+	// A 403 for a scan without exploitation is a plain permission error.
+	_, err = client.StartScan(&ScanRequest{Target: "example.com", AI: true})
+	if errors.Is(err, ErrFeatureDisabled) || !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected plain forbidden error, got %v", err)
+	}
+
+	if _, err := client.StartScan(nil); err != errNilScanRequest {
+		t.Errorf("Expected error for nil scan request, got %v", err)
+	}
+}
@@ -0,0 +1,371 @@
+package aiptx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// Filter Expressions
+// =============================================================================
+
+// FilterSyntaxError is returned by ParseFilter when an expression is malformed.
+type FilterSyntaxError struct {
+	Expr    string
+	Pos     int
+	Message string
+}
+
+func (e *FilterSyntaxError) Error() string {
+	return fmt.Sprintf("invalid filter expression at position %d: %s", e.Pos, e.Message)
+}
+
+// ParseFilter parses a filter expression into a FindingsFilter.
+//
+// An expression is a list of conditions joined by AND. Each condition is
+// either a comparison or a boolean field, optionally negated with NOT:
+//
+//	severity>=high AND tool=nuclei AND NOT false_positive
+//
+// Supported fields are project, severity, type, tool, stage (the kill chain
+// stage), owner, remediation (the remediation status), verified,
+// false_positive, overdue, new_since_baseline and confidence. Severity
+// accepts =, >= and >, and confidence only >=, as in confidence>=0.8; all
+// other comparisons use =. Severity, type, stage and remediation also
+// accept a list of alternatives:
+//
+//	severity IN (high, critical) AND type IN (vulnerability, exposure)
+//
+// Apart from stage and remediation, each field may appear in only one
+// condition.
+//
+// Values containing spaces may be double-quoted.
+func ParseFilter(expr string) (*FindingsFilter, error) {
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{expr: expr, tokens: tokens, seen: map[string]bool{}}
+	filter := &FindingsFilter{}
+
+	if len(p.tokens) == 0 {
+		return filter, nil
+	}
+	for {
+		if err := p.parseCondition(filter); err != nil {
+			return nil, err
+		}
+		tok, ok := p.next()
+		if !ok {
+			return filter, nil
+		}
+		if !strings.EqualFold(tok.text, "AND") {
+			return nil, p.errorf(tok, "expected AND, got %q", tok.text)
+		}
+	}
+}
+
+type filterToken struct {
+	text   string
+	pos    int
+	quoted bool
+}
+
+// lexFilter splits an expression into words, quoted strings and operators.
+func lexFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			start := i
+			i++
+			var sb strings.Builder
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i == len(runes) {
+				return nil, &FilterSyntaxError{Expr: expr, Pos: start, Message: "unterminated quoted string"}
+			}
+			i++
+			tokens = append(tokens, filterToken{text: sb.String(), pos: start, quoted: true})
+		case strings.ContainsRune("(),", r):
+			tokens = append(tokens, filterToken{text: string(r), pos: i})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			tokens = append(tokens, filterToken{text: string(runes[start:i]), pos: start})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("=!<>\"(),", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{text: string(runes[start:i]), pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	expr   string
+	tokens []filterToken
+	i      int
+	// seen holds the fields that already have a condition.
+	seen map[string]bool
+}
+
+func (p *filterParser) next() (filterToken, bool) {
+	if p.i >= len(p.tokens) {
+		return filterToken{pos: len(p.expr)}, false
+	}
+	tok := p.tokens[p.i]
+	p.i++
+	return tok, true
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.i >= len(p.tokens) {
+		return filterToken{pos: len(p.expr)}, false
+	}
+	return p.tokens[p.i], true
+}
+
+func (p *filterParser) errorf(tok filterToken, format string, args ...interface{}) error {
+	return &FilterSyntaxError{Expr: p.expr, Pos: tok.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func isFilterOperator(tok filterToken) bool {
+	if tok.quoted {
+		return false
+	}
+	switch tok.text {
+	case "=", "!=", ">", ">=", "<", "<=":
+		return true
+	}
+	return false
+}
+
+// parseCondition parses one condition and applies it to filter.
+func (p *filterParser) parseCondition(filter *FindingsFilter) error {
+	field, ok := p.next()
+	if !ok {
+		return p.errorf(field, "expected condition")
+	}
+
+	negate := false
+	if !field.quoted && strings.EqualFold(field.text, "NOT") {
+		negate = true
+		if field, ok = p.next(); !ok {
+			return p.errorf(field, "expected field after NOT")
+		}
+	}
+	name := strings.ToLower(field.text)
+	if err := p.once(field); err != nil {
+		return err
+	}
+
+	op, ok := p.peek()
+	if ok && !op.quoted && strings.EqualFold(op.text, "IN") {
+		p.i++
+		if negate {
+			return p.errorf(field, "NOT can only be applied to boolean fields")
+		}
+		return p.parseList(field, filter)
+	}
+	if !ok || !isFilterOperator(op) {
+		// Bare boolean field, e.g. "verified" or "NOT false_positive".
+		value := !negate
+		switch name {
+		case "verified":
+			filter.Verified = &value
+		case "false_positive":
+			filter.FalsePositive = &value
+		case "overdue":
+			if negate {
+				return p.errorf(field, "NOT cannot be applied to %s", field.text)
+			}
+			filter.Overdue = true
+		case "new_since_baseline":
+			if negate {
+				return p.errorf(field, "NOT cannot be applied to %s", field.text)
+			}
+			filter.NewSinceBaseline = true
+		default:
+			return p.errorf(field, "field %q is not a boolean", field.text)
+		}
+		return nil
+	}
+	p.i++
+	if negate {
+		return p.errorf(field, "NOT can only be applied to boolean fields")
+	}
+
+	value, ok := p.next()
+	if !ok || isFilterOperator(value) {
+		return p.errorf(value, "expected value after %s", op.text)
+	}
+
+	if name != "severity" && name != "confidence" && op.text != "=" {
+		return p.errorf(op, "operator %s is not supported for %s", op.text, name)
+	}
+
+	switch name {
+	case "project", "project_id":
+		id, err := strconv.ParseInt(value.text, 10, 64)
+		if err != nil || id <= 0 {
+			return p.errorf(value, "invalid project ID %q", value.text)
+		}
+		filter.ProjectID = id
+	case "severity":
+		sev := Severity(strings.ToLower(value.text))
+		rank := sev.rank()
+		if rank < 0 {
+			return p.errorf(value, "unknown severity %q", value.text)
+		}
+		switch op.text {
+		case "=":
+			filter.Severity = sev
+		case ">=":
+			filter.MinSeverity = sev
+		case ">":
+			if rank == len(severityOrder)-1 {
+				return p.errorf(value, "no severity is greater than %s", sev)
+			}
+			filter.MinSeverity = severityOrder[rank+1]
+		default:
+			return p.errorf(op, "operator %s is not supported for severity", op.text)
+		}
+	case "confidence":
+		if op.text != ">=" {
+			return p.errorf(op, "operator %s is not supported for confidence", op.text)
+		}
+		c, err := strconv.ParseFloat(value.text, 64)
+		if err != nil || c < 0 || c > 1 {
+			return p.errorf(value, "invalid confidence %q", value.text)
+		}
+		filter.MinConfidence = c
+	case "type":
+		filter.Type = value.text
+	case "tool":
+		filter.Tool = value.text
+	case "owner":
+		filter.Owner = value.text
+	case "remediation", "remediation_status":
+		filter.RemediationStatuses = append(filter.RemediationStatuses, RemediationStatus(strings.ToLower(value.text)))
+	case "stage", "kill_chain_stage":
+		stage, ok := parseKillChainStage(value.text)
+		if !ok {
+			return p.errorf(value, "unknown kill chain stage %q", value.text)
+		}
+		filter.KillChainStages = append(filter.KillChainStages, stage)
+	case "verified", "false_positive":
+		b, err := strconv.ParseBool(value.text)
+		if err != nil {
+			return p.errorf(value, "invalid boolean %q", value.text)
+		}
+		if name == "verified" {
+			filter.Verified = &b
+		} else {
+			filter.FalsePositive = &b
+		}
+	default:
+		return p.errorf(field, "unknown field %q", field.text)
+	}
+	return nil
+}
+
+// filterFieldAliases maps the long names of fields to their short names.
+var filterFieldAliases = map[string]string{
+	"project_id":         "project",
+	"remediation_status": "remediation",
+	"kill_chain_stage":   "stage",
+}
+
+// once records a condition on field, returning an error if the field already
+// has one, since the later condition would silently replace it. Conditions
+// on stage and remediation add to a list of alternatives, so they may be
+// repeated.
+func (p *filterParser) once(field filterToken) error {
+	name := strings.ToLower(field.text)
+	if alias, ok := filterFieldAliases[name]; ok {
+		name = alias
+	}
+	if name == "stage" || name == "remediation" {
+		return nil
+	}
+	if p.seen[name] {
+		return p.errorf(field, "repeated condition on %s", field.text)
+	}
+	p.seen[name] = true
+	return nil
+}
+
+// parseList parses the parenthesised value list following IN.
+func (p *filterParser) parseList(field filterToken, filter *FindingsFilter) error {
+	if open, ok := p.next(); !ok || open.text != "(" || open.quoted {
+		return p.errorf(open, "expected ( after IN")
+	}
+
+	var values []filterToken
+	for {
+		value, ok := p.next()
+		if !ok || (!value.quoted && strings.ContainsAny(value.text, "(),")) || isFilterOperator(value) {
+			return p.errorf(value, "expected value in list")
+		}
+		values = append(values, value)
+
+		sep, ok := p.next()
+		if !ok {
+			return p.errorf(sep, "expected ) to close list")
+		}
+		if sep.text == ")" && !sep.quoted {
+			break
+		}
+		if sep.text != "," || sep.quoted {
+			return p.errorf(sep, "expected , or ) in list, got %q", sep.text)
+		}
+	}
+
+	switch strings.ToLower(field.text) {
+	case "severity":
+		for _, value := range values {
+			sev := Severity(strings.ToLower(value.text))
+			if sev.rank() < 0 {
+				return p.errorf(value, "unknown severity %q", value.text)
+			}
+			filter.Severities = append(filter.Severities, sev)
+		}
+	case "type":
+		for _, value := range values {
+			filter.Types = append(filter.Types, value.text)
+		}
+	case "remediation", "remediation_status":
+		for _, value := range values {
+			filter.RemediationStatuses = append(filter.RemediationStatuses, RemediationStatus(strings.ToLower(value.text)))
+		}
+	case "stage", "kill_chain_stage":
+		for _, value := range values {
+			stage, ok := parseKillChainStage(value.text)
+			if !ok {
+				return p.errorf(value, "unknown kill chain stage %q", value.text)
+			}
+			filter.KillChainStages = append(filter.KillChainStages, stage)
+		}
+	default:
+		return p.errorf(field, "IN is not supported for %s", field.text)
+	}
+	return nil
+}
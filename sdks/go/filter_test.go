@@ -0,0 +1,148 @@
+package aiptx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter(`severity>=high AND tool=nuclei AND NOT false_positive AND project=42`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filter.MinSeverity != "high" {
+		t.Errorf("Expected min severity high, got %s", filter.MinSeverity)
+	}
+	if filter.Tool != "nuclei" {
+		t.Errorf("Expected tool nuclei, got %s", filter.Tool)
+	}
+	if filter.FalsePositive == nil || *filter.FalsePositive {
+		t.Errorf("Expected false_positive=false")
+	}
+	if filter.ProjectID != 42 {
+		t.Errorf("Expected project 42, got %d", filter.ProjectID)
+	}
+
+	params := filter.values()
+	if params.Get("min_severity") != "high" || params.Get("false_positive") != "false" {
+		t.Errorf("Unexpected query params: %s", params.Encode())
+	}
+}
+
+func TestParseFilterValues(t *testing.T) {
+	filter, err := ParseFilter(`severity > medium and type="open port" and verified=true`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filter.MinSeverity != "high" {
+		t.Errorf("Expected min severity high, got %s", filter.MinSeverity)
+	}
+	if filter.Type != "open port" {
+		t.Errorf("Expected quoted type, got %q", filter.Type)
+	}
+	if filter.Verified == nil || !*filter.Verified {
+		t.Errorf("Expected verified=true")
+	}
+}
+
+func TestParseFilterLists(t *testing.T) {
+	filter, err := ParseFilter(`severity IN (high, critical) AND type in ("open port", vulnerability)`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(filter.Severities) != 2 || filter.Severities[1] != SeverityCritical {
+		t.Errorf("Expected [high critical], got %v", filter.Severities)
+	}
+	if len(filter.Types) != 2 || filter.Types[0] != "open port" {
+		t.Errorf("Expected two types, got %v", filter.Types)
+	}
+
+	params := filter.values()
+	if len(params["severity"]) != 2 || len(params["type"]) != 2 {
+		t.Errorf("Expected repeated params, got %s", params.Encode())
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []string{
+		"severity>=hgih",
+		"severity>=high OR tool=nuclei",
+		"NOT tool=nuclei",
+		"tool>=nuclei",
+		"color=red",
+		"severity>=",
+		"severity>critical",
+		"severity IN (high, urgent)",
+		"tool IN (nuclei)",
+		"severity IN (high",
+		`type="open port`,
+		"tool=nuclei AND tool=nmap",
+		"severity>=high AND severity=critical",
+		"project=1 AND project_id=2",
+	}
+	for _, expr := range tests {
+		_, err := ParseFilter(expr)
+		var syntaxErr *FilterSyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Expected FilterSyntaxError for %q, got %v", expr, err)
+		}
+	}
+}
+
+func TestParseFilterNewSinceBaseline(t *testing.T) {
+	filter, err := ParseFilter(`new_since_baseline AND severity>=high`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !filter.NewSinceBaseline || filter.values().Get("new_since_baseline") != "true" {
+		t.Errorf("Expected new_since_baseline, got %s", filter.values().Encode())
+	}
+	if _, err := ParseFilter(`NOT new_since_baseline`); err == nil {
+		t.Errorf("Expected error for negated new_since_baseline")
+	}
+}
+
+func TestParseFilterKillChainStage(t *testing.T) {
+	filter, err := ParseFilter(`stage IN (Exploitation, c2) AND kill_chain_stage=exfil`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(filter.KillChainStages) != 3 || filter.KillChainStages[0] != StageExploitation {
+		t.Errorf("Expected three stages, got %v", filter.KillChainStages)
+	}
+	if got := filter.values()["kill_chain_stage"]; len(got) != 3 || got[2] != "exfil" {
+		t.Errorf("Expected kill_chain_stage params, got %v", got)
+	}
+	if !filter.Matches(Finding{KillChainStage: StageC2}) || filter.Matches(Finding{KillChainStage: StageRecon}) {
+		t.Errorf("Expected Matches to check the stage")
+	}
+	if _, err := ParseFilter(`stage=lateral`); err == nil {
+		t.Errorf("Expected error for unknown stage")
+	}
+}
+
+func TestParseFilterRemediation(t *testing.T) {
+	filter, err := ParseFilter(`owner=alice AND remediation IN (open, in_progress) AND overdue`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	params := filter.values()
+	if params.Get("owner") != "alice" || len(params["remediation_status"]) != 2 || params.Get("overdue") != "true" {
+		t.Errorf("Unexpected query params: %s", params.Encode())
+	}
+}
+
+func TestParseFilterConfidence(t *testing.T) {
+	filter, err := ParseFilter(`confidence>=0.75 AND severity>=high`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filter.MinConfidence != 0.75 || filter.values().Get("min_confidence") != "0.75" {
+		t.Errorf("Expected min_confidence 0.75, got %s", filter.values().Encode())
+	}
+	for _, expr := range []string{`confidence=0.5`, `confidence>=1.5`, `confidence>=high`} {
+		if _, err := ParseFilter(expr); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
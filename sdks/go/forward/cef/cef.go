@@ -0,0 +1,208 @@
+// Package cef converts AIPTX findings and scan events into CEF or LEEF
+// messages and forwards them to a SIEM such as ArcSight or QRadar over
+// syslog:
+//
+//	fwd := cef.NewForwarder("tls", "siem.internal:6514")
+//	fwd.Format = cef.LEEF
+//	defer fwd.Close()
+//	for stream.Next() {
+//	    if err := fwd.SendEvent(stream.Event()); err != nil {
+//	        log.Print(err)
+//	    }
+//	}
+package cef
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Header fields identifying AIPTX as the device.
+const (
+	Vendor        = "AIPTX"
+	Product       = "AIPTX"
+	DeviceVersion = "1.0"
+)
+
+// Format is a SIEM message format.
+type Format int
+
+// Message formats.
+const (
+	// CEF is ArcSight's Common Event Format.
+	CEF Format = iota
+	// LEEF is QRadar's Log Event Extended Format, version 1.0.
+	LEEF
+)
+
+// Extension is a key-value pair in the extension of a message.
+type Extension struct {
+	Key   string
+	Value string
+}
+
+// Message is a finding or scan event, ready to be formatted as CEF or LEEF.
+type Message struct {
+	// SignatureID identifies the kind of event, e.g. the finding type.
+	SignatureID string
+	Name        string
+	// Severity ranges from 0 to 10.
+	Severity   int
+	Time       time.Time
+	Extensions []Extension
+}
+
+// severities maps finding severities to the 0-10 scale of CEF and LEEF.
+var severities = map[aiptx.Severity]int{
+	aiptx.SeverityInfo:     1,
+	aiptx.SeverityLow:      3,
+	aiptx.SeverityMedium:   5,
+	aiptx.SeverityHigh:     8,
+	aiptx.SeverityCritical: 10,
+}
+
+// FromFinding converts a finding into a message.
+func FromFinding(f aiptx.Finding) *Message {
+	m := &Message{
+		SignatureID: "finding:" + f.Type,
+		Name:        fmt.Sprintf("%s finding: %s", f.Type, f.Value),
+		Severity:    severities[f.Severity],
+		Time:        f.DiscoveredAt,
+	}
+	m.add("externalId", strconv.FormatInt(f.ID, 10))
+	m.add("cat", f.Type)
+	m.add("msg", f.Description)
+	if u, err := url.Parse(f.Value); err == nil && u.Scheme != "" && u.Host != "" {
+		m.add("request", f.Value)
+		m.add("dhost", u.Hostname())
+	}
+	m.add("cs1Label", "projectId")
+	m.add("cs1", strconv.FormatInt(f.ProjectID, 10))
+	if f.Tool != "" {
+		m.add("cs2Label", "tool")
+		m.add("cs2", f.Tool)
+	}
+	if f.KillChainStage != "" {
+		m.add("cs3Label", "killChainStage")
+		m.add("cs3", string(f.KillChainStage))
+	}
+	if f.Confidence > 0 {
+		m.add("cfp1Label", "confidence")
+		m.add("cfp1", strconv.FormatFloat(f.Confidence, 'f', -1, 64))
+	}
+	return m
+}
+
+// FromScan converts a scan status into a message. action is the event type,
+// such as aiptx.EventScanStarted.
+func FromScan(s aiptx.ScanStatus, action string, at time.Time) *Message {
+	m := &Message{
+		SignatureID: action,
+		Name:        fmt.Sprintf("scan %s", strings.TrimPrefix(action, "scan.")),
+		Severity:    1,
+		Time:        at,
+	}
+	if action == aiptx.EventScanFailed {
+		m.Severity = 5
+	}
+	m.add("externalId", s.ID)
+	m.add("act", action)
+	m.add("outcome", string(s.Status))
+	m.add("msg", s.Error)
+	m.add("cs4Label", "phase")
+	m.add("cs4", string(s.Phase))
+	return m
+}
+
+// ErrUnsupportedEvent is returned by FromEvent for events that are neither
+// finding nor scan events.
+var ErrUnsupportedEvent = errors.New("cef: unsupported event type")
+
+// FromEvent converts a finding or scan event into a message. Other event
+// types return an error wrapping ErrUnsupportedEvent.
+func FromEvent(ev aiptx.Event) (*Message, error) {
+	switch ev.Type {
+	case aiptx.EventFindingCreated, aiptx.EventFindingUpdated:
+		var f aiptx.Finding
+		if err := ev.Decode(&f); err != nil {
+			return nil, err
+		}
+		return FromFinding(f), nil
+	case aiptx.EventScanStarted, aiptx.EventScanPhaseChanged, aiptx.EventScanCompleted, aiptx.EventScanFailed:
+		var s aiptx.ScanStatus
+		if err := ev.Decode(&s); err != nil {
+			return nil, err
+		}
+		return FromScan(s, ev.Type, ev.Time), nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnsupportedEvent, ev.Type)
+}
+
+// add appends an extension unless value is empty.
+func (m *Message) add(key, value string) {
+	if value != "" {
+		m.Extensions = append(m.Extensions, Extension{key, value})
+	}
+}
+
+// Format formats m in format f.
+func (m *Message) Format(f Format) string {
+	if f == LEEF {
+		return m.LEEF()
+	}
+	return m.CEF()
+}
+
+// CEF formats m as a CEF:0 message.
+func (m *Message) CEF() string {
+	var b strings.Builder
+	b.WriteString("CEF:0")
+	for _, field := range []string{Vendor, Product, DeviceVersion, m.SignatureID, m.Name, strconv.Itoa(m.Severity)} {
+		b.WriteByte('|')
+		b.WriteString(cefHeaderEscaper.Replace(field))
+	}
+	b.WriteByte('|')
+	sep := ""
+	if !m.Time.IsZero() {
+		b.WriteString("rt=" + strconv.FormatInt(m.Time.UnixMilli(), 10))
+		sep = " "
+	}
+	for _, ext := range m.Extensions {
+		b.WriteString(sep + ext.Key + "=" + cefValueEscaper.Replace(ext.Value))
+		sep = " "
+	}
+	return b.String()
+}
+
+// LEEF formats m as a LEEF:1.0 message with tab-separated attributes.
+func (m *Message) LEEF() string {
+	var b strings.Builder
+	b.WriteString("LEEF:1.0")
+	for _, field := range []string{Vendor, Product, DeviceVersion, m.SignatureID} {
+		b.WriteByte('|')
+		b.WriteString(leefHeaderEscaper.Replace(field))
+	}
+	b.WriteByte('|')
+	b.WriteString("sev=" + strconv.Itoa(m.Severity))
+	if !m.Time.IsZero() {
+		b.WriteString("\tdevTime=" + m.Time.UTC().Format("Jan 02 2006 15:04:05") + "\tdevTimeFormat=MMM dd yyyy HH:mm:ss")
+	}
+	b.WriteString("\tname=" + leefValueEscaper.Replace(m.Name))
+	for _, ext := range m.Extensions {
+		b.WriteString("\t" + ext.Key + "=" + leefValueEscaper.Replace(ext.Value))
+	}
+	return b.String()
+}
+
+var (
+	cefHeaderEscaper  = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefValueEscaper   = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+	leefHeaderEscaper = strings.NewReplacer(`|`, `\|`, "\n", " ", "\r", " ", "\t", " ")
+	leefValueEscaper  = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+)
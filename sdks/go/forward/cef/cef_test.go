@@ -0,0 +1,59 @@
+package cef
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestCEF(t *testing.T) {
+	m := FromFinding(aiptx.Finding{
+		ID:           1001,
+		ProjectID:    42,
+		Type:         "xss",
+		Value:        "https://example.com/search?q=a|b",
+		Description:  "Reflected in a=b\nline two",
+		Severity:     "high",
+		Tool:         "dalfox",
+		DiscoveredAt: time.Date(2024, 1, 15, 9, 31, 27, 0, time.UTC),
+	})
+
+	want := `CEF:0|AIPTX|AIPTX|1.0|finding:xss|xss finding: https://example.com/search?q=a\|b|8|` +
+		`rt=1705311087000 externalId=1001 cat=xss msg=Reflected in a\=b\nline two ` +
+		`request=https://example.com/search?q\=a|b dhost=example.com cs1Label=projectId cs1=42 cs2Label=tool cs2=dalfox`
+	if got := m.CEF(); got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestLEEF(t *testing.T) {
+	m := FromFinding(aiptx.Finding{ID: 7, Type: "sqli", Value: "id param", Description: "a\tb", Severity: "critical", Confidence: 0.85})
+
+	want := "LEEF:1.0|AIPTX|AIPTX|1.0|finding:sqli|sev=10\tname=sqli finding: id param\texternalId=7\tcat=sqli\tmsg=a b\tcs1Label=projectId\tcs1=0\tcfp1Label=confidence\tcfp1=0.85"
+	if got := m.Format(LEEF); got != want {
+		t.Errorf("Expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestFromEvent(t *testing.T) {
+	ev := aiptx.Event{
+		Type: aiptx.EventScanFailed,
+		Time: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Data: json.RawMessage(`{"id": "scan-1", "status": "error", "error": "target unreachable"}`),
+	}
+	m, err := FromEvent(ev)
+	if err != nil {
+		t.Fatalf("FromEvent failed: %v", err)
+	}
+	if m.SignatureID != aiptx.EventScanFailed || m.Severity != 5 || !strings.Contains(m.CEF(), "msg=target unreachable") {
+		t.Errorf("Expected failed scan message, got %s", m.CEF())
+	}
+
+	if _, err := FromEvent(aiptx.Event{Type: "project.created"}); !errors.Is(err, ErrUnsupportedEvent) {
+		t.Errorf("Expected ErrUnsupportedEvent, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package cef
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Facility is the syslog facility messages are sent with.
+type Facility int
+
+// Common syslog facilities.
+const (
+	FacilityUser     Facility = 1
+	FacilitySecurity Facility = 4
+	FacilityLocal0   Facility = 16
+	FacilityLocal4   Facility = 20
+)
+
+// Forwarder sends messages to a syslog receiver using RFC 5424 framing.
+// Over "tcp" and "tls", messages are octet-counted as in RFC 6587 and RFC
+// 5425. The connection is opened on the first send and reopened once if a
+// write fails. A Forwarder is safe for concurrent use.
+type Forwarder struct {
+	// Network is "udp", "tcp" or "tls".
+	Network string
+	// Addr is the host:port of the receiver.
+	Addr string
+	// Format defaults to CEF.
+	Format Format
+	// Facility defaults to FacilityLocal4. The kernel facility, 0, is
+	// reserved and cannot be used.
+	Facility Facility
+	// Hostname and AppName fill the syslog header. They default to the local
+	// hostname and "aiptx".
+	Hostname string
+	AppName  string
+	// TLSConfig is used when Network is "tls".
+	TLSConfig *tls.Config
+	// Timeout bounds dialing and each write. It defaults to 10 seconds.
+	Timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewForwarder returns a Forwarder sending CEF messages to addr over network.
+func NewForwarder(network, addr string) *Forwarder {
+	return &Forwarder{Network: network, Addr: addr}
+}
+
+// Send formats m and sends it to the receiver.
+func (f *Forwarder) Send(m *Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	frame := f.frame(m)
+	if f.conn != nil {
+		if err := f.write(frame); err == nil {
+			return nil
+		}
+		f.conn.Close()
+		f.conn = nil
+	}
+	if err := f.dial(); err != nil {
+		return err
+	}
+	if err := f.write(frame); err != nil {
+		f.conn.Close()
+		f.conn = nil
+		return fmt.Errorf("cef: send to %s: %w", f.Addr, err)
+	}
+	return nil
+}
+
+// SendEvent converts a finding or scan event and sends it. Events of other
+// types are ignored.
+func (f *Forwarder) SendEvent(ev aiptx.Event) error {
+	m, err := FromEvent(ev)
+	if errors.Is(err, ErrUnsupportedEvent) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return f.Send(m)
+}
+
+// Close closes the connection, if any.
+func (f *Forwarder) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn == nil {
+		return nil
+	}
+	err := f.conn.Close()
+	f.conn = nil
+	return err
+}
+
+func (f *Forwarder) timeout() time.Duration {
+	if f.Timeout > 0 {
+		return f.Timeout
+	}
+	return 10 * time.Second
+}
+
+func (f *Forwarder) facility() Facility {
+	if f.Facility > 0 {
+		return f.Facility
+	}
+	return FacilityLocal4
+}
+
+func (f *Forwarder) hostname() string {
+	if f.Hostname != "" {
+		return f.Hostname
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+func (f *Forwarder) appName() string {
+	if f.AppName != "" {
+		return f.AppName
+	}
+	return "aiptx"
+}
+
+func (f *Forwarder) dial() error {
+	dialer := &net.Dialer{Timeout: f.timeout()}
+	var err error
+	switch f.Network {
+	case "udp", "tcp":
+		f.conn, err = dialer.Dial(f.Network, f.Addr)
+	case "tls":
+		f.conn, err = tls.DialWithDialer(dialer, "tcp", f.Addr, f.TLSConfig)
+	default:
+		return fmt.Errorf("cef: unsupported network %q", f.Network)
+	}
+	if err != nil {
+		return fmt.Errorf("cef: dial %s: %w", f.Addr, err)
+	}
+	return nil
+}
+
+func (f *Forwarder) write(frame []byte) error {
+	f.conn.SetWriteDeadline(time.Now().Add(f.timeout()))
+	_, err := f.conn.Write(frame)
+	return err
+}
+
+// frame renders m as an RFC 5424 syslog message, octet-counted on stream
+// transports.
+func (f *Forwarder) frame(m *Message) []byte {
+	at := m.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	pri := int(f.facility())*8 + syslogSeverity(m.Severity)
+	msg := fmt.Sprintf("<%d>1 %s %s %s - %s - %s", pri,
+		at.UTC().Format(time.RFC3339Nano), nilValue(f.hostname()), nilValue(f.appName()),
+		nilValue(truncate(m.SignatureID, 32)), m.Format(f.Format))
+	if f.Network == "udp" {
+		return []byte(msg)
+	}
+	return []byte(strconv.Itoa(len(msg)) + " " + msg)
+}
+
+// syslogSeverity maps the 0-10 message severity to a syslog severity.
+func syslogSeverity(sev int) int {
+	switch {
+	case sev >= 10:
+		return 2 // critical
+	case sev >= 8:
+		return 3 // error
+	case sev >= 5:
+		return 4 // warning
+	case sev >= 3:
+		return 5 // notice
+	}
+	return 6 // informational
+}
+
+// nilValue returns s, or the syslog NILVALUE if s is empty. Header fields
+// may not contain spaces, so they are replaced.
+func nilValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if c <= ' ' || c > '~' {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package cef
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestForwarderUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Defaults also apply to Forwarders not made by NewForwarder.
+	fwd := &Forwarder{Network: "udp", Addr: conn.LocalAddr().String(), Hostname: "scanner 1"}
+	defer fwd.Close()
+	m := &Message{SignatureID: "finding:xss", Name: "xss", Severity: 8, Time: time.Date(2024, 1, 15, 9, 31, 27, 0, time.UTC)}
+	if err := fwd.Send(m); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	buf := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	want := "<163>1 2024-01-15T09:31:27Z scanner_1 aiptx - finding:xss - CEF:0|AIPTX|AIPTX|1.0|finding:xss|xss|8|rt=1705311087000"
+	if got := string(buf[:n]); got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestForwarderTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	frames := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			size, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			frames <- string(buf)
+		}
+	}()
+
+	fwd := NewForwarder("tcp", ln.Addr().String())
+	fwd.Format = LEEF
+	defer fwd.Close()
+	for _, id := range []int64{1, 2} {
+		if err := fwd.SendEvent(findingEvent(id)); err != nil {
+			t.Fatalf("SendEvent failed: %v", err)
+		}
+	}
+	if err := fwd.SendEvent(aiptx.Event{Type: "project.created"}); err != nil {
+		t.Errorf("Expected unsupported events to be ignored, got %v", err)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		select {
+		case frame := <-frames:
+			if !strings.Contains(frame, "LEEF:1.0|AIPTX|AIPTX|1.0|finding:xss|sev=8") || !strings.Contains(frame, "externalId="+id) {
+				t.Errorf("Expected LEEF frame for finding %s, got %q", id, frame)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected frame for finding %s", id)
+		}
+	}
+}
+
+func findingEvent(id int64) aiptx.Event {
+	return aiptx.Event{
+		Type: aiptx.EventFindingCreated,
+		Data: []byte(`{"id": ` + strconv.FormatInt(id, 10) + `, "type": "xss", "value": "q", "severity": "high"}`),
+	}
+}
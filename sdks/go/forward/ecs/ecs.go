@@ -0,0 +1,266 @@
+// Package ecs converts AIPTX findings and scan events into Elastic Common
+// Schema (ECS) documents, so SOC teams can correlate pentest activity with
+// their detections in Elasticsearch or OpenSearch.
+//
+// Documents can be written anywhere JSON is accepted, or sent with the bulk
+// Indexer:
+//
+//	idx := ecs.NewIndexer("https://es.internal:9200", "aiptx-findings")
+//	for stream.Next() {
+//	    doc, err := ecs.FromEvent(stream.Event())
+//	    ...
+//	    idx.Add(ctx, doc)
+//	}
+//	err := idx.Flush(ctx)
+package ecs
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Version is the ECS version documents conform to.
+const Version = "8.11.0"
+
+// Document is an ECS document. Fields follow the ECS field reference;
+// AIPTX-specific data is kept under the aiptx field set.
+type Document struct {
+	// ID is used as the document _id when indexing, so that re-exporting
+	// the same finding or event does not duplicate it.
+	ID string `json:"-"`
+
+	Timestamp     time.Time         `json:"@timestamp"`
+	ECS           ECSField          `json:"ecs"`
+	Event         Event             `json:"event"`
+	Message       string            `json:"message,omitempty"`
+	Vulnerability *Vulnerability    `json:"vulnerability,omitempty"`
+	URL           *URL              `json:"url,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	AIPTX         AIPTX             `json:"aiptx"`
+}
+
+// ECSField is the ecs field set.
+type ECSField struct {
+	Version string `json:"version"`
+}
+
+// Event is the event field set.
+type Event struct {
+	ID       string     `json:"id,omitempty"`
+	Kind     string     `json:"kind"`
+	Category []string   `json:"category"`
+	Type     []string   `json:"type"`
+	Action   string     `json:"action,omitempty"`
+	Outcome  string     `json:"outcome,omitempty"`
+	Module   string     `json:"module"`
+	Dataset  string     `json:"dataset"`
+	Severity int        `json:"severity,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+	Start    *time.Time `json:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty"`
+}
+
+// Vulnerability is the vulnerability field set.
+type Vulnerability struct {
+	ID             string   `json:"id,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Severity       string   `json:"severity,omitempty"`
+	Category       []string `json:"category,omitempty"`
+	Classification string   `json:"classification,omitempty"`
+	Scanner        Scanner  `json:"scanner"`
+}
+
+// Scanner is the vulnerability.scanner field set.
+type Scanner struct {
+	Vendor string `json:"vendor"`
+}
+
+// URL is the url field set, filled when a finding's value is a URL.
+type URL struct {
+	Full   string `json:"full"`
+	Domain string `json:"domain,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// AIPTX holds the fields ECS has no place for.
+type AIPTX struct {
+	ProjectID      int64  `json:"project_id,omitempty"`
+	SessionID      int64  `json:"session_id,omitempty"`
+	FindingID      int64  `json:"finding_id,omitempty"`
+	ScanID         string `json:"scan_id,omitempty"`
+	Tool           string `json:"tool,omitempty"`
+	Phase          string `json:"phase,omitempty"`
+	KillChainStage string `json:"kill_chain_stage,omitempty"`
+	Verified       bool   `json:"verified,omitempty"`
+	FalsePositive  bool   `json:"false_positive,omitempty"`
+	// Confidence is the model's confidence in an AI-reported finding.
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// severityScores maps severities to event.severity, using the risk scores
+// of Elastic detection rules.
+var severityScores = map[aiptx.Severity]int{
+	aiptx.SeverityInfo:     1,
+	aiptx.SeverityLow:      21,
+	aiptx.SeverityMedium:   47,
+	aiptx.SeverityHigh:     73,
+	aiptx.SeverityCritical: 99,
+}
+
+// FromFinding converts a finding into an ECS vulnerability alert.
+func FromFinding(f aiptx.Finding) *Document {
+	doc := &Document{
+		ID:        "aiptx-finding-" + strconv.FormatInt(f.ID, 10),
+		Timestamp: timestamp(f.DiscoveredAt),
+		ECS:       ECSField{Version: Version},
+		Event: Event{
+			Kind:     "alert",
+			Category: []string{"vulnerability"},
+			Type:     []string{"info"},
+			Module:   "aiptx",
+			Dataset:  "aiptx.finding",
+			Severity: severityScores[f.Severity],
+			Created:  optionalTime(f.DiscoveredAt),
+		},
+		Message: findingMessage(f),
+		Vulnerability: &Vulnerability{
+			Description: f.Description,
+			Severity:    titleCase(string(f.Severity)),
+			Category:    []string{f.Type},
+			Scanner:     Scanner{Vendor: "AIPTX"},
+		},
+		Labels: map[string]string{"aiptx_project_id": strconv.FormatInt(f.ProjectID, 10)},
+		AIPTX: AIPTX{
+			ProjectID:      f.ProjectID,
+			SessionID:      f.SessionID,
+			FindingID:      f.ID,
+			Tool:           f.Tool,
+			Phase:          string(f.Phase),
+			KillChainStage: string(f.KillChainStage),
+			Verified:       f.Verified,
+			FalsePositive:  f.FalsePositive,
+			Confidence:     f.Confidence,
+		},
+	}
+	if upper := strings.ToUpper(f.Value); strings.HasPrefix(upper, "CVE-") {
+		doc.Vulnerability.ID = upper
+		doc.Vulnerability.Classification = "CVE"
+	}
+	if u, err := url.Parse(f.Value); err == nil && u.Scheme != "" && u.Host != "" {
+		doc.URL = &URL{Full: f.Value, Domain: u.Hostname(), Path: u.Path}
+	}
+	if f.Verified {
+		doc.Tags = append(doc.Tags, "verified")
+	}
+	if f.FalsePositive {
+		doc.Tags = append(doc.Tags, "false_positive")
+	}
+	return doc
+}
+
+// FromScan converts a scan status into an ECS event describing scan activity.
+// action is the event type, such as aiptx.EventScanStarted.
+func FromScan(s aiptx.ScanStatus, action string, at time.Time) *Document {
+	id := "aiptx-scan-" + s.ID + "-" + action
+	if action == aiptx.EventScanPhaseChanged {
+		// A scan changes phase several times.
+		id += "-" + string(s.Phase)
+	}
+	doc := &Document{
+		ID:        id,
+		Timestamp: timestamp(at),
+		ECS:       ECSField{Version: Version},
+		Event: Event{
+			Kind:     "event",
+			Category: []string{"network"},
+			Type:     []string{"info"},
+			Action:   action,
+			Module:   "aiptx",
+			Dataset:  "aiptx.scan",
+			Start:    optionalTime(s.StartedAt),
+			End:      optionalTime(s.CompletedAt),
+		},
+		Message: fmt.Sprintf("AIPTX scan %s %s", s.ID, strings.TrimPrefix(action, "scan.")),
+		AIPTX:   AIPTX{ScanID: s.ID, Phase: string(s.Phase)},
+	}
+	switch action {
+	case aiptx.EventScanStarted:
+		doc.Event.Type = []string{"start"}
+	case aiptx.EventScanCompleted:
+		doc.Event.Type = []string{"end"}
+		doc.Event.Outcome = "success"
+	case aiptx.EventScanFailed:
+		doc.Event.Type = []string{"end"}
+		doc.Event.Outcome = "failure"
+		doc.Message += ": " + s.Error
+	}
+	return doc
+}
+
+// FromEvent converts a finding or scan event into an ECS document. Other
+// event types return an error. Documents are identified by the event ID,
+// so each update of a finding is a document of its own.
+func FromEvent(ev aiptx.Event) (*Document, error) {
+	switch ev.Type {
+	case aiptx.EventFindingCreated, aiptx.EventFindingUpdated:
+		var f aiptx.Finding
+		if err := ev.Decode(&f); err != nil {
+			return nil, err
+		}
+		doc := FromFinding(f)
+		if ev.ID != "" {
+			doc.ID = "aiptx-event-" + ev.ID
+			doc.Event.ID = ev.ID
+		}
+		return doc, nil
+	case aiptx.EventScanStarted, aiptx.EventScanPhaseChanged, aiptx.EventScanCompleted, aiptx.EventScanFailed:
+		var s aiptx.ScanStatus
+		if err := ev.Decode(&s); err != nil {
+			return nil, err
+		}
+		doc := FromScan(s, ev.Type, ev.Time)
+		if ev.ID != "" {
+			doc.ID = "aiptx-event-" + ev.ID
+			doc.Event.ID = ev.ID
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("ecs: unsupported event type %q", ev.Type)
+}
+
+func findingMessage(f aiptx.Finding) string {
+	msg := fmt.Sprintf("%s %s finding: %s", titleCase(string(f.Severity)), f.Type, f.Value)
+	if f.Tool != "" {
+		msg += " (" + f.Tool + ")"
+	}
+	return msg
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// timestamp returns t, or the current time if t is zero, since every ECS
+// document needs a @timestamp.
+func timestamp(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now().UTC()
+	}
+	return t
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
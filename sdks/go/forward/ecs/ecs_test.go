@@ -0,0 +1,85 @@
+package ecs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestFromFinding(t *testing.T) {
+	found := time.Date(2024, 1, 15, 9, 31, 27, 0, time.UTC)
+	doc := FromFinding(aiptx.Finding{
+		ID:           1001,
+		ProjectID:    42,
+		Type:         "vulnerability",
+		Value:        "CVE-2021-44228",
+		Severity:     "critical",
+		Tool:         "nuclei",
+		Verified:     true,
+		DiscoveredAt: found,
+	})
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+
+	if fields["@timestamp"] != "2024-01-15T09:31:27Z" {
+		t.Errorf("Expected @timestamp, got %v", fields["@timestamp"])
+	}
+	event := fields["event"].(map[string]interface{})
+	if event["kind"] != "alert" || event["severity"] != 99.0 || event["dataset"] != "aiptx.finding" {
+		t.Errorf("Expected critical alert, got %v", event)
+	}
+	if _, ok := event["start"]; ok {
+		t.Errorf("Expected zero times to be omitted, got %v", event)
+	}
+	vuln := fields["vulnerability"].(map[string]interface{})
+	if vuln["id"] != "CVE-2021-44228" || vuln["severity"] != "Critical" {
+		t.Errorf("Expected CVE vulnerability, got %v", vuln)
+	}
+	if doc.ID != "aiptx-finding-1001" || doc.URL != nil {
+		t.Errorf("Expected ID and no URL, got %q and %+v", doc.ID, doc.URL)
+	}
+
+	doc = FromFinding(aiptx.Finding{ID: 1, Value: "https://example.com/admin", Severity: "high", Confidence: 0.62})
+	if doc.URL == nil || doc.URL.Domain != "example.com" || doc.URL.Path != "/admin" {
+		t.Errorf("Expected URL fields, got %+v", doc.URL)
+	}
+	if doc.AIPTX.Confidence != 0.62 {
+		t.Errorf("Expected confidence 0.62, got %v", doc.AIPTX.Confidence)
+	}
+}
+
+func TestFromEvent(t *testing.T) {
+	ev := aiptx.Event{
+		ID:   "e-9",
+		Type: aiptx.EventScanFailed,
+		Time: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Data: json.RawMessage(`{"id": "scan-1", "status": "error", "error": "target unreachable"}`),
+	}
+	doc, err := FromEvent(ev)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.ID != "aiptx-event-e-9" || doc.Event.Outcome != "failure" || doc.Event.Type[0] != "end" {
+		t.Errorf("Expected failed scan end event, got %+v", doc.Event)
+	}
+	if doc.Message != "AIPTX scan scan-1 failed: target unreachable" {
+		t.Errorf("Unexpected message %q", doc.Message)
+	}
+
+	recon := FromScan(aiptx.ScanStatus{ID: "scan-1", Phase: aiptx.PhaseRecon}, aiptx.EventScanPhaseChanged, ev.Time)
+	scan := FromScan(aiptx.ScanStatus{ID: "scan-1", Phase: aiptx.PhaseScan}, aiptx.EventScanPhaseChanged, ev.Time)
+	if recon.ID == scan.ID {
+		t.Errorf("Expected distinct IDs for phase changes, got %q", recon.ID)
+	}
+
+	if _, err := FromEvent(aiptx.Event{Type: aiptx.EventSessionCompleted}); err == nil {
+		t.Errorf("Expected error for unsupported event type")
+	}
+}
@@ -0,0 +1,178 @@
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aiptx/aiptx-go/internal/jsonhttp"
+)
+
+// DefaultBatchSize is the number of documents an Indexer buffers before it
+// sends them in one bulk request.
+const DefaultBatchSize = 500
+
+// Indexer sends documents to the Elasticsearch or OpenSearch bulk API in
+// batches. Documents are created with their ID, as data streams require, so
+// sending a document again leaves the indexed one in place instead of
+// duplicating it. An Indexer is safe for concurrent use.
+//
+// If a bulk request fails, its documents stay buffered and are sent again
+// with the next batch or Flush. Documents the cluster rejects are reported
+// in a *BulkError and dropped.
+type Indexer struct {
+	// URL is the base URL of the cluster, e.g. https://es.internal:9200.
+	URL string
+	// Index is the target index or data stream.
+	Index string
+	// BatchSize defaults to DefaultBatchSize.
+	BatchSize int
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+
+	// APIKey, if set, is sent as an ApiKey authorization header. Otherwise
+	// Username and Password are used for basic authentication, if set.
+	APIKey   string
+	Username string
+	Password string
+
+	mu      sync.Mutex
+	pending []*Document
+}
+
+// NewIndexer creates an indexer for index on the cluster at url.
+func NewIndexer(url, index string) *Indexer {
+	return &Indexer{
+		URL:        strings.TrimSuffix(url, "/"),
+		Index:      index,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// BulkError reports documents the cluster rejected.
+type BulkError struct {
+	// Failed maps document IDs to the reason they were rejected.
+	Failed map[string]string
+}
+
+func (e *BulkError) Error() string {
+	ids := make([]string, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return "ecs: documents rejected"
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("ecs: %d documents rejected, first %s: %s", len(ids), ids[0], e.Failed[ids[0]])
+}
+
+// Add buffers doc, sending the buffered documents once BatchSize is reached.
+func (i *Indexer) Add(ctx context.Context, doc *Document) error {
+	i.mu.Lock()
+	i.pending = append(i.pending, doc)
+	if len(i.pending) < i.batchSize() {
+		i.mu.Unlock()
+		return nil
+	}
+	batch := i.pending
+	i.pending = nil
+	i.mu.Unlock()
+	return i.sendBatch(ctx, batch)
+}
+
+// Flush sends all buffered documents.
+func (i *Indexer) Flush(ctx context.Context) error {
+	i.mu.Lock()
+	batch := i.pending
+	i.pending = nil
+	i.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	return i.sendBatch(ctx, batch)
+}
+
+// sendBatch sends batch, buffering it again ahead of the documents added
+// meanwhile if the request fails.
+func (i *Indexer) sendBatch(ctx context.Context, batch []*Document) error {
+	err := i.send(ctx, batch)
+	var bulkErr *BulkError
+	if err != nil && !errors.As(err, &bulkErr) {
+		i.mu.Lock()
+		i.pending = append(batch, i.pending...)
+		i.mu.Unlock()
+	}
+	return err
+}
+
+func (i *Indexer) batchSize() int {
+	if i.BatchSize > 0 {
+		return i.BatchSize
+	}
+	return DefaultBatchSize
+}
+
+// send indexes batch with one bulk request.
+func (i *Indexer) send(ctx context.Context, batch []*Document) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range batch {
+		action := map[string]map[string]string{"create": {"_index": i.Index}}
+		if doc.ID != "" {
+			action["create"]["_id"] = doc.ID
+		}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", i.URL+"/_bulk", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if i.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+i.APIKey)
+	} else if i.Username != "" {
+		req.SetBasicAuth(i.Username, i.Password)
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := jsonhttp.Send(i.HTTPClient, req, &result); err != nil {
+		return fmt.Errorf("ecs: bulk request: %w", err)
+	}
+	bulkErr := &BulkError{Failed: map[string]string{}}
+	for _, item := range result.Items {
+		for _, op := range item {
+			// A conflict means the document was indexed before.
+			if op.Error != nil && op.Status != http.StatusConflict {
+				bulkErr.Failed[op.ID] = op.Error.Type + ": " + op.Error.Reason
+			}
+		}
+	}
+	if len(bulkErr.Failed) == 0 {
+		return nil
+	}
+	return bulkErr
+}
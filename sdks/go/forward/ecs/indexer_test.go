@@ -0,0 +1,80 @@
+package ecs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestIndexer(t *testing.T) {
+	var requests [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("Unexpected request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("Authorization") != "ApiKey secret" {
+			t.Errorf("Expected API key, got %q", r.Header.Get("Authorization"))
+		}
+		var lines []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		requests = append(requests, lines)
+		switch len(requests) {
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case 3:
+			w.Write([]byte(`{"errors": true, "items": [
+				{"create": {"_id": "aiptx-finding-3", "status": 400, "error": {"type": "mapper_parsing_exception", "reason": "bad field"}}},
+				{"create": {"_id": "aiptx-finding-4", "status": 409, "error": {"type": "version_conflict_engine_exception", "reason": "exists"}}}
+			]}`))
+			return
+		}
+		w.Write([]byte(`{"errors": false, "items": []}`))
+	}))
+	defer server.Close()
+
+	idx := NewIndexer(server.URL+"/", "aiptx-findings")
+	idx.BatchSize = 2
+	idx.APIKey = "secret"
+	ctx := context.Background()
+
+	for id := int64(1); id <= 3; id++ {
+		if err := idx.Add(ctx, FromFinding(aiptx.Finding{ID: id, Severity: "low"})); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(requests) != 1 || len(requests[0]) != 4 {
+		t.Fatalf("Expected one batch of two documents, got %v", requests)
+	}
+	var action map[string]map[string]string
+	json.Unmarshal([]byte(requests[0][0]), &action)
+	if action["create"]["_index"] != "aiptx-findings" || action["create"]["_id"] != "aiptx-finding-1" {
+		t.Errorf("Expected create action with ID, got %v", action)
+	}
+
+	// A failed request keeps its documents buffered.
+	if err := idx.Flush(ctx); err == nil {
+		t.Errorf("Expected error for failed bulk request")
+	}
+	// Conflicting documents already exist and are not errors.
+	err := idx.Add(ctx, FromFinding(aiptx.Finding{ID: 4, Severity: "low"}))
+	if len(requests) != 3 || len(requests[2]) != 4 {
+		t.Fatalf("Expected the failed document to be sent again, got %v", requests)
+	}
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Failed) != 1 || bulkErr.Failed["aiptx-finding-3"] == "" {
+		t.Errorf("Expected rejected document, got %v", err)
+	}
+	if err := idx.Flush(ctx); err != nil || len(requests) != 3 {
+		t.Errorf("Expected nothing left to flush, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+//go:build ignore
+
+// gen_unknown generates unknown_gen.go: the UnmarshalJSON and Unknown
+// methods of every struct type in the package that has an unknown
+// *rawFields field.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by gen_unknown.go; DO NOT EDIT.
+
+package aiptx
+
+import "encoding/json"
+{{range .}}
+// UnmarshalJSON implements json.Unmarshaler.
+func ({{.Recv}} *{{.Name}}) UnmarshalJSON(data []byte) error {
+	type plain {{.Name}}
+	unknown, err := unmarshalKnown(data, (*plain)({{.Recv}}))
+	{{.Recv}}.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func ({{.Recv}} {{.Name}}) Unknown() map[string]json.RawMessage {
+	return {{.Recv}}.unknown.get()
+}
+{{end}}`))
+
+type holder struct {
+	Name string
+	Recv string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var holders []holder
+	for _, file := range pkgs["aiptx"].Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st, ok := spec.Type.(*ast.StructType); ok && hasUnknown(st) {
+				name := spec.Name.Name
+				holders = append(holders, holder{Name: name, Recv: string(unicode.ToLower(rune(name[0])))})
+			}
+			return false
+		})
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i].Name < holders[j].Name })
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, holders); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("unknown_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// hasUnknown reports whether st has the field "unknown *rawFields".
+func hasUnknown(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		star, ok := f.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		if id, ok := star.X.(*ast.Ident); !ok || id.Name != "rawFields" {
+			continue
+		}
+		for _, name := range f.Names {
+			if name.Name == "unknown" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// Package graphql provides a client for the AIPTX GraphQL endpoint.
+//
+// The GraphQL endpoint resolves related resources in a single round trip,
+// which avoids the chain of REST calls otherwise needed to walk from a
+// project to its sessions, findings and evidence:
+//
+//	gql := graphql.NewClient(client)
+//	project, err := gql.Project(graphql.NewProjectQuery(42).
+//	    WithSessions().
+//	    WithFindings(&aiptx.FindingsFilter{MinSeverity: aiptx.SeverityHigh}).
+//	    WithEvidence())
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// DefaultPath is the path of the GraphQL endpoint on the AIPTX server.
+const DefaultPath = "/graphql"
+
+// Client executes GraphQL requests using an AIPTX API client.
+type Client struct {
+	API  *aiptx.Client
+	Path string
+}
+
+// NewClient creates a GraphQL client that shares the transport and
+// credentials of api.
+func NewClient(api *aiptx.Client) *Client {
+	return &Client{API: api, Path: DefaultPath}
+}
+
+// Request is a GraphQL request document with its variables.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Location is a position in a GraphQL request document.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is a single error reported by the GraphQL endpoint.
+type Error struct {
+	Message   string        `json:"message"`
+	Path      []interface{} `json:"path,omitempty"`
+	Locations []Location    `json:"locations,omitempty"`
+}
+
+// Errors is the list of errors in a GraphQL response. It is returned by
+// Execute when the server reports any errors, alongside partially decoded
+// data.
+type Errors []Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Message
+	}
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors Errors          `json:"errors,omitempty"`
+}
+
+// Execute sends req and decodes the response data into out.
+func (c *Client) Execute(req *Request, out interface{}) error {
+	var resp response
+	if err := c.API.Do("POST", c.Path, req, &resp); err != nil {
+		return err
+	}
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("graphql: decoding data: %w", err)
+		}
+	}
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+	return nil
+}
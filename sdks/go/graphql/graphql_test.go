@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestProjectQuery(t *testing.T) {
+	var got Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			t.Errorf("Expected /graphql, got %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"data": {"project": {
+			"id": 42, "name": "acme",
+			"sessions": [{"id": 1, "project_id": 42}],
+			"findings": [{"id": 7, "severity": "high", "evidence": [{"id": 3, "sha256": "abc"}]}]
+		}}}`))
+	}))
+	defer server.Close()
+
+	gql := NewClient(aiptx.NewClient(server.URL))
+	q := NewProjectQuery(42).WithSessions().WithFindings(&aiptx.FindingsFilter{MinSeverity: aiptx.SeverityHigh}).WithEvidence()
+	project, err := gql.Project(q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(got.Query, "findings(filter: $findings)") || !strings.Contains(got.Query, "evidence {") {
+		t.Errorf("Unexpected query document:\n%s", got.Query)
+	}
+	if strings.Contains(got.Query, "rawOutput") {
+		t.Errorf("Expected raw output to be omitted by default")
+	}
+	if project.Name != "acme" || len(project.Sessions) != 1 {
+		t.Errorf("Unexpected project: %+v", project)
+	}
+	if len(project.Findings) != 1 || project.Findings[0].Evidence[0].SHA256 != "abc" {
+		t.Errorf("Unexpected findings: %+v", project.Findings)
+	}
+}
+
+func TestExecuteErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"project": null}, "errors": [{"message": "project not found"}]}`))
+	}))
+	defer server.Close()
+
+	gql := NewClient(aiptx.NewClient(server.URL))
+	_, err := gql.Project(NewProjectQuery(1))
+
+	var gqlErrs Errors
+	if !errors.As(err, &gqlErrs) || gqlErrs[0].Message != "project not found" {
+		t.Errorf("Expected GraphQL errors, got %v", err)
+	}
+}
+
+func TestFindingsFilterFields(t *testing.T) {
+	since := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	filter := &aiptx.FindingsFilter{ListOptions: aiptx.ListOptions{
+		CreatedAfter: since,
+		Fields:       []string{"id", "severity", "discovered_at", "cvss_score"},
+	}}
+	req := NewProjectQuery(42).WithFindings(filter).Request()
+	for _, want := range []string{"      id\n", "      severity\n", "      discovered_at: discoveredAt\n", "      cvss_score: cvssScore\n"} {
+		if !strings.Contains(req.Query, want) {
+			t.Errorf("Expected selection %q in:\n%s", want, req.Query)
+		}
+	}
+	if _, findings, _ := strings.Cut(req.Query, "findings("); strings.Contains(findings, "description") {
+		t.Errorf("Expected only the requested fields, got:\n%s", req.Query)
+	}
+	in := req.Variables["findings"].(map[string]interface{})
+	if in["createdAfter"] != since {
+		t.Errorf("Expected createdAfter %v, got %v", since, in["createdAfter"])
+	}
+
+	filter = &aiptx.FindingsFilter{ListOptions: aiptx.ListOptions{Fields: []string{"-extra_data", "-remediation"}}}
+	req = NewProjectQuery(42).WithFindings(filter).WithRawOutput().Request()
+	if strings.Contains(req.Query, "extraData") || strings.Contains(req.Query, "remediation {") {
+		t.Errorf("Expected excluded fields to be omitted, got:\n%s", req.Query)
+	}
+	if !strings.Contains(req.Query, "raw_output: rawOutput") || !strings.Contains(req.Query, "description") {
+		t.Errorf("Expected the other fields to be selected, got:\n%s", req.Query)
+	}
+}
@@ -0,0 +1,319 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Field selections. Fields are aliased to the REST API's snake_case names so
+// results decode directly into the aiptx types.
+var (
+	projectFields = []string{
+		"id", "name", "target", "description", "scope", "tags",
+		"created_at: createdAt", "updated_at: updatedAt",
+	}
+	sessionFields = []string{
+		"id", "project_id: projectId", "name", "phase", "status", "iteration",
+		"max_iterations: maxIterations", "created_at: createdAt",
+		"started_at: startedAt", "completed_at: completedAt",
+	}
+	findingFields = []string{
+		"id", "project_id: projectId", "session_id: sessionId", "type", "value",
+		"description", "severity", "phase", "tool", "extra_data: extraData",
+		"verified", "false_positive: falsePositive", "discovered_at: discoveredAt",
+		"kill_chain_stage: killChainStage", "confidence", "references { url title source }",
+		"remediation { owner due_date: dueDate status notes updated_at: updatedAt }",
+	}
+	rawOutputField = "raw_output: rawOutput"
+	evidenceFields = []string{
+		"id", "finding_id: findingId", "kind", "name", "content_type: contentType",
+		"size", "sha256", "created_at: createdAt",
+	}
+)
+
+// FindingResult is a finding together with its evidence.
+type FindingResult struct {
+	aiptx.Finding
+	Evidence []aiptx.Evidence `json:"evidence,omitempty"`
+}
+
+// ProjectResult is a project together with the related resources requested
+// by a ProjectQuery.
+type ProjectResult struct {
+	aiptx.Project
+	Sessions []aiptx.Session `json:"sessions,omitempty"`
+	Findings []FindingResult `json:"findings,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is needed because the
+// embedded Finding has its own UnmarshalJSON, which would otherwise be
+// promoted and skip Evidence.
+func (r *FindingResult) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedded(data, &r.Finding, map[string]interface{}{
+		"evidence": &r.Evidence,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, like FindingResult.UnmarshalJSON.
+func (r *ProjectResult) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedded(data, &r.Project, map[string]interface{}{
+		"sessions": &r.Sessions,
+		"findings": &r.Findings,
+	})
+}
+
+// unmarshalEmbedded decodes the given related fields of the JSON object in
+// data, and the remaining fields into base, so they are not reported as
+// unknown to it.
+func unmarshalEmbedded(data []byte, base interface{}, related map[string]interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name, v := range related {
+		if raw, ok := fields[name]; ok {
+			if err := json.Unmarshal(raw, v); err != nil {
+				return err
+			}
+			delete(fields, name)
+		}
+	}
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(rest, base)
+}
+
+// ProjectQuery builds a query for a project and its related resources.
+type ProjectQuery struct {
+	id        int64
+	sessions  bool
+	findings  *aiptx.FindingsFilter
+	evidence  bool
+	rawOutput bool
+}
+
+// NewProjectQuery starts a query for the project with the given ID.
+func NewProjectQuery(id int64) *ProjectQuery {
+	return &ProjectQuery{id: id}
+}
+
+// WithSessions includes the project's sessions.
+func (q *ProjectQuery) WithSessions() *ProjectQuery {
+	q.sessions = true
+	return q
+}
+
+// WithFindings includes the project's findings matching filter, which may be
+// nil to include all findings. The filter's Fields select the fields of each
+// finding as they do for ListFindings; its Limit and Cursor are ignored, as
+// all matching findings are included.
+func (q *ProjectQuery) WithFindings(filter *aiptx.FindingsFilter) *ProjectQuery {
+	if filter == nil {
+		filter = &aiptx.FindingsFilter{}
+	}
+	q.findings = filter
+	return q
+}
+
+// WithEvidence includes the evidence of each finding. It implies
+// WithFindings(nil) if no findings were requested.
+func (q *ProjectQuery) WithEvidence() *ProjectQuery {
+	if q.findings == nil {
+		q.findings = &aiptx.FindingsFilter{}
+	}
+	q.evidence = true
+	return q
+}
+
+// WithRawOutput includes the raw tool output of each finding, which is
+// omitted by default because of its size.
+func (q *ProjectQuery) WithRawOutput() *ProjectQuery {
+	q.rawOutput = true
+	return q
+}
+
+// Request renders the query as a GraphQL request.
+func (q *ProjectQuery) Request() *Request {
+	vars := map[string]interface{}{"id": q.id}
+
+	var b strings.Builder
+	b.WriteString("query Project($id: ID!")
+	if q.findings != nil {
+		b.WriteString(", $findings: FindingsFilterInput")
+		vars["findings"] = findingsInput(q.findings)
+	}
+	b.WriteString(") {\n  project(id: $id) {\n")
+	writeFields(&b, "    ", projectFields)
+	if q.sessions {
+		b.WriteString("    sessions {\n")
+		writeFields(&b, "      ", sessionFields)
+		b.WriteString("    }\n")
+	}
+	if q.findings != nil {
+		b.WriteString("    findings(filter: $findings) {\n")
+		writeFields(&b, "      ", findingSelection(q.findings.Fields, q.rawOutput))
+		if q.evidence {
+			b.WriteString("      evidence {\n")
+			writeFields(&b, "        ", evidenceFields)
+			b.WriteString("      }\n")
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("  }\n}\n")
+
+	return &Request{Query: b.String(), OperationName: "Project", Variables: vars}
+}
+
+// Project executes q and returns the project with its related resources.
+func (c *Client) Project(q *ProjectQuery) (*ProjectResult, error) {
+	var data struct {
+		Project *ProjectResult `json:"project"`
+	}
+	if err := c.Execute(q.Request(), &data); err != nil {
+		return data.Project, err
+	}
+	return data.Project, nil
+}
+
+func writeFields(b *strings.Builder, indent string, fields []string) {
+	for _, f := range fields {
+		b.WriteString(indent)
+		b.WriteString(f)
+		b.WriteString("\n")
+	}
+}
+
+// findingSelection returns the finding fields to select. fields include and
+// exclude fields by their REST names, as ListOptions.Fields does.
+func findingSelection(fields []string, rawOutput bool) []string {
+	selected := findingFields
+	if rawOutput {
+		selected = append(selected[:len(selected):len(selected)], rawOutputField)
+	}
+	exclude := map[string]bool{}
+	var include []string
+	for _, f := range fields {
+		if name, ok := strings.CutPrefix(f, "-"); ok {
+			exclude[name] = true
+		} else {
+			include = append(include, f)
+		}
+	}
+	if len(include) > 0 {
+		selected = nil
+		for _, name := range include {
+			selected = append(selected, findingField(name))
+		}
+	}
+	var out []string
+	for _, f := range selected {
+		if !exclude[selectionName(f)] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// findingField returns the selection of the finding field with the given
+// REST name. Fields unknown to the SDK are selected by their camelCase name.
+func findingField(name string) string {
+	for _, f := range append(findingFields[:len(findingFields):len(findingFields)], rawOutputField) {
+		if selectionName(f) == name {
+			return f
+		}
+	}
+	parts := strings.Split(name, "_")
+	if len(parts) == 1 {
+		return name
+	}
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return name + ": " + strings.Join(parts, "")
+}
+
+// selectionName returns the REST name of a field selection, which is its
+// alias if it has one.
+func selectionName(f string) string {
+	name, _, _ := strings.Cut(f, " ")
+	return strings.TrimSuffix(name, ":")
+}
+
+// findingsInput converts a REST findings filter into the GraphQL input type.
+func findingsInput(f *aiptx.FindingsFilter) map[string]interface{} {
+	in := map[string]interface{}{}
+	if !f.CreatedAfter.IsZero() {
+		in["createdAfter"] = f.CreatedAfter
+	}
+	if f.OwnerTag != "" {
+		in["ownerTag"] = f.OwnerTag
+	}
+	if f.Severity != "" {
+		in["severity"] = f.Severity
+	}
+	if f.MinSeverity != "" {
+		in["minSeverity"] = f.MinSeverity
+	}
+	if len(f.Severities) > 0 {
+		in["severities"] = f.Severities
+	}
+	if f.Type != "" {
+		in["type"] = f.Type
+	}
+	if len(f.Types) > 0 {
+		in["types"] = f.Types
+	}
+	if f.Tool != "" {
+		in["tool"] = f.Tool
+	}
+	if len(f.KillChainStages) > 0 {
+		in["killChainStages"] = f.KillChainStages
+	}
+	if f.Owner != "" {
+		in["owner"] = f.Owner
+	}
+	if len(f.RemediationStatuses) > 0 {
+		in["remediationStatuses"] = f.RemediationStatuses
+	}
+	if !f.DueBefore.IsZero() {
+		in["dueBefore"] = f.DueBefore
+	}
+	if f.Overdue {
+		in["overdue"] = true
+	}
+	if f.Verified != nil {
+		in["verified"] = *f.Verified
+	}
+	if f.FalsePositive != nil {
+		in["falsePositive"] = *f.FalsePositive
+	}
+	if f.MinConfidence > 0 {
+		in["minConfidence"] = f.MinConfidence
+	}
+	if !f.DiscoveredAfter.IsZero() {
+		in["discoveredAfter"] = f.DiscoveredAfter
+	}
+	if !f.DiscoveredBefore.IsZero() {
+		in["discoveredBefore"] = f.DiscoveredBefore
+	}
+	if f.DiscoveredWithin > 0 {
+		in["discoveredWithin"] = int64(f.DiscoveredWithin / time.Second)
+	}
+	if f.NewSinceBaseline {
+		in["newSinceBaseline"] = true
+	}
+	if f.SortBy != "" {
+		in["sortBy"] = f.SortBy
+	}
+	if f.SortOrder != "" {
+		in["sortOrder"] = f.SortOrder
+	}
+	return in
+}
@@ -0,0 +1,64 @@
+package aiptx
+
+import (
+	"net/http"
+	"time"
+)
+
+// =============================================================================
+// Hooks
+// =============================================================================
+
+// Hooks are optional callbacks invoked around API requests, for cross-cutting
+// concerns such as audit logging and metrics. Any field may be nil. Hooks are
+// called synchronously on the requesting goroutine and must not read or
+// close request or response bodies.
+type Hooks struct {
+	// OnRequest is called before each request is sent, including retries.
+	// Headers may be modified.
+	OnRequest func(req *http.Request)
+
+	// OnResponse is called after each attempt with the response or the
+	// transport error, and the time taken.
+	OnResponse func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+
+	// OnRetry is called before a failed request is retried. attempt is the
+	// number of the upcoming attempt, starting at 2, and err is the failure
+	// that caused the retry.
+	OnRetry func(req *http.Request, attempt int, err error, delay time.Duration)
+
+	// OnRateLimited is called when the server rejects a request with
+	// 429 Too Many Requests.
+	OnRateLimited func(req *http.Request, resp *http.Response)
+
+	// OnVersionSkew is called once per client if the server version is
+	// outside the range this SDK supports. Setting it makes the client
+	// fetch the server version before its first request.
+	OnVersionSkew func(warning VersionSkewWarning)
+
+	// OnDeprecation is called the first time a response marks an endpoint
+	// as deprecated with Deprecation or Sunset headers, once per method and
+	// route.
+	OnDeprecation func(d Deprecation)
+}
+
+func (h *Hooks) request(req *http.Request) {
+	if h.OnRequest != nil {
+		h.OnRequest(req)
+	}
+}
+
+func (h *Hooks) response(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if h.OnResponse != nil {
+		h.OnResponse(req, resp, err, elapsed)
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests && h.OnRateLimited != nil {
+		h.OnRateLimited(req, resp)
+	}
+}
+
+func (h *Hooks) retry(req *http.Request, attempt int, err error, delay time.Duration) {
+	if h.OnRetry != nil {
+		h.OnRetry(req, attempt, err, delay)
+	}
+}
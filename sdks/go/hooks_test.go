@@ -0,0 +1,43 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "abc" {
+			t.Errorf("Expected header set by OnRequest")
+		}
+		if r.URL.Path == "/tools" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	var responses []int
+	var rateLimited int
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}))
+	client.Hooks = Hooks{
+		OnRequest: func(req *http.Request) { req.Header.Set("X-Trace-Id", "abc") },
+		OnResponse: func(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+			responses = append(responses, resp.StatusCode)
+		},
+		OnRateLimited: func(req *http.Request, resp *http.Response) { rateLimited++ },
+	}
+
+	client.Health()
+	client.ListTools()
+
+	if len(responses) != 2 || responses[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected two responses, got %v", responses)
+	}
+	if rateLimited != 1 {
+		t.Errorf("Expected one rate-limited callback, got %d", rateLimited)
+	}
+}
@@ -0,0 +1,78 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// =============================================================================
+// Host Reports
+// =============================================================================
+
+// HostReport gathers everything known about one asset of a project: its
+// findings, the services listening on it and the credentials found for it.
+type HostReport struct {
+	ProjectID int64  `json:"project_id"`
+	Host      string `json:"host"`
+	// Addresses and Hostnames are the IP addresses and DNS names the host
+	// is known by.
+	Addresses   []string     `json:"addresses,omitempty"`
+	Hostnames   []string     `json:"hostnames,omitempty"`
+	OS          string       `json:"os,omitempty"`
+	Findings    []Finding    `json:"findings"`
+	Services    []Service    `json:"services"`
+	Credentials []Credential `json:"credentials"`
+
+	unknown *rawFields
+}
+
+// Service is a network service found on a host.
+type Service struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	Name     string `json:"name,omitempty"`
+	Product  string `json:"product,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+// Credential is a credential found during an engagement. The secret itself
+// is never returned by the API.
+type Credential struct {
+	ID       int64  `json:"id"`
+	Kind     string `json:"kind"`
+	Username string `json:"username,omitempty"`
+	// Service is the service the credential is for, such as "ssh/22".
+	Service string `json:"service,omitempty"`
+	// FindingID is the finding that reported the credential.
+	FindingID int64 `json:"finding_id,omitempty"`
+	Verified  bool  `json:"verified"`
+}
+
+// MaxSeverity returns the highest severity of the host's findings that are
+// not false positives, or "" if there are none.
+func (r *HostReport) MaxSeverity() Severity {
+	var highest Severity
+	for _, f := range r.Findings {
+		if sev := f.Severity; !f.FalsePositive && sev.rank() > highest.rank() {
+			highest = sev
+		}
+	}
+	return highest
+}
+
+// GetHostReport returns the findings, services and credentials of one host
+// of a project. host is an IP address or hostname.
+func (c *Client) GetHostReport(projectID int64, host string) (*HostReport, error) {
+	return c.GetHostReportContext(context.Background(), projectID, host)
+}
+
+// GetHostReportContext is like GetHostReport but uses ctx for its request.
+func (c *Client) GetHostReportContext(ctx context.Context, projectID int64, host string) (*HostReport, error) {
+	var report HostReport
+	path := fmt.Sprintf("/projects/%d/hosts/%s", projectID, url.PathEscape(host))
+	if err := c.requestContext(ctx, "GET", path, nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,39 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetHostReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/projects/3/hosts/fe80::1%25eth0" {
+			t.Errorf("Expected escaped host path, got %s", r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"project_id": 3, "host": "fe80::1%eth0", "hostnames": ["db.acme.internal"], "os": "Linux",
+			"findings": [
+				{"id": 1, "type": "vulnerability", "value": "CVE-2023-1234", "severity": "high"},
+				{"id": 2, "type": "vulnerability", "value": "weak cipher", "severity": "critical", "false_positive": true}
+			],
+			"services": [{"port": 5432, "protocol": "tcp", "name": "postgresql", "product": "PostgreSQL", "version": "13.2"}],
+			"credentials": [{"id": 9, "kind": "password", "username": "postgres", "service": "postgresql/5432", "finding_id": 1, "verified": true}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	report, err := client.Project(3).HostReport("fe80::1%eth0")
+	if err != nil {
+		t.Fatalf("HostReport failed: %v", err)
+	}
+	if len(report.Findings) != 2 || len(report.Services) != 1 || len(report.Credentials) != 1 {
+		t.Fatalf("Expected 2 findings, 1 service and 1 credential, got %+v", report)
+	}
+	if report.Services[0].Port != 5432 || report.Credentials[0].Username != "postgres" {
+		t.Errorf("Unexpected service or credential: %+v %+v", report.Services[0], report.Credentials[0])
+	}
+	if report.MaxSeverity() != SeverityHigh {
+		t.Errorf("Expected max severity high ignoring false positives, got %s", report.MaxSeverity())
+	}
+}
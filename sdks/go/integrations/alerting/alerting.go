@@ -0,0 +1,210 @@
+// Package alerting opens PagerDuty or Opsgenie incidents for critical,
+// verified findings on production projects, and resolves them once the
+// finding is fixed:
+//
+//	alerter := alerting.New(client, &alerting.PagerDuty{RoutingKey: key})
+//	if err := alerter.Run(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Incidents are keyed by finding, so a finding that is created and then
+// updated opens a single incident. An incident is also resolved when its
+// finding is downgraded from critical.
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// DefaultProductionTag is the project tag that marks production projects.
+const DefaultProductionTag = "production"
+
+// Alert is an incident for a finding.
+type Alert struct {
+	// DedupKey identifies the incident, so triggering it again does not open
+	// a second one.
+	DedupKey  string
+	Summary   string
+	Details   map[string]interface{}
+	FindingID int64
+	ProjectID int64
+}
+
+// Provider opens and resolves incidents in an on-call service.
+type Provider interface {
+	Trigger(ctx context.Context, alert *Alert) error
+	Resolve(ctx context.Context, dedupKey string) error
+}
+
+// Alerter turns finding events into incidents.
+type Alerter struct {
+	Client   *aiptx.Client
+	Provider Provider
+	// ProductionTag defaults to DefaultProductionTag.
+	ProductionTag string
+	// OnError, if set, is called with the events Run fails to handle.
+	// Otherwise the errors are logged. Run carries on with the next event
+	// either way.
+	OnError func(ev aiptx.Event, err error)
+
+	mu         sync.Mutex
+	production map[int64]bool
+	// triggered holds the findings with an incident opened by this
+	// Alerter, to resolve when they are downgraded.
+	triggered map[int64]bool
+}
+
+// New returns an Alerter that opens incidents with provider.
+func New(client *aiptx.Client, provider Provider) *Alerter {
+	return &Alerter{Client: client, Provider: provider, ProductionTag: DefaultProductionTag}
+}
+
+// DedupKey returns the dedup key of the incident for a finding.
+func DedupKey(findingID int64) string {
+	return fmt.Sprintf("aiptx-finding-%d", findingID)
+}
+
+// Run handles finding events until ctx is cancelled or the event stream
+// fails. Options such as aiptx.WithCursorStore are passed to the stream.
+// Events that fail to be handled are passed to OnError and skipped.
+func (a *Alerter) Run(ctx context.Context, opts ...aiptx.EventOption) error {
+	opts = append([]aiptx.EventOption{aiptx.WithEventTypes(aiptx.EventFindingCreated, aiptx.EventFindingUpdated)}, opts...)
+	stream := a.Client.Events(ctx, opts...)
+	for stream.Next() {
+		ev := stream.Event()
+		if err := a.HandleEvent(ctx, ev); err != nil && ctx.Err() == nil {
+			if a.OnError != nil {
+				a.OnError(ev, err)
+			} else {
+				log.Printf("alerting: handling event %s: %v", ev.ID, err)
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
+
+// HandleEvent triggers or resolves the incident for a finding event. Other
+// events are ignored.
+func (a *Alerter) HandleEvent(ctx context.Context, ev aiptx.Event) error {
+	if ev.Type != aiptx.EventFindingCreated && ev.Type != aiptx.EventFindingUpdated {
+		return nil
+	}
+	var f aiptx.Finding
+	if err := ev.Decode(&f); err != nil {
+		return err
+	}
+	return a.HandleFinding(ctx, f)
+}
+
+// HandleFinding triggers an incident if f is a critical, verified finding on
+// a production project, and resolves it once f is fixed, marked a false
+// positive or, if this Alerter triggered it, downgraded from critical. Other
+// findings are ignored.
+func (a *Alerter) HandleFinding(ctx context.Context, f aiptx.Finding) error {
+	if f.Severity != aiptx.SeverityCritical {
+		if !a.wasTriggered(f.ID) {
+			return nil
+		}
+		return a.resolve(ctx, f.ID)
+	}
+	production, err := a.isProduction(f.ProjectID)
+	if err != nil || !production {
+		return err
+	}
+
+	if f.FalsePositive || fixed(f) {
+		return a.resolve(ctx, f.ID)
+	}
+	if !f.Verified {
+		return nil
+	}
+	err = a.Provider.Trigger(ctx, &Alert{
+		DedupKey:  DedupKey(f.ID),
+		Summary:   fmt.Sprintf("Critical %s finding: %s", f.Type, f.Value),
+		FindingID: f.ID,
+		ProjectID: f.ProjectID,
+		Details: map[string]interface{}{
+			"finding_id":  f.ID,
+			"project_id":  f.ProjectID,
+			"type":        f.Type,
+			"value":       f.Value,
+			"description": f.Description,
+			"tool":        f.Tool,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	if a.triggered == nil {
+		a.triggered = map[int64]bool{}
+	}
+	a.triggered[f.ID] = true
+	a.mu.Unlock()
+	return nil
+}
+
+// resolve resolves the incident for a finding.
+func (a *Alerter) resolve(ctx context.Context, findingID int64) error {
+	if err := a.Provider.Resolve(ctx, DedupKey(findingID)); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	delete(a.triggered, findingID)
+	a.mu.Unlock()
+	return nil
+}
+
+// wasTriggered reports whether this Alerter opened an incident for a
+// finding that is not resolved yet.
+func (a *Alerter) wasTriggered(findingID int64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.triggered[findingID]
+}
+
+// fixed reports whether the fix for f has at least been claimed.
+func fixed(f aiptx.Finding) bool {
+	if f.Remediation == nil {
+		return false
+	}
+	return f.Remediation.Status == aiptx.RemediationFixed || f.Remediation.Status.Closed()
+}
+
+// isProduction reports whether a project carries the production tag. Tags
+// are cached for the lifetime of the Alerter.
+func (a *Alerter) isProduction(projectID int64) (bool, error) {
+	a.mu.Lock()
+	production, ok := a.production[projectID]
+	a.mu.Unlock()
+	if ok {
+		return production, nil
+	}
+
+	project, err := a.Client.GetProject(projectID)
+	if err != nil {
+		return false, err
+	}
+	tag := a.ProductionTag
+	if tag == "" {
+		tag = DefaultProductionTag
+	}
+	production = slices.Contains(project.Tags, tag)
+
+	a.mu.Lock()
+	if a.production == nil {
+		a.production = map[int64]bool{}
+	}
+	a.production[projectID] = production
+	a.mu.Unlock()
+	return production, nil
+}
@@ -0,0 +1,124 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+type recorder struct {
+	triggered []string
+	resolved  []string
+}
+
+func (r *recorder) Trigger(ctx context.Context, alert *Alert) error {
+	r.triggered = append(r.triggered, alert.DedupKey)
+	return nil
+}
+
+func (r *recorder) Resolve(ctx context.Context, dedupKey string) error {
+	r.resolved = append(r.resolved, dedupKey)
+	return nil
+}
+
+func TestAlerter(t *testing.T) {
+	lookups := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		switch r.URL.Path {
+		case "/projects/1":
+			w.Write([]byte(`{"id": 1, "tags": ["production", "pci"]}`))
+		case "/projects/2":
+			w.Write([]byte(`{"id": 2, "tags": ["staging"]}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	rec := &recorder{}
+	alerter := New(aiptx.NewClient(server.URL), rec)
+	ctx := context.Background()
+
+	findings := []aiptx.Finding{
+		{ID: 10, ProjectID: 1, Severity: "critical", Verified: true},
+		{ID: 11, ProjectID: 1, Severity: "critical"},
+		{ID: 12, ProjectID: 1, Severity: "high", Verified: true},
+		{ID: 13, ProjectID: 2, Severity: "critical", Verified: true},
+		{ID: 10, ProjectID: 1, Severity: "critical", Verified: true, Remediation: &aiptx.Remediation{Status: aiptx.RemediationFixed}},
+		{ID: 14, ProjectID: 1, Severity: "critical", Verified: true, FalsePositive: true},
+	}
+	for _, f := range findings {
+		if err := alerter.HandleFinding(ctx, f); err != nil {
+			t.Fatalf("HandleFinding failed: %v", err)
+		}
+	}
+
+	if len(rec.triggered) != 1 || rec.triggered[0] != "aiptx-finding-10" {
+		t.Errorf("Expected incident for finding 10, got %v", rec.triggered)
+	}
+	if len(rec.resolved) != 2 || rec.resolved[0] != "aiptx-finding-10" || rec.resolved[1] != "aiptx-finding-14" {
+		t.Errorf("Expected findings 10 and 14 to be resolved, got %v", rec.resolved)
+	}
+	if lookups != 2 {
+		t.Errorf("Expected project tags to be cached, got %d lookups", lookups)
+	}
+
+	err := alerter.HandleEvent(ctx, aiptx.Event{Type: aiptx.EventFindingCreated, Data: []byte(`{"id": 15, "project_id": 1, "severity": "critical", "verified": true}`)})
+	if err != nil || len(rec.triggered) != 2 || rec.triggered[1] != "aiptx-finding-15" {
+		t.Errorf("Expected incident from event, got %v (%v)", rec.triggered, err)
+	}
+
+	// A downgraded finding no longer warrants an incident.
+	if err := alerter.HandleFinding(ctx, aiptx.Finding{ID: 15, ProjectID: 1, Severity: "high", Verified: true}); err != nil {
+		t.Fatalf("HandleFinding failed: %v", err)
+	}
+	if len(rec.resolved) != 3 || rec.resolved[2] != "aiptx-finding-15" {
+		t.Errorf("Expected finding 15 to be resolved, got %v", rec.resolved)
+	}
+}
+
+func TestRunContinuesAfterErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events/poll":
+			polls++
+			if polls > 1 {
+				cancel()
+				w.Write([]byte(`{"events": []}`))
+				return
+			}
+			w.Write([]byte(`{"events": [
+				{"id": "e1", "type": "finding.created", "data": {"id": 20, "project_id": 9, "severity": "critical", "verified": true}},
+				{"id": "e2", "type": "finding.created", "data": {"id": 21, "project_id": 1, "severity": "critical", "verified": true}}
+			]}`))
+		case "/projects/1":
+			w.Write([]byte(`{"id": 1, "tags": ["production"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	rec := &recorder{}
+	alerter := New(aiptx.NewClient(server.URL), rec)
+	var failed []string
+	alerter.OnError = func(ev aiptx.Event, err error) {
+		failed = append(failed, ev.ID)
+	}
+	if err := alerter.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(failed) != 1 || failed[0] != "e1" {
+		t.Errorf("Expected event e1 to fail, got %v", failed)
+	}
+	if len(rec.triggered) != 1 || rec.triggered[0] != "aiptx-finding-21" {
+		t.Errorf("Expected the next event to be handled, got %v", rec.triggered)
+	}
+}
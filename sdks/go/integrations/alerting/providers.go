@@ -0,0 +1,117 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/aiptx/aiptx-go/internal/jsonhttp"
+)
+
+// PagerDuty opens incidents through the PagerDuty Events API v2.
+type PagerDuty struct {
+	// RoutingKey is the integration key of the service.
+	RoutingKey string
+	// URL defaults to https://events.pagerduty.com/v2/enqueue.
+	URL string
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// Trigger opens an incident, or updates the open one with the same key.
+func (p *PagerDuty) Trigger(ctx context.Context, alert *Alert) error {
+	return p.send(ctx, map[string]interface{}{
+		"routing_key":  p.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    alert.DedupKey,
+		"payload": map[string]interface{}{
+			"summary":        alert.Summary,
+			"source":         "aiptx",
+			"severity":       "critical",
+			"custom_details": alert.Details,
+		},
+	})
+}
+
+// Resolve resolves the incident with the given key, if one is open.
+func (p *PagerDuty) Resolve(ctx context.Context, dedupKey string) error {
+	return p.send(ctx, map[string]interface{}{
+		"routing_key":  p.RoutingKey,
+		"event_action": "resolve",
+		"dedup_key":    dedupKey,
+	})
+}
+
+func (p *PagerDuty) send(ctx context.Context, event interface{}) error {
+	u := p.URL
+	if u == "" {
+		u = "https://events.pagerduty.com/v2/enqueue"
+	}
+	return post(ctx, p.HTTPClient, u, nil, event)
+}
+
+// Opsgenie opens alerts through the Opsgenie Alert API.
+type Opsgenie struct {
+	// APIKey is the key of an API integration.
+	APIKey string
+	// URL defaults to https://api.opsgenie.com. Use
+	// https://api.eu.opsgenie.com for EU accounts.
+	URL string
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// Trigger creates an alert. Opsgenie deduplicates open alerts by alias.
+func (o *Opsgenie) Trigger(ctx context.Context, alert *Alert) error {
+	details := map[string]string{}
+	for k, v := range alert.Details {
+		details[k] = fmt.Sprint(v)
+	}
+	message := truncate(alert.Summary, 130)
+	return post(ctx, o.HTTPClient, o.baseURL()+"/v2/alerts", o.header(), map[string]interface{}{
+		"message":     message,
+		"alias":       alert.DedupKey,
+		"description": alert.Summary,
+		"priority":    "P1",
+		"source":      "aiptx",
+		"details":     details,
+	})
+}
+
+// Resolve closes the alert with the given alias.
+func (o *Opsgenie) Resolve(ctx context.Context, dedupKey string) error {
+	u := o.baseURL() + "/v2/alerts/" + url.PathEscape(dedupKey) + "/close?identifierType=alias"
+	return post(ctx, o.HTTPClient, u, o.header(), map[string]string{"source": "aiptx"})
+}
+
+func (o *Opsgenie) baseURL() string {
+	if o.URL != "" {
+		return o.URL
+	}
+	return "https://api.opsgenie.com"
+}
+
+func (o *Opsgenie) header() http.Header {
+	return http.Header{"Authorization": {"GenieKey " + o.APIKey}}
+}
+
+// post sends body as JSON and fails on non-2xx responses.
+func post(ctx context.Context, client *http.Client, u string, header http.Header, body interface{}) error {
+	if err := jsonhttp.Do(ctx, client, "POST", u, header, body, nil); err != nil {
+		return fmt.Errorf("alerting: %w", err)
+	}
+	return nil
+}
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
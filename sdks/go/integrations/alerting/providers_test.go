@@ -0,0 +1,90 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPagerDuty(t *testing.T) {
+	var events []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&event)
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pd := &PagerDuty{RoutingKey: "rk", URL: server.URL}
+	ctx := context.Background()
+	if err := pd.Trigger(ctx, &Alert{DedupKey: "aiptx-finding-1", Summary: "Critical rce finding"}); err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+	if err := pd.Resolve(ctx, "aiptx-finding-1"); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if events[0]["event_action"] != "trigger" || events[0]["dedup_key"] != "aiptx-finding-1" || events[0]["routing_key"] != "rk" {
+		t.Errorf("Expected trigger event, got %v", events[0])
+	}
+	if payload := events[0]["payload"].(map[string]interface{}); payload["severity"] != "critical" || payload["summary"] != "Critical rce finding" {
+		t.Errorf("Expected critical payload, got %v", payload)
+	}
+	if events[1]["event_action"] != "resolve" || events[1]["dedup_key"] != "aiptx-finding-1" {
+		t.Errorf("Expected resolve event, got %v", events[1])
+	}
+}
+
+func TestOpsgenie(t *testing.T) {
+	var requests []string
+	var alert map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey key" {
+			t.Errorf("Expected GenieKey authorization, got %q", r.Header.Get("Authorization"))
+		}
+		requests = append(requests, r.URL.RequestURI())
+		if r.URL.Path == "/v2/alerts" {
+			json.NewDecoder(r.Body).Decode(&alert)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	og := &Opsgenie{APIKey: "key", URL: server.URL}
+	ctx := context.Background()
+	if err := og.Trigger(ctx, &Alert{DedupKey: "aiptx-finding-1", Summary: "Critical", Details: map[string]interface{}{"finding_id": 1}}); err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+	if err := og.Resolve(ctx, "aiptx-finding-1"); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if alert["alias"] != "aiptx-finding-1" || alert["priority"] != "P1" || alert["details"].(map[string]interface{})["finding_id"] != "1" {
+		t.Errorf("Expected P1 alert with alias, got %v", alert)
+	}
+	if len(requests) != 2 || requests[1] != "/v2/alerts/aiptx-finding-1/close?identifierType=alias" {
+		t.Errorf("Expected close by alias, got %v", requests)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Key format is not valid!"}`, http.StatusUnprocessableEntity)
+	})
+	if err := og.Resolve(ctx, "aiptx-finding-1"); err == nil {
+		t.Errorf("Expected error for 422 response")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	s := "x" + strings.Repeat("é", 70)
+	if got := truncate(s, 130); len(got) != 129 || !utf8.ValidString(got) {
+		t.Errorf("Expected truncation on a character boundary, got %d bytes", len(got))
+	}
+	if got := truncate("short", 130); got != "short" {
+		t.Errorf("Expected short message unchanged, got %q", got)
+	}
+}
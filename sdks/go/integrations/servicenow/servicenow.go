@@ -0,0 +1,165 @@
+// Package servicenow creates ServiceNow Vulnerability Response records from
+// findings and keeps their status in sync in both directions:
+//
+//	sn := servicenow.NewClient("https://acme.service-now.com", user, password)
+//	syncer := servicenow.NewSyncer(client, sn)
+//	syncer.Filter = &aiptx.FindingsFilter{MinSeverity: aiptx.SeverityMedium}
+//	if err := syncer.Run(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Records are linked to findings through their correlation ID, so no state
+// is kept outside the two systems.
+package servicenow
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aiptx/aiptx-go/internal/jsonhttp"
+)
+
+// DefaultTable is the Vulnerability Response vulnerable item table.
+const DefaultTable = "sn_vul_vulnerable_item"
+
+// pageSize is the number of records fetched per Table API request.
+const pageSize = 1000
+
+// Client is a minimal ServiceNow Table API client.
+type Client struct {
+	// InstanceURL is the base URL of the instance, e.g.
+	// https://acme.service-now.com.
+	InstanceURL string
+	Username    string
+	Password    string
+	// Table defaults to DefaultTable.
+	Table string
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client using basic authentication.
+func NewClient(instanceURL, username, password string) *Client {
+	return &Client{InstanceURL: instanceURL, Username: username, Password: password, Table: DefaultTable}
+}
+
+// Record is a ServiceNow record, mapping column names to the values the
+// Table API returns for them.
+type Record map[string]string
+
+// SysID returns the record's sys_id.
+func (r Record) SysID() string {
+	return r["sys_id"]
+}
+
+// UpdatedAt returns when the record was last updated.
+func (r Record) UpdatedAt() time.Time {
+	t, _ := time.Parse("2006-01-02 15:04:05", r["sys_updated_on"])
+	return t
+}
+
+// Error is an error response from the Table API.
+type Error struct {
+	StatusCode int
+	Message    string
+	Detail     string
+}
+
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("servicenow: %d %s: %s", e.StatusCode, e.Message, e.Detail)
+	}
+	return fmt.Sprintf("servicenow: %d %s", e.StatusCode, e.Message)
+}
+
+// Query returns the records matching an encoded query, such as
+// "correlation_idSTARTSWITHaiptx-".
+func (c *Client) Query(ctx context.Context, query string) ([]Record, error) {
+	var records []Record
+	for offset := 0; ; offset += pageSize {
+		params := url.Values{
+			"sysparm_query":                  {query},
+			"sysparm_limit":                  {strconv.Itoa(pageSize)},
+			"sysparm_offset":                 {strconv.Itoa(offset)},
+			"sysparm_exclude_reference_link": {"true"},
+		}
+		var page []Record
+		if err := c.do(ctx, "GET", c.tablePath("")+"?"+params.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		records = append(records, page...)
+		if len(page) < pageSize {
+			return records, nil
+		}
+	}
+}
+
+// Create inserts a record and returns it as stored.
+func (c *Client) Create(ctx context.Context, fields Record) (Record, error) {
+	var record Record
+	if err := c.do(ctx, "POST", c.tablePath(""), fields, &record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Update changes the given fields of a record and returns it as stored.
+func (c *Client) Update(ctx context.Context, sysID string, fields Record) (Record, error) {
+	var record Record
+	if err := c.do(ctx, "PATCH", c.tablePath(sysID), fields, &record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (c *Client) tablePath(sysID string) string {
+	table := c.Table
+	if table == "" {
+		table = DefaultTable
+	}
+	path := "/api/now/table/" + table
+	if sysID != "" {
+		path += "/" + sysID
+	}
+	return path
+}
+
+// do sends a Table API request and decodes the "result" member of the
+// response into out.
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	auth := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+	header := http.Header{"Authorization": {"Basic " + auth}}
+	var result struct {
+		Result json.RawMessage `json:"result"`
+	}
+	err := jsonhttp.Do(ctx, c.HTTPClient, method, c.InstanceURL+path, header, in, &result)
+	var statusErr *jsonhttp.StatusError
+	if errors.As(err, &statusErr) {
+		var body struct {
+			Error struct {
+				Message string `json:"message"`
+				Detail  string `json:"detail"`
+			} `json:"error"`
+		}
+		json.Unmarshal(statusErr.Body, &body)
+		msg := body.Error.Message
+		if msg == "" {
+			msg = http.StatusText(statusErr.StatusCode)
+		}
+		return &Error{StatusCode: statusErr.StatusCode, Message: msg, Detail: body.Error.Detail}
+	}
+	if err != nil {
+		return fmt.Errorf("servicenow: %w", err)
+	}
+	if out == nil || len(result.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(result.Result, out)
+}
@@ -0,0 +1,51 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "admin" || pass != "secret" {
+			t.Errorf("Expected basic auth, got %s:%s", user, pass)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/now/table/sn_vul_vulnerable_item":
+			if q := r.URL.Query(); q.Get("sysparm_query") != "state=1" || q.Get("sysparm_offset") != "0" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"result": [{"sys_id": "a1", "number": "VIT0001", "sys_updated_on": "2024-01-15 09:30:00"}]}`))
+		case "PATCH /api/now/table/sn_vul_vulnerable_item/a1":
+			w.Write([]byte(`{"result": {"sys_id": "a1", "state": "3"}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"message": "Operation Failed", "detail": "ACL Exception"}, "status": "failure"}`))
+		}
+	}))
+	defer server.Close()
+	sn := NewClient(server.URL, "admin", "secret")
+	ctx := context.Background()
+
+	records, err := sn.Query(ctx, "state=1")
+	if err != nil || len(records) != 1 || records[0].SysID() != "a1" {
+		t.Fatalf("Expected one record, got %v (%v)", records, err)
+	}
+	if got := records[0].UpdatedAt().Format("15:04"); got != "09:30" {
+		t.Errorf("Expected update time 09:30, got %s", got)
+	}
+
+	record, err := sn.Update(ctx, "a1", Record{"state": StateClosed})
+	if err != nil || record["state"] != "3" {
+		t.Errorf("Expected closed record, got %v (%v)", record, err)
+	}
+
+	_, err = sn.Create(ctx, Record{})
+	var snErr *Error
+	if !errors.As(err, &snErr) || snErr.StatusCode != 403 || snErr.Detail != "ACL Exception" {
+		t.Errorf("Expected ACL error, got %v", err)
+	}
+}
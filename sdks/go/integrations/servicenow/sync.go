@@ -0,0 +1,132 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+	"github.com/aiptx/aiptx-go/ticketsync"
+)
+
+// DefaultInterval is how often Run synchronizes.
+const DefaultInterval = ticketsync.DefaultInterval
+
+// Record states of the vulnerable item table.
+const (
+	StateOpen               = "1"
+	StateUnderInvestigation = "2"
+	StateClosed             = "3"
+	StateInReview           = "11"
+)
+
+// DefaultStates maps remediation statuses to record states.
+var DefaultStates = map[aiptx.RemediationStatus]string{
+	aiptx.RemediationOpen:       StateOpen,
+	aiptx.RemediationInProgress: StateUnderInvestigation,
+	aiptx.RemediationFixed:      StateInReview,
+	aiptx.RemediationVerified:   StateClosed,
+	aiptx.RemediationAccepted:   StateClosed,
+}
+
+// DefaultRemoteStates maps record states back to remediation statuses. A
+// closed record verifies the fix, since ServiceNow does not distinguish it
+// from accepted risk by state alone.
+var DefaultRemoteStates = map[string]aiptx.RemediationStatus{
+	StateOpen:               aiptx.RemediationOpen,
+	StateUnderInvestigation: aiptx.RemediationInProgress,
+	StateInReview:           aiptx.RemediationFixed,
+	StateClosed:             aiptx.RemediationVerified,
+}
+
+// riskRatings maps severities to vulnerable item risk ratings.
+var riskRatings = map[aiptx.Severity]string{
+	aiptx.SeverityCritical: "1",
+	aiptx.SeverityHigh:     "2",
+	aiptx.SeverityMedium:   "3",
+	aiptx.SeverityLow:      "4",
+	aiptx.SeverityInfo:     "5",
+}
+
+// Syncer creates a record for every finding matching Filter and keeps the
+// finding's remediation status and the record's state in sync. When both
+// sides differ, the side changed last wins. It is a ticketsync.Reconciler
+// with a Tracker and the LastWriteWins strategy; use those directly for
+// other strategies.
+type Syncer struct {
+	AIPTX      *aiptx.Client
+	ServiceNow *Client
+	// Filter selects the findings to sync. If nil, all findings are synced.
+	Filter *aiptx.FindingsFilter
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+	// States and RemoteStates default to DefaultStates and
+	// DefaultRemoteStates.
+	States       map[aiptx.RemediationStatus]string
+	RemoteStates map[string]aiptx.RemediationStatus
+	// Fields returns the fields of a new record. It defaults to RecordFields.
+	Fields func(f aiptx.Finding) Record
+}
+
+// NewSyncer returns a Syncer for all findings.
+func NewSyncer(client *aiptx.Client, sn *Client) *Syncer {
+	return &Syncer{
+		AIPTX:        client,
+		ServiceNow:   sn,
+		Interval:     DefaultInterval,
+		States:       DefaultStates,
+		RemoteStates: DefaultRemoteStates,
+		Fields:       RecordFields,
+	}
+}
+
+// RecordFields returns the description and risk rating of the record for a
+// finding. The correlation ID and state are set by the Syncer.
+func RecordFields(f aiptx.Finding) Record {
+	return Record{
+		"short_description": fmt.Sprintf("%s: %s", f.Type, f.Value),
+		"description":       f.Description,
+		"risk_rating":       riskRatings[f.Severity],
+	}
+}
+
+// SyncResult counts the changes made by a synchronization.
+type SyncResult struct {
+	// Created is the number of records created.
+	Created int
+	// Pushed is the number of records updated from their finding.
+	Pushed int
+	// Pulled is the number of findings updated from their record.
+	Pulled int
+}
+
+// Run synchronizes every Interval until ctx is cancelled or a
+// synchronization fails.
+func (s *Syncer) Run(ctx context.Context) error {
+	r := s.reconciler()
+	r.Interval = s.Interval
+	return r.Run(ctx)
+}
+
+// SyncOnce creates missing records and reconciles the status of linked
+// findings and records once. A failure to sync one finding does not stop
+// the others.
+func (s *Syncer) SyncOnce(ctx context.Context) (*SyncResult, error) {
+	result, err := s.reconciler().ReconcileOnce(ctx)
+	if result == nil {
+		return nil, err
+	}
+	return &SyncResult{Created: result.Created, Pushed: result.Pushed, Pulled: result.Pulled}, err
+}
+
+// reconciler returns the ticketsync.Reconciler doing the work of s.
+func (s *Syncer) reconciler() *ticketsync.Reconciler {
+	r := ticketsync.New(s.AIPTX, &Tracker{
+		Client:       s.ServiceNow,
+		States:       s.States,
+		RemoteStates: s.RemoteStates,
+		Fields:       s.Fields,
+	})
+	r.Filter = s.Filter
+	return r
+}
@@ -0,0 +1,80 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestSyncOnce(t *testing.T) {
+	var statusUpdates []map[string]interface{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /findings":
+			w.Write([]byte(`[
+				{"id": 1, "type": "xss", "value": "/search", "severity": "high"},
+				{"id": 2, "remediation": {"status": "fixed", "updated_at": "2024-01-15T10:00:00Z"}},
+				{"id": 3, "remediation": {"status": "in_progress", "updated_at": "2024-01-15T08:00:00Z"}},
+				{"id": 4, "remediation": {"status": "accepted", "updated_at": "2024-01-15T08:00:00Z"}},
+				{"id": 5, "false_positive": true}
+			]`))
+		case "PATCH /findings/3/remediation":
+			var update map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&update)
+			statusUpdates = append(statusUpdates, update)
+			w.Write([]byte(`{"id": 3}`))
+		default:
+			t.Errorf("Unexpected AIPTX request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer api.Close()
+
+	var created, patched []Record
+	sn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fields Record
+		json.NewDecoder(r.Body).Decode(&fields)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/now/table/sn_vul_vulnerable_item":
+			w.Write([]byte(`{"result": [
+				{"sys_id": "b2", "correlation_id": "aiptx-finding-2", "state": "2", "sys_updated_on": "2024-01-15 09:00:00"},
+				{"sys_id": "b3", "number": "VIT0003", "correlation_id": "aiptx-finding-3", "state": "11", "sys_updated_on": "2024-01-15 09:00:00"},
+				{"sys_id": "b4", "correlation_id": "aiptx-finding-4", "state": "3", "sys_updated_on": "2024-01-15 09:00:00"}
+			]}`))
+		case "POST /api/now/table/sn_vul_vulnerable_item":
+			created = append(created, fields)
+			w.Write([]byte(`{"result": {"sys_id": "b1"}}`))
+		case "PATCH /api/now/table/sn_vul_vulnerable_item/b2":
+			patched = append(patched, fields)
+			w.Write([]byte(`{"result": {"sys_id": "b2"}}`))
+		default:
+			t.Errorf("Unexpected ServiceNow request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer sn.Close()
+
+	syncer := NewSyncer(aiptx.NewClient(api.URL), NewClient(sn.URL, "", ""))
+	result, err := syncer.SyncOnce(context.Background())
+	if err != nil {
+		t.Fatalf("SyncOnce failed: %v", err)
+	}
+	if *result != (SyncResult{Created: 1, Pushed: 1, Pulled: 1}) {
+		t.Errorf("Expected one change of each kind, got %+v", result)
+	}
+
+	if len(created) != 1 || created[0]["correlation_id"] != "aiptx-finding-1" || created[0]["state"] != StateOpen ||
+		created[0]["short_description"] != "xss: /search" || created[0]["risk_rating"] != "2" {
+		t.Errorf("Expected open record for finding 1, got %v", created)
+	}
+	// Finding 2 changed after its record, so the record follows.
+	if len(patched) != 1 || patched[0]["state"] != StateInReview {
+		t.Errorf("Expected record b2 to move to review, got %v", patched)
+	}
+	// Record b3 changed after its finding, so the finding follows.
+	if len(statusUpdates) != 1 || statusUpdates[0]["status"] != "fixed" || statusUpdates[0]["notes"] != "Synced from ServiceNow VIT0003" {
+		t.Errorf("Expected finding 3 to be marked fixed, got %v", statusUpdates)
+	}
+}
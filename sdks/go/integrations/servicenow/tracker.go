@@ -0,0 +1,103 @@
+package servicenow
+
+import (
+	"context"
+
+	"github.com/aiptx/aiptx-go"
+	"github.com/aiptx/aiptx-go/ticketsync"
+)
+
+// Tracker is a ticketsync.Tracker keeping findings as Vulnerability Response
+// records, linked to findings through their correlation ID.
+type Tracker struct {
+	Client *Client
+	// States and RemoteStates default to DefaultStates and
+	// DefaultRemoteStates.
+	States       map[aiptx.RemediationStatus]string
+	RemoteStates map[string]aiptx.RemediationStatus
+	// Fields returns the fields of a new record. It defaults to RecordFields.
+	Fields func(f aiptx.Finding) Record
+}
+
+// NewTracker returns a Tracker using client.
+func NewTracker(client *Client) *Tracker {
+	return &Tracker{Client: client}
+}
+
+// Name returns "ServiceNow".
+func (t *Tracker) Name() string {
+	return "ServiceNow"
+}
+
+// Tickets returns the records with a finding's correlation ID.
+func (t *Tracker) Tickets(ctx context.Context) ([]ticketsync.Ticket, error) {
+	records, err := t.Client.Query(ctx, "correlation_idSTARTSWITH"+ticketsync.LinkPrefix)
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]ticketsync.Ticket, 0, len(records))
+	for _, r := range records {
+		if id, ok := ticketsync.ParseLinkID(r["correlation_id"]); ok {
+			tickets = append(tickets, ticket(r, id))
+		}
+	}
+	return tickets, nil
+}
+
+// Create inserts a record for a finding in state.
+func (t *Tracker) Create(ctx context.Context, f aiptx.Finding, state string) (*ticketsync.Ticket, error) {
+	fields := RecordFields(f)
+	if t.Fields != nil {
+		fields = t.Fields(f)
+	}
+	fields["correlation_id"] = ticketsync.LinkID(f.ID)
+	fields["state"] = state
+	record, err := t.Client.Create(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+	created := ticket(record, f.ID)
+	return &created, nil
+}
+
+// Update sets the state of a record.
+func (t *Tracker) Update(ctx context.Context, tk *ticketsync.Ticket, state string) error {
+	if _, err := t.Client.Update(ctx, tk.ID, Record{"state": state}); err != nil {
+		return err
+	}
+	tk.State = state
+	return nil
+}
+
+// State returns the record state for a remediation status.
+func (t *Tracker) State(status aiptx.RemediationStatus) string {
+	states := t.States
+	if states == nil {
+		states = DefaultStates
+	}
+	return states[status]
+}
+
+// Status returns the remediation status for a record state.
+func (t *Tracker) Status(state string) (aiptx.RemediationStatus, bool) {
+	states := t.RemoteStates
+	if states == nil {
+		states = DefaultRemoteStates
+	}
+	status, ok := states[state]
+	return status, ok
+}
+
+func ticket(r Record, findingID int64) ticketsync.Ticket {
+	key := r["number"]
+	if key == "" {
+		key = r.SysID()
+	}
+	return ticketsync.Ticket{
+		ID:        r.SysID(),
+		Key:       key,
+		FindingID: findingID,
+		State:     r["state"],
+		UpdatedAt: r.UpdatedAt(),
+	}
+}
@@ -0,0 +1,107 @@
+package teams
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Card is an adaptive card.
+type Card struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []interface{} `json:"body"`
+}
+
+// TextBlock is a card element showing text.
+type TextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap,omitempty"`
+}
+
+// FactSet is a card element showing name-value pairs.
+type FactSet struct {
+	Type  string `json:"type"`
+	Facts []Fact `json:"facts"`
+}
+
+// Fact is a name-value pair in a FactSet.
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// newCard returns a card with a title and facts. Facts with empty values
+// are left out.
+func newCard(title, color string, facts ...Fact) *Card {
+	set := FactSet{Type: "FactSet"}
+	for _, f := range facts {
+		if f.Value != "" {
+			set.Facts = append(set.Facts, f)
+		}
+	}
+	return &Card{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []interface{}{
+			TextBlock{Type: "TextBlock", Text: title, Size: "Medium", Weight: "Bolder", Color: color, Wrap: true},
+			set,
+		},
+	}
+}
+
+// add appends a wrapped text block to c, unless text is empty.
+func (c *Card) add(text string) {
+	if text != "" {
+		c.Body = append(c.Body, TextBlock{Type: "TextBlock", Text: text, Wrap: true})
+	}
+}
+
+// severityColors maps severities to adaptive card colors.
+var severityColors = map[aiptx.Severity]string{
+	aiptx.SeverityCritical: "Attention",
+	aiptx.SeverityHigh:     "Attention",
+	aiptx.SeverityMedium:   "Warning",
+}
+
+// FindingCard returns the card for a new finding.
+func FindingCard(f aiptx.Finding) *Card {
+	card := newCard(fmt.Sprintf("New %s finding: %s", f.Severity, f.Value), severityColors[f.Severity],
+		Fact{"Severity", string(f.Severity)},
+		Fact{"Type", f.Type},
+		Fact{"Tool", f.Tool},
+		Fact{"Project", strconv.FormatInt(f.ProjectID, 10)},
+		Fact{"Finding", strconv.FormatInt(f.ID, 10)},
+	)
+	card.add(f.Description)
+	return card
+}
+
+// ScanCard returns the card for a completed or failed scan. action is the
+// event type, aiptx.EventScanCompleted or aiptx.EventScanFailed.
+func ScanCard(s aiptx.ScanStatus, action string, projectID int64) *Card {
+	title, color := "Scan completed", "Good"
+	if action == aiptx.EventScanFailed {
+		title, color = "Scan failed", "Attention"
+	}
+	var duration string
+	if !s.StartedAt.IsZero() && !s.CompletedAt.IsZero() {
+		duration = s.CompletedAt.Sub(s.StartedAt).Round(time.Second).String()
+	}
+	card := newCard(title, color,
+		Fact{"Scan", s.ID},
+		Fact{"Project", strconv.FormatInt(projectID, 10)},
+		Fact{"Findings", strconv.Itoa(s.FindingsCount)},
+		Fact{"Duration", duration},
+	)
+	card.add(s.Error)
+	return card
+}
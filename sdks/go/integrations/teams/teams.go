@@ -0,0 +1,133 @@
+// Package teams posts adaptive cards about new findings and finished scans
+// to Microsoft Teams channels through incoming webhooks. Each project can be
+// routed to its own channel with its own findings filter:
+//
+//	n := teams.New(client, os.Getenv("TEAMS_WEBHOOK"))
+//	n.Projects[42] = teams.Channel{
+//	    WebhookURL: os.Getenv("TEAMS_WEBHOOK_PAYMENTS"),
+//	    Findings:   &aiptx.FindingsFilter{MinSeverity: aiptx.SeverityHigh},
+//	}
+//	if err := n.Run(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+package teams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aiptx/aiptx-go"
+	"github.com/aiptx/aiptx-go/internal/jsonhttp"
+)
+
+// Channel is where and what to notify about for a project.
+type Channel struct {
+	// WebhookURL is the incoming webhook of the Teams channel. Projects
+	// routed to a channel without one are not notified.
+	WebhookURL string
+	// Findings selects the findings to notify about. If nil, every new
+	// finding is posted.
+	Findings *aiptx.FindingsFilter
+	// MuteScans turns off scan completion cards.
+	MuteScans bool
+}
+
+// Notifier posts cards for finding and scan events.
+type Notifier struct {
+	Client *aiptx.Client
+	// Default is used for projects without an entry in Projects.
+	Default  Channel
+	Projects map[int64]Channel
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// New returns a Notifier posting every project's events to webhookURL.
+func New(client *aiptx.Client, webhookURL string) *Notifier {
+	return &Notifier{
+		Client:   client,
+		Default:  Channel{WebhookURL: webhookURL},
+		Projects: map[int64]Channel{},
+	}
+}
+
+// channel returns the channel of a project.
+func (n *Notifier) channel(projectID int64) Channel {
+	if ch, ok := n.Projects[projectID]; ok {
+		return ch
+	}
+	return n.Default
+}
+
+// Run posts cards for events until ctx is cancelled or the event stream
+// fails. Options such as aiptx.WithCursorStore are passed to the stream.
+func (n *Notifier) Run(ctx context.Context, opts ...aiptx.EventOption) error {
+	opts = append([]aiptx.EventOption{aiptx.WithEventTypes(aiptx.EventFindingCreated, aiptx.EventScanCompleted, aiptx.EventScanFailed)}, opts...)
+	stream := n.Client.Events(ctx, opts...)
+	for stream.Next() {
+		if err := n.HandleEvent(ctx, stream.Event()); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
+
+// HandleEvent posts a card for a new finding or a completed or failed scan,
+// if the project's channel wants it. Other events are ignored.
+func (n *Notifier) HandleEvent(ctx context.Context, ev aiptx.Event) error {
+	switch ev.Type {
+	case aiptx.EventFindingCreated:
+		var f aiptx.Finding
+		if err := ev.Decode(&f); err != nil {
+			return err
+		}
+		ch := n.channel(f.ProjectID)
+		if ch.Findings != nil && !ch.Findings.Matches(f) {
+			return nil
+		}
+		return n.post(ctx, ch, FindingCard(f))
+	case aiptx.EventScanCompleted, aiptx.EventScanFailed:
+		var s aiptx.ScanStatus
+		if err := ev.Decode(&s); err != nil {
+			return err
+		}
+		ch := n.channel(ev.ProjectID)
+		if ch.MuteScans {
+			return nil
+		}
+		return n.post(ctx, ch, ScanCard(s, ev.Type, ev.ProjectID))
+	}
+	return nil
+}
+
+// post sends card to the channel's webhook, if it has one.
+func (n *Notifier) post(ctx context.Context, ch Channel, card *Card) error {
+	if ch.WebhookURL == "" {
+		return nil
+	}
+	err := jsonhttp.Do(ctx, n.HTTPClient, "POST", ch.WebhookURL, nil, message{
+		Type: "message",
+		Attachments: []attachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("teams: webhook: %w", err)
+	}
+	return nil
+}
+
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string `json:"contentType"`
+	Content     *Card  `json:"content"`
+}
@@ -0,0 +1,80 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestNotifier(t *testing.T) {
+	posts := map[string][]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		posts[r.URL.Path] = append(posts[r.URL.Path], msg)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n := New(nil, server.URL+"/default")
+	n.Projects[42] = Channel{
+		WebhookURL: server.URL + "/payments",
+		Findings:   &aiptx.FindingsFilter{MinSeverity: aiptx.SeverityHigh},
+		MuteScans:  true,
+	}
+	n.Projects[7] = Channel{}
+	ctx := context.Background()
+
+	events := []aiptx.Event{
+		{Type: aiptx.EventFindingCreated, Data: []byte(`{"id": 1, "project_id": 42, "severity": "critical", "type": "rce", "value": "/upload"}`)},
+		{Type: aiptx.EventFindingCreated, Data: []byte(`{"id": 2, "project_id": 42, "severity": "low"}`)},
+		{Type: aiptx.EventScanCompleted, ProjectID: 42, Data: []byte(`{"id": "s-1"}`)},
+		{Type: aiptx.EventFindingCreated, Data: []byte(`{"id": 3, "project_id": 7, "severity": "critical"}`)},
+		{Type: aiptx.EventScanFailed, ProjectID: 1, Data: []byte(`{"id": "s-2", "error": "target unreachable"}`)},
+		{Type: aiptx.EventScanPhaseChanged, ProjectID: 1, Data: []byte(`{"id": "s-2"}`)},
+	}
+	for _, ev := range events {
+		if err := n.HandleEvent(ctx, ev); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+	}
+
+	if len(posts["/payments"]) != 1 || len(posts["/default"]) != 1 || len(posts) != 2 {
+		t.Fatalf("Expected one card per channel, got %v", posts)
+	}
+	attachment := posts["/payments"][0]["attachments"].([]interface{})[0].(map[string]interface{})
+	if attachment["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("Expected adaptive card, got %v", attachment["contentType"])
+	}
+	title := attachment["content"].(map[string]interface{})["body"].([]interface{})[0].(map[string]interface{})
+	if title["text"] != "New critical finding: /upload" || title["color"] != "Attention" {
+		t.Errorf("Expected critical finding title, got %v", title)
+	}
+}
+
+func TestScanCard(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	card := ScanCard(aiptx.ScanStatus{ID: "s-1", FindingsCount: 12, StartedAt: start, CompletedAt: start.Add(95 * time.Minute)}, aiptx.EventScanCompleted, 42)
+
+	if title := card.Body[0].(TextBlock); title.Text != "Scan completed" || title.Color != "Good" {
+		t.Errorf("Expected completed title, got %+v", title)
+	}
+	facts := card.Body[1].(FactSet).Facts
+	want := []Fact{{"Scan", "s-1"}, {"Project", "42"}, {"Findings", "12"}, {"Duration", "1h35m0s"}}
+	if len(facts) != len(want) {
+		t.Fatalf("Expected facts %v, got %v", want, facts)
+	}
+	for i := range want {
+		if facts[i] != want[i] {
+			t.Errorf("Expected fact %v, got %v", want[i], facts[i])
+		}
+	}
+	if len(card.Body) != 2 {
+		t.Errorf("Expected no error text, got %v", card.Body)
+	}
+}
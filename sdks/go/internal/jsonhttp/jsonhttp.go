@@ -0,0 +1,91 @@
+// Package jsonhttp sends the JSON requests of the integrations and
+// forwarders to third-party APIs and webhooks.
+package jsonhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultClient is used when no client is given.
+var DefaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// maxErrorBody bounds the part of an error response kept in a StatusError.
+const maxErrorBody = 4096
+
+// StatusError reports a response with a non-2xx status.
+type StatusError struct {
+	Method     string
+	Host       string
+	StatusCode int
+	Status     string
+	// Body is the start of the response body.
+	Body []byte
+}
+
+// Error reports the host but not the path of the request, which for
+// webhooks contains their secret.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s returned %s: %s", e.Method, e.Host, e.Status, bytes.TrimSpace(e.Body))
+}
+
+// Do sends in as JSON, if not nil, with header, and decodes the response
+// into out, if not nil. client may be nil to use DefaultClient.
+func Do(ctx context.Context, client *http.Client, method, url string, header http.Header, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return Send(client, req, out)
+}
+
+// Send sends req and decodes the response into out, if not nil. Responses
+// with a non-2xx status fail with a *StatusError. client may be nil to use
+// DefaultClient.
+func Send(client *http.Client, req *http.Request, out interface{}) error {
+	if client == nil {
+		client = DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &StatusError{
+			Method:     req.Method,
+			Host:       req.URL.Host,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       msg,
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s response: %w", req.URL.Host, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package jsonhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("X-Token") != "secret" {
+			t.Errorf("Expected JSON request with header, got %v", r.Header)
+		}
+		var in map[string]string
+		json.NewDecoder(r.Body).Decode(&in)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(" invalid payload\n"))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"echo": in["text"]})
+	}))
+	defer server.Close()
+	header := http.Header{"X-Token": {"secret"}}
+
+	var out struct{ Echo string }
+	if err := Do(context.Background(), nil, "POST", server.URL+"/ok", header, map[string]string{"text": "hi"}, &out); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if out.Echo != "hi" {
+		t.Errorf("Expected echo hi, got %q", out.Echo)
+	}
+
+	err := Do(context.Background(), nil, "POST", server.URL+"/fail", header, map[string]string{}, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected *StatusError with status 400, got %v", err)
+	}
+	if msg := err.Error(); !strings.HasSuffix(msg, "400 Bad Request: invalid payload") || strings.Contains(msg, "/fail") {
+		t.Errorf("Expected error with host and body but not path, got %q", msg)
+	}
+}
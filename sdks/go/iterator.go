@@ -0,0 +1,260 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// =============================================================================
+// Iterators
+// =============================================================================
+
+// pager fetches the pages of a list endpoint one at a time for the
+// iterators, following the cursor of each page to the next.
+type pager struct {
+	ctx    context.Context
+	client *Client
+	path   string
+	// values encodes the filter, and cursor points at its Cursor field.
+	values func() url.Values
+	cursor *string
+
+	info *PageInfo
+	done bool
+	err  error
+	seen cursorLoop
+}
+
+// cursorLoop records the cursors followed through a list endpoint, to stop
+// a server that returns one of them again from paging forever.
+type cursorLoop map[string]bool
+
+// follow records cursor, returning an error if it was followed before.
+func (l cursorLoop) follow(path, cursor string) error {
+	if l[cursor] {
+		return fmt.Errorf("aiptx: %s returned cursor %q again", path, cursor)
+	}
+	l[cursor] = true
+	return nil
+}
+
+// more reports whether another page should be fetched.
+func (p *pager) more() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	return true
+}
+
+// fetch reads the next page into out.
+func (p *pager) fetch(out interface{}) {
+	info, err := p.client.listPage(p.ctx, withQuery(p.path, p.values()), out)
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.info = info
+	if !info.HasNext || info.NextCursor == "" {
+		p.done = true
+		return
+	}
+	if p.seen == nil {
+		p.seen = cursorLoop{*p.cursor: true}
+	}
+	if err := p.seen.follow(p.path, info.NextCursor); err != nil {
+		p.err = err
+		return
+	}
+	*p.cursor = info.NextCursor
+}
+
+// total implements Total for the iterators. PageInfo.Total is -1 when the
+// server omits the total.
+func (p *pager) total() int {
+	if p.info == nil || p.info.Total < 0 {
+		return -1
+	}
+	return p.info.Total
+}
+
+// FindingsIterator iterates over findings one page at a time, fetching each
+// page only once the previous one has been read, so projects with too many
+// findings for ListFindings can be processed in constant memory:
+//
+//	it := client.IterateFindings(ctx, &aiptx.FindingsFilter{ProjectID: 42})
+//	for it.Next() {
+//	    process(it.Finding())
+//	}
+//	if err := it.Err(); err != nil {
+//	    return err
+//	}
+type FindingsIterator struct {
+	pager
+	filter  FindingsFilter
+	page    []Finding
+	current Finding
+}
+
+// IterateFindings returns an iterator over the findings matching filter,
+// which may be nil. Pages hold filter.Limit findings, or
+// DefaultExportPageSize if it is zero, and iteration starts at
+// filter.Cursor.
+func (c *Client) IterateFindings(ctx context.Context, filter *FindingsFilter) *FindingsIterator {
+	it := &FindingsIterator{}
+	if filter != nil {
+		it.filter = *filter
+	}
+	if it.filter.Limit == 0 {
+		it.filter.Limit = DefaultExportPageSize
+	}
+	it.pager = pager{ctx: ctx, client: c, path: "/findings", values: it.filter.values, cursor: &it.filter.Cursor}
+	it.err = it.filter.validate()
+	return it
+}
+
+// Next advances to the next finding, fetching the next page if needed, and
+// reports whether there is one. It returns false at the end of the results
+// or on error; check Err to tell them apart.
+func (it *FindingsIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		it.page = nil
+		it.fetch(&it.page)
+	}
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Finding returns the finding Next advanced to.
+func (it *FindingsIterator) Finding() Finding {
+	return it.current
+}
+
+// Err returns the error that ended the iteration, or nil if it ended
+// because there were no more findings.
+func (it *FindingsIterator) Err() error {
+	return it.err
+}
+
+// Total returns the number of findings across all pages, or -1 if the
+// server did not report it or no page has been fetched yet.
+func (it *FindingsIterator) Total() int {
+	return it.total()
+}
+
+// ProjectsIterator iterates over projects one page at a time, like
+// FindingsIterator, for instances with too many projects to list at once.
+type ProjectsIterator struct {
+	pager
+	opts    ListOptions
+	page    []Project
+	current Project
+}
+
+// IterateProjects returns an iterator over the projects matching opts,
+// which may be nil. Pages hold opts.Limit projects, or the server's default
+// if it is zero, and iteration starts at opts.Cursor.
+func (c *Client) IterateProjects(ctx context.Context, opts *ListOptions) *ProjectsIterator {
+	it := &ProjectsIterator{}
+	if opts != nil {
+		it.opts = *opts
+	}
+	it.pager = pager{ctx: ctx, client: c, path: "/projects", values: it.opts.values, cursor: &it.opts.Cursor}
+	return it
+}
+
+// Next advances to the next project, fetching the next page if needed, and
+// reports whether there is one. It returns false at the end of the results
+// or on error; check Err to tell them apart.
+func (it *ProjectsIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		it.page = nil
+		it.fetch(&it.page)
+	}
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Project returns the project Next advanced to.
+func (it *ProjectsIterator) Project() Project {
+	return it.current
+}
+
+// Err returns the error that ended the iteration, or nil if it ended
+// because there were no more projects.
+func (it *ProjectsIterator) Err() error {
+	return it.err
+}
+
+// Total returns the number of projects across all pages, or -1 if the
+// server did not report it or no page has been fetched yet.
+func (it *ProjectsIterator) Total() int {
+	return it.total()
+}
+
+// SessionsIterator iterates over the sessions of a project one page at a
+// time, like FindingsIterator.
+type SessionsIterator struct {
+	pager
+	opts    ListOptions
+	page    []Session
+	current Session
+}
+
+// IterateSessions returns an iterator over the sessions of a project
+// matching opts, which may be nil. Pages hold opts.Limit sessions, or the
+// server's default if it is zero, and iteration starts at opts.Cursor.
+func (c *Client) IterateSessions(ctx context.Context, projectID int64, opts *ListOptions) *SessionsIterator {
+	it := &SessionsIterator{}
+	if opts != nil {
+		it.opts = *opts
+	}
+	path := fmt.Sprintf("/projects/%d/sessions", projectID)
+	it.pager = pager{ctx: ctx, client: c, path: path, values: it.opts.values, cursor: &it.opts.Cursor}
+	return it
+}
+
+// Next advances to the next session, fetching the next page if needed, and
+// reports whether there is one. It returns false at the end of the results
+// or on error; check Err to tell them apart.
+func (it *SessionsIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		it.page = nil
+		it.fetch(&it.page)
+	}
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Session returns the session Next advanced to.
+func (it *SessionsIterator) Session() Session {
+	return it.current
+}
+
+// Err returns the error that ended the iteration, or nil if it ended
+// because there were no more sessions.
+func (it *SessionsIterator) Err() error {
+	return it.err
+}
+
+// Total returns the number of sessions across all pages, or -1 if the
+// server did not report it or no page has been fetched yet.
+func (it *SessionsIterator) Total() int {
+	return it.total()
+}
@@ -0,0 +1,156 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindingsIterator(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		w.Header().Set("X-Total-Count", "3")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Header().Set("X-Next-Cursor", "c2")
+			w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+		case "c2":
+			w.Write([]byte(`[{"id": 3}]`))
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	it := NewClient(server.URL).IterateFindings(context.Background(), &FindingsFilter{
+		ListOptions: ListOptions{Limit: 2},
+		ProjectID:   42,
+	})
+	if it.Total() != -1 {
+		t.Errorf("Expected unknown total before the first page")
+	}
+
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Finding().ID)
+		if len(ids) == 1 && len(requests) != 1 {
+			t.Errorf("Expected pages to be fetched lazily, got %d requests", len(requests))
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ids) != 3 || ids[2] != 3 {
+		t.Errorf("Expected findings 1-3, got %v", ids)
+	}
+	if len(requests) != 2 || requests[0] != "limit=2&project_id=42" || requests[1] != "cursor=c2&limit=2&project_id=42" {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+	if it.Total() != 3 {
+		t.Errorf("Expected total 3, got %d", it.Total())
+	}
+	if it.Next() {
+		t.Errorf("Expected iteration to stay finished")
+	}
+}
+
+func TestFindingsIteratorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	it := NewClient(server.URL).IterateFindings(context.Background(), nil)
+	if it.Next() {
+		t.Fatal("Expected no findings")
+	}
+	if !errors.Is(it.Err(), ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", it.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = NewClient(server.URL).IterateFindings(ctx, nil)
+	if it.Next() || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestProjectsAndSessionsIterators(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `<`+r.URL.Path+`?cursor=p2>; rel="next"`)
+			w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+			return
+		}
+		w.Write([]byte(`[{"id": 3}]`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	projects := client.IterateProjects(context.Background(), &ListOptions{Limit: 2})
+	var ids []int64
+	for projects.Next() {
+		ids = append(ids, projects.Project().ID)
+	}
+	if projects.Err() != nil || len(ids) != 3 {
+		t.Errorf("Expected projects 1-3, got %v (%v)", ids, projects.Err())
+	}
+	if projects.Total() != -1 {
+		t.Errorf("Expected unknown total, got %d", projects.Total())
+	}
+
+	sessions := client.IterateSessions(context.Background(), 42, nil)
+	ids = nil
+	for sessions.Next() {
+		ids = append(ids, sessions.Session().ID)
+	}
+	if sessions.Err() != nil || len(ids) != 3 {
+		t.Errorf("Expected sessions 1-3, got %v (%v)", ids, sessions.Err())
+	}
+
+	want := []string{
+		"/projects?limit=2",
+		"/projects?cursor=p2&limit=2",
+		"/projects/42/sessions",
+		"/projects/42/sessions?cursor=p2",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("Expected requests %v, got %v", want, requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("Expected request %s, got %s", want[i], requests[i])
+		}
+	}
+}
+
+func TestIteratorRepeatedCursor(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Next-Cursor", "c2")
+		w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer server.Close()
+
+	it := NewClient(server.URL).IterateProjects(context.Background(), nil)
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Err() == nil {
+		t.Error("Expected error for a repeated cursor")
+	}
+	if count != 2 || requests != 2 {
+		t.Errorf("Expected two pages, got %d projects in %d requests", count, requests)
+	}
+	if it.Total() != -1 {
+		t.Errorf("Expected unknown total without X-Total-Count, got %d", it.Total())
+	}
+}
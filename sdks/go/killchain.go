@@ -0,0 +1,42 @@
+package aiptx
+
+import "strings"
+
+// =============================================================================
+// Kill Chain
+// =============================================================================
+
+// KillChainStage is the stage of the attack kill chain a finding belongs to.
+// Reports use it to tell the story of an attack from reconnaissance to
+// exfiltration.
+type KillChainStage string
+
+// Kill chain stages, in attack order.
+const (
+	StageRecon         KillChainStage = "recon"
+	StageWeaponization KillChainStage = "weaponization"
+	StageExploitation  KillChainStage = "exploitation"
+	StageC2            KillChainStage = "c2"
+	StageExfiltration  KillChainStage = "exfil"
+)
+
+// killChainOrder lists the stages in attack order.
+var killChainOrder = []KillChainStage{
+	StageRecon,
+	StageWeaponization,
+	StageExploitation,
+	StageC2,
+	StageExfiltration,
+}
+
+// parseKillChainStage returns the stage named s, ignoring case, and whether
+// it is known.
+func parseKillChainStage(s string) (KillChainStage, bool) {
+	stage := KillChainStage(strings.ToLower(s))
+	for _, known := range killChainOrder {
+		if stage == known {
+			return stage, true
+		}
+	}
+	return stage, false
+}
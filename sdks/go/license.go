@@ -0,0 +1,72 @@
+package aiptx
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// License
+// =============================================================================
+
+// Plan tiers.
+const (
+	PlanCommunity    = "community"
+	PlanProfessional = "professional"
+	PlanEnterprise   = "enterprise"
+)
+
+// License describes the server's license and the limits of its plan.
+type License struct {
+	Plan               string    `json:"plan"`
+	Licensee           string    `json:"licensee"`
+	Seats              int       `json:"seats"`
+	SeatsUsed          int       `json:"seats_used"`
+	MaxConcurrentScans int       `json:"max_concurrent_scans"`
+	Features           []string  `json:"features,omitempty"`
+	IssuedAt           time.Time `json:"issued_at"`
+	ExpiresAt          time.Time `json:"expires_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// SeatsAvailable returns the number of unused seats.
+func (l *License) SeatsAvailable() int {
+	return max(l.Seats-l.SeatsUsed, 0)
+}
+
+// Expired reports whether the license has expired. Licenses without an
+// expiry never expire.
+func (l *License) Expired() bool {
+	return !l.ExpiresAt.IsZero() && !time.Now().Before(l.ExpiresAt)
+}
+
+// ExpiresWithin reports whether the license expires in less than d, such
+// as before an engagement ends. It is true for expired licenses.
+func (l *License) ExpiresWithin(d time.Duration) bool {
+	return !l.ExpiresAt.IsZero() && time.Until(l.ExpiresAt) < d
+}
+
+// HasFeature reports whether the plan includes the named feature.
+func (l *License) HasFeature(name string) bool {
+	for _, f := range l.Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLicense returns the server's license and plan limits.
+func (c *Client) GetLicense() (*License, error) {
+	return c.GetLicenseContext(context.Background())
+}
+
+// GetLicenseContext is like GetLicense but uses ctx for its request.
+func (c *Client) GetLicenseContext(ctx context.Context) (*License, error) {
+	var license License
+	if err := c.requestContext(ctx, "GET", "/license", nil, &license); err != nil {
+		return nil, err
+	}
+	return &license, nil
+}
@@ -0,0 +1,55 @@
+package aiptx
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetLicense(t *testing.T) {
+	expires := time.Now().Add(10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/license" {
+			t.Errorf("Expected path /license, got %s", r.URL.Path)
+		}
+		fmt.Fprintf(w, `{"plan": "professional", "licensee": "Acme", "seats": 10, "seats_used": 8,
+			"max_concurrent_scans": 4, "features": ["sso", "exploit"], "expires_at": %q}`, expires)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	license, err := client.GetLicense()
+	if err != nil {
+		t.Fatalf("GetLicense failed: %v", err)
+	}
+	if license.Plan != PlanProfessional {
+		t.Errorf("Expected plan professional, got %s", license.Plan)
+	}
+	if license.SeatsAvailable() != 2 {
+		t.Errorf("Expected 2 seats available, got %d", license.SeatsAvailable())
+	}
+	if license.MaxConcurrentScans != 4 {
+		t.Errorf("Expected 4 concurrent scans, got %d", license.MaxConcurrentScans)
+	}
+	if license.Expired() {
+		t.Errorf("Expected license not to be expired")
+	}
+	if !license.ExpiresWithin(30 * 24 * time.Hour) {
+		t.Errorf("Expected license to expire within 30 days")
+	}
+	if license.ExpiresWithin(7 * 24 * time.Hour) {
+		t.Errorf("Expected license not to expire within 7 days")
+	}
+	if !license.HasFeature("sso") || license.HasFeature("graphql") {
+		t.Errorf("Expected features [sso exploit], got %v", license.Features)
+	}
+}
+
+func TestLicenseWithoutExpiry(t *testing.T) {
+	license := &License{Plan: PlanEnterprise}
+	if license.Expired() || license.ExpiresWithin(365*24*time.Hour) {
+		t.Errorf("Expected license without expiry never to expire")
+	}
+}
@@ -0,0 +1,78 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Scan Limits
+// =============================================================================
+
+// ScanLimits caps the resources of a scan, so one heavy scan cannot starve
+// the shared scanner pool. Zero fields are unlimited, up to the server's own
+// limits.
+type ScanLimits struct {
+	// CPU is the number of CPU cores, which may be fractional.
+	CPU float64 `json:"cpu,omitempty"`
+	// MemoryMB is the memory limit in megabytes.
+	MemoryMB int `json:"memory_mb,omitempty"`
+	// MaxDurationSeconds is how long the scan may run before it is
+	// aborted with status ScanAbortedTimeout.
+	MaxDurationSeconds int `json:"max_duration_seconds,omitempty"`
+	// MaxParallelTools is how many tools may run at once.
+	MaxParallelTools int `json:"max_parallel_tools,omitempty"`
+}
+
+// MaxDuration returns how long the scan may run, or zero if it is
+// unlimited.
+func (l *ScanLimits) MaxDuration() time.Duration {
+	return time.Duration(l.MaxDurationSeconds) * time.Second
+}
+
+// withMaxDuration returns r with MaxDuration moved into its limits, which is
+// how the server receives it. A nil r is returned as is.
+func (r *ScanRequest) withMaxDuration() *ScanRequest {
+	if r == nil || r.MaxDuration <= 0 {
+		return r
+	}
+	req := *r
+	var limits ScanLimits
+	if r.Limits != nil {
+		limits = *r.Limits
+	}
+	limits.MaxDurationSeconds = int((r.MaxDuration + time.Second - 1) / time.Second)
+	req.Limits = &limits
+	return &req
+}
+
+func (l *ScanLimits) validate() error {
+	if l.CPU < 0 || l.MemoryMB < 0 || l.MaxDurationSeconds < 0 || l.MaxParallelTools < 0 {
+		return fmt.Errorf("scan limits must not be negative")
+	}
+	return nil
+}
+
+// UpdateScanLimits changes the resource limits of a queued or running scan
+// and returns its status. Fields of limits that are zero are left
+// unchanged.
+func (c *Client) UpdateScanLimits(scanID string, limits *ScanLimits) (*ScanStatus, error) {
+	return c.UpdateScanLimitsContext(context.Background(), scanID, limits)
+}
+
+// UpdateScanLimitsContext is like UpdateScanLimits but uses ctx for its
+// request.
+func (c *Client) UpdateScanLimitsContext(ctx context.Context, scanID string, limits *ScanLimits) (*ScanStatus, error) {
+	if limits == nil {
+		return nil, fmt.Errorf("scan limits are nil")
+	}
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+	var status ScanStatus
+	if err := c.requestContext(ctx, "PATCH", fmt.Sprintf("/scans/%s/limits", scanID), limits, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
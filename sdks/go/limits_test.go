@@ -0,0 +1,69 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUpdateScanLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/scans/scan-1/limits" {
+			t.Errorf("Expected PATCH /scans/scan-1/limits, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["max_parallel_tools"] != float64(2) {
+			t.Errorf("Expected max_parallel_tools 2, got %v", body["max_parallel_tools"])
+		}
+		if _, ok := body["cpu"]; ok {
+			t.Errorf("Expected unset cpu to be omitted")
+		}
+		w.Write([]byte(`{"id": "scan-1", "status": "running",
+			"limits": {"cpu": 2, "memory_mb": 4096, "max_duration_seconds": 7200, "max_parallel_tools": 2}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	status, err := client.UpdateScanLimits("scan-1", &ScanLimits{MaxParallelTools: 2})
+	if err != nil {
+		t.Fatalf("UpdateScanLimits failed: %v", err)
+	}
+	if status.Limits == nil || status.Limits.MemoryMB != 4096 || status.Limits.MaxDuration() != 2*time.Hour {
+		t.Errorf("Unexpected limits %+v", status.Limits)
+	}
+
+	if _, err := client.UpdateScanLimits("scan-1", &ScanLimits{CPU: -1}); err == nil {
+		t.Errorf("Expected error for negative limits")
+	}
+	if _, err := client.UpdateScanLimits("scan-1", nil); err == nil {
+		t.Errorf("Expected error for nil limits")
+	}
+}
+
+func TestScanRequestMaxDuration(t *testing.T) {
+	limits := &ScanLimits{CPU: 1, MaxDurationSeconds: 60}
+	req := &ScanRequest{Target: "example.com", Limits: limits, MaxDuration: 90*time.Minute + time.Millisecond, AbortOnPhaseTimeout: true}
+	data, err := json.Marshal(req.withMaxDuration())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"target":"example.com","limits":{"cpu":1,"max_duration_seconds":5401},"abort_on_phase_timeout":true}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+	if limits.MaxDurationSeconds != 60 {
+		t.Errorf("Expected request limits to be left unchanged, got %d", limits.MaxDurationSeconds)
+	}
+
+	data, _ = json.Marshal((&ScanRequest{Target: "example.com"}).withMaxDuration())
+	if string(data) != `{"target":"example.com"}` {
+		t.Errorf("Expected only target, got %s", data)
+	}
+
+	if req := (*ScanRequest)(nil).withMaxDuration(); req != nil {
+		t.Errorf("Expected nil request, got %+v", req)
+	}
+}
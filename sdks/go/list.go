@@ -0,0 +1,165 @@
+package aiptx
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// List Options
+// =============================================================================
+
+// SortOrder is the direction in which list results are sorted.
+type SortOrder string
+
+// Sort orders.
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// Fields that list results can be sorted by.
+const (
+	SortBySeverity     = "severity"
+	SortByCreatedAt    = "created_at"
+	SortByUpdatedAt    = "updated_at"
+	SortByDiscoveredAt = "discovered_at"
+)
+
+// ListOptions contains options shared by the list endpoints. It is embedded
+// in FindingsFilter.
+type ListOptions struct {
+	// CreatedAfter restricts results to resources created after this time.
+	// Findings are filtered with FindingsFilter.DiscoveredAfter instead.
+	CreatedAfter time.Time
+
+	// SortBy names the field results are sorted by, e.g. SortBySeverity.
+	// SortOrder defaults to the server's order for that field.
+	SortBy    string
+	SortOrder SortOrder
+
+	// Fields limits the fields the server includes in each result. A field
+	// prefixed with "-" is excluded instead, e.g. "-raw_output". Omitted
+	// fields decode as zero values.
+	Fields []string
+
+	// Limit is the maximum number of results per page. Zero uses the
+	// server's default.
+	Limit int
+	// Cursor starts the page after the position returned as
+	// PageInfo.NextCursor by the previous page.
+	Cursor string
+
+	// OwnerTag restricts results to resources created by clients with this
+	// owner tag. See WithOwnerTag.
+	OwnerTag string
+}
+
+// values encodes the options as query parameters.
+func (o *ListOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if !o.CreatedAfter.IsZero() {
+		params.Add("created_after", o.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if o.SortBy != "" {
+		params.Add("sort_by", o.SortBy)
+	}
+	if o.SortOrder != "" {
+		params.Add("sort_order", string(o.SortOrder))
+	}
+	if len(o.Fields) > 0 {
+		params.Add("fields", strings.Join(o.Fields, ","))
+	}
+	if o.Limit > 0 {
+		params.Add("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		params.Add("cursor", o.Cursor)
+	}
+	if o.OwnerTag != "" {
+		params.Add("owner_tag", o.OwnerTag)
+	}
+	return params
+}
+
+// withQuery appends encoded params to path.
+func withQuery(path string, params url.Values) string {
+	if len(params) == 0 {
+		return path
+	}
+	return path + "?" + params.Encode()
+}
+
+// =============================================================================
+// Pages
+// =============================================================================
+
+// PageInfo describes a page of list results, from the X-Total-Count and Link
+// response headers.
+type PageInfo struct {
+	// Total is the number of results across all pages, or -1 if the server
+	// did not report it.
+	Total int
+	// HasNext reports whether there are more results after this page.
+	HasNext bool
+	// NextCursor is passed as ListOptions.Cursor to fetch the next page.
+	NextCursor string
+}
+
+// Pages returns the number of pages of size limit needed for all results,
+// or -1 if the total is unknown.
+func (p *PageInfo) Pages(limit int) int {
+	if p.Total < 0 || limit <= 0 {
+		return -1
+	}
+	return (p.Total + limit - 1) / limit
+}
+
+// listPage fetches one page of a list endpoint into out.
+func (c *Client) listPage(ctx context.Context, path string, out interface{}) (*PageInfo, error) {
+	resp, err := c.do(ctx, "GET", path, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	page := parsePageInfo(resp.Header)
+	if err := decodeResponse(resp, out, c.strictDecoding); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// parsePageInfo reads the pagination headers of a list response. The next
+// cursor is taken from the Link header with rel="next", falling back to
+// X-Next-Cursor.
+func parsePageInfo(h http.Header) *PageInfo {
+	page := &PageInfo{Total: -1}
+	if total, err := strconv.Atoi(h.Get("X-Total-Count")); err == nil && total >= 0 {
+		page.Total = total
+	}
+	for _, link := range h.Values("Link") {
+		for _, l := range strings.Split(link, ",") {
+			target, rel := parseLink(l)
+			if rel != "next" {
+				continue
+			}
+			page.HasNext = true
+			if u, err := url.Parse(target); err == nil {
+				page.NextCursor = u.Query().Get("cursor")
+			}
+		}
+	}
+	if cursor := h.Get("X-Next-Cursor"); cursor != "" {
+		page.HasNext = true
+		if page.NextCursor == "" {
+			page.NextCursor = cursor
+		}
+	}
+	return page
+}
@@ -0,0 +1,92 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListOptionsValues(t *testing.T) {
+	var opts *ListOptions
+	if len(opts.values()) != 0 {
+		t.Errorf("Expected no params for nil options")
+	}
+
+	since := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	opts = &ListOptions{CreatedAfter: since}
+	if got := opts.values().Get("created_after"); got != "2024-03-01T11:00:00Z" {
+		t.Errorf("Expected UTC timestamp, got %s", got)
+	}
+}
+
+func TestFindingsFilterSort(t *testing.T) {
+	filter := &FindingsFilter{
+		ListOptions: ListOptions{SortBy: SortBySeverity, SortOrder: SortDescending},
+		MinSeverity: SeverityHigh,
+	}
+	params := filter.values()
+	if params.Get("sort_by") != "severity" || params.Get("sort_order") != "desc" {
+		t.Errorf("Expected sort params, got %s", params.Encode())
+	}
+	if params.Get("min_severity") != "high" {
+		t.Errorf("Expected filter params to be kept, got %s", params.Encode())
+	}
+}
+
+func TestListOptionsFields(t *testing.T) {
+	opts := &ListOptions{Fields: []string{"-raw_output", "-extra_data"}}
+	if got := opts.values().Get("fields"); got != "-raw_output,-extra_data" {
+		t.Errorf("Expected comma-separated fields, got %s", got)
+	}
+}
+
+func TestListFindingsPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected limit=2, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("X-Total-Count", "5")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Header().Set("Link", `</findings?cursor=c2&limit=2>; rel="next"`)
+			w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+		case "c2":
+			w.Header().Set("X-Next-Cursor", "c3")
+			w.Write([]byte(`[{"id": 3}, {"id": 4}]`))
+		default:
+			w.Write([]byte(`[{"id": 5}]`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	filter := &FindingsFilter{ListOptions: ListOptions{Limit: 2}}
+	var ids []int64
+	for pages := 0; ; pages++ {
+		findings, page, err := client.ListFindingsPage(filter)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if page.Total != 5 || page.Pages(2) != 3 {
+			t.Errorf("Expected 5 results in 3 pages, got %+v", page)
+		}
+		for _, f := range findings {
+			ids = append(ids, f.ID)
+		}
+		if !page.HasNext {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	if len(ids) != 5 || ids[4] != 5 {
+		t.Errorf("Expected five findings, got %v", ids)
+	}
+}
+
+func TestPageInfoUnknownTotal(t *testing.T) {
+	page := parsePageInfo(http.Header{})
+	if page.Total != -1 || page.HasNext || page.Pages(10) != -1 {
+		t.Errorf("Expected unknown total and no next page, got %+v", page)
+	}
+}
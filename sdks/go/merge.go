@@ -0,0 +1,104 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Merging and Splitting Findings
+// =============================================================================
+
+// SplitSpec describes how to break a finding that reports several issues
+// into separate findings.
+type SplitSpec struct {
+	Parts []SplitPart `json:"parts"`
+	// Reason is recorded in the history of each finding.
+	Reason string `json:"reason,omitempty"`
+}
+
+// SplitPart is one finding created by SplitFinding. Empty fields are copied
+// from the original finding.
+type SplitPart struct {
+	Type        string   `json:"type,omitempty"`
+	Value       string   `json:"value,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Severity    Severity `json:"severity,omitempty"`
+	// EvidenceIDs moves the listed evidence of the original finding to
+	// this part.
+	EvidenceIDs []int64 `json:"evidence_ids,omitempty"`
+}
+
+// Finding history actions.
+const (
+	HistoryCreated   = "created"
+	HistoryMerged    = "merged"
+	HistoryMergedIn  = "merged_in"
+	HistorySplit     = "split"
+	HistorySplitFrom = "split_from"
+)
+
+// FindingHistoryEntry records a change to a finding, such as another finding
+// being merged into it.
+type FindingHistoryEntry struct {
+	Action string `json:"action"`
+	// RelatedIDs are the other findings involved, e.g. the findings merged
+	// in or the findings created by a split.
+	RelatedIDs []int64   `json:"related_ids,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// MergeFindings consolidates duplicate findings, such as the same issue
+// reported by different tools, into the finding with ID into. Their evidence
+// is moved to it and the merged findings are kept, with MergedInto set, so
+// their history is preserved. It returns the updated target finding.
+func (c *Client) MergeFindings(ids []int64, into int64) (*Finding, error) {
+	return c.MergeFindingsContext(context.Background(), ids, into)
+}
+
+// MergeFindingsContext is like MergeFindings but uses ctx for its request.
+func (c *Client) MergeFindingsContext(ctx context.Context, ids []int64, into int64) (*Finding, error) {
+	body := map[string][]int64{"finding_ids": ids}
+	var finding Finding
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/findings/%d/merge", into), body, &finding); err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+// SplitFinding breaks a finding into the parts described by spec and returns
+// the new findings. The original finding is kept, marked as split, so its
+// history is preserved.
+func (c *Client) SplitFinding(id int64, spec *SplitSpec) ([]Finding, error) {
+	return c.SplitFindingContext(context.Background(), id, spec)
+}
+
+// SplitFindingContext is like SplitFinding but uses ctx for its request.
+func (c *Client) SplitFindingContext(ctx context.Context, id int64, spec *SplitSpec) ([]Finding, error) {
+	if len(spec.Parts) < 2 {
+		return nil, fmt.Errorf("splitting finding %d needs at least 2 parts, got %d", id, len(spec.Parts))
+	}
+	var findings []Finding
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/findings/%d/split", id), spec, &findings); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// GetFindingHistory returns the changes made to a finding, oldest first.
+func (c *Client) GetFindingHistory(id int64) ([]FindingHistoryEntry, error) {
+	return c.GetFindingHistoryContext(context.Background(), id)
+}
+
+// GetFindingHistoryContext is like GetFindingHistory but uses ctx for its
+// request.
+func (c *Client) GetFindingHistoryContext(ctx context.Context, id int64) ([]FindingHistoryEntry, error) {
+	var history []FindingHistoryEntry
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/findings/%d/history", id), nil, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,53 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMergeAndSplitFindings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/findings/1/merge":
+			var body map[string][]int64
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body["finding_ids"]) != 2 || body["finding_ids"][1] != 3 {
+				t.Errorf("Expected findings 2 and 3, got %v", body)
+			}
+			w.Write([]byte(`{"id": 1}`))
+		case "/findings/1/split":
+			var spec SplitSpec
+			json.NewDecoder(r.Body).Decode(&spec)
+			if len(spec.Parts) != 2 || spec.Parts[1].EvidenceIDs[0] != 9 {
+				t.Errorf("Expected two parts, got %+v", spec)
+			}
+			w.Write([]byte(`[{"id": 10}, {"id": 11}]`))
+		case "/findings/2/history":
+			w.Write([]byte(`[{"action": "created"}, {"action": "merged", "related_ids": [1]}]`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	if f, err := client.MergeFindings([]int64{2, 3}, 1); err != nil || f.ID != 1 {
+		t.Errorf("Expected merged finding 1, got %+v (%v)", f, err)
+	}
+
+	if _, err := client.SplitFinding(1, &SplitSpec{Parts: []SplitPart{{Value: "a"}}}); err == nil {
+		t.Errorf("Expected error for a single part")
+	}
+	parts, err := client.SplitFinding(1, &SplitSpec{Parts: []SplitPart{
+		{Type: "xss", Value: "/search"},
+		{Type: "sqli", Value: "/login", EvidenceIDs: []int64{9}},
+	}})
+	if err != nil || len(parts) != 2 {
+		t.Errorf("Expected two findings, got %+v (%v)", parts, err)
+	}
+
+	history, err := client.GetFindingHistory(2)
+	if err != nil || len(history) != 2 || history[1].Action != HistoryMerged || history[1].RelatedIDs[0] != 1 {
+		t.Errorf("Expected merge in history, got %+v (%v)", history, err)
+	}
+}
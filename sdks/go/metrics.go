@@ -0,0 +1,85 @@
+package aiptx
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// Server Metrics
+// =============================================================================
+
+// ServerMetrics is a snapshot of the server's internal metrics, the same
+// values it exports to Prometheus.
+type ServerMetrics struct {
+	CollectedAt time.Time     `json:"collected_at"`
+	Queue       QueueMetrics  `json:"queue"`
+	Scans       ScanMetrics   `json:"scans"`
+	LLM         LLMMetrics    `json:"llm"`
+	Database    DBPoolMetrics `json:"database"`
+
+	unknown *rawFields
+}
+
+// QueueMetrics describes the scan queue.
+type QueueMetrics struct {
+	Depth            int     `json:"depth"`
+	OldestAgeSeconds float64 `json:"oldest_age_seconds"`
+}
+
+// OldestAge returns how long the oldest queued scan has waited.
+func (m QueueMetrics) OldestAge() time.Duration {
+	return time.Duration(m.OldestAgeSeconds * float64(time.Second))
+}
+
+// ScanMetrics counts scans. Completed and Failed are totals since the
+// server started.
+type ScanMetrics struct {
+	InFlight  int   `json:"in_flight"`
+	Completed int64 `json:"completed"`
+	Failed    int64 `json:"failed"`
+}
+
+// LLMMetrics describes requests to the LLM provider. Latencies are
+// percentiles over the server's recent requests.
+type LLMMetrics struct {
+	Requests     int64   `json:"requests"`
+	Errors       int64   `json:"errors"`
+	LatencyP50MS float64 `json:"latency_p50_ms"`
+	LatencyP95MS float64 `json:"latency_p95_ms"`
+	LatencyP99MS float64 `json:"latency_p99_ms"`
+}
+
+// DBPoolMetrics describes the server's database connection pool.
+type DBPoolMetrics struct {
+	MaxOpen     int     `json:"max_open"`
+	Open        int     `json:"open"`
+	InUse       int     `json:"in_use"`
+	Idle        int     `json:"idle"`
+	WaitCount   int64   `json:"wait_count"`
+	WaitSeconds float64 `json:"wait_seconds"`
+}
+
+// Utilization returns the fraction of the pool's maximum connections in
+// use, or 0 if the pool is unbounded.
+func (m DBPoolMetrics) Utilization() float64 {
+	if m.MaxOpen <= 0 {
+		return 0
+	}
+	return float64(m.InUse) / float64(m.MaxOpen)
+}
+
+// GetServerMetrics returns a snapshot of the server's internal metrics.
+func (c *Client) GetServerMetrics() (*ServerMetrics, error) {
+	return c.GetServerMetricsContext(context.Background())
+}
+
+// GetServerMetricsContext is like GetServerMetrics but uses ctx for its
+// request.
+func (c *Client) GetServerMetricsContext(ctx context.Context) (*ServerMetrics, error) {
+	var metrics ServerMetrics
+	if err := c.requestContext(ctx, "GET", "/metrics/server", nil, &metrics); err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
@@ -0,0 +1,42 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetServerMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics/server" {
+			t.Errorf("Expected path /metrics/server, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"collected_at": "2024-03-12T09:15:00Z",
+			"queue": {"depth": 7, "oldest_age_seconds": 90},
+			"scans": {"in_flight": 3, "completed": 1200, "failed": 14},
+			"llm": {"requests": 5000, "errors": 12, "latency_p50_ms": 850, "latency_p95_ms": 2400, "latency_p99_ms": 6100},
+			"database": {"max_open": 20, "open": 12, "in_use": 5, "idle": 7, "wait_count": 3, "wait_seconds": 0.4}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	metrics, err := client.GetServerMetrics()
+	if err != nil {
+		t.Fatalf("GetServerMetrics failed: %v", err)
+	}
+	if metrics.Queue.Depth != 7 || metrics.Queue.OldestAge() != 90*time.Second {
+		t.Errorf("Expected queue depth 7 aged 90s, got %+v", metrics.Queue)
+	}
+	if metrics.Scans.InFlight != 3 {
+		t.Errorf("Expected 3 scans in flight, got %d", metrics.Scans.InFlight)
+	}
+	if metrics.LLM.LatencyP95MS != 2400 {
+		t.Errorf("Expected LLM p95 2400ms, got %v", metrics.LLM.LatencyP95MS)
+	}
+	if metrics.Database.Utilization() != 0.25 {
+		t.Errorf("Expected pool utilization 0.25, got %v", metrics.Database.Utilization())
+	}
+}
@@ -0,0 +1,234 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Multi-Server Client
+// =============================================================================
+
+// MultiClient spreads work over several AIPTX servers, such as regional
+// deployments. Reads fan out to every server in parallel and tag results
+// with the server they came from. Writes to a project are routed to the
+// server that owns it, which is learned from ListProjects and CreateProject
+// or set with MapProject. Project IDs are only unique within a server, so a project ID
+// mapped to several servers cannot be routed; use Server(name).Project(id)
+// for those.
+//
+// A MultiClient is safe for concurrent use.
+type MultiClient struct {
+	servers map[string]*Client
+	names   []string
+
+	mu       sync.RWMutex
+	projects map[serverProjectID]bool
+}
+
+// serverProjectID identifies a project across servers.
+type serverProjectID struct {
+	server string
+	id     int64
+}
+
+// NewMultiClient returns a client for the given servers, keyed by a name
+// such as "eu" or "us". Later changes to servers do not affect it.
+func NewMultiClient(servers map[string]*Client) *MultiClient {
+	m := &MultiClient{servers: make(map[string]*Client, len(servers)), projects: map[serverProjectID]bool{}}
+	for name, client := range servers {
+		m.servers[name] = client
+		m.names = append(m.names, name)
+	}
+	sort.Strings(m.names)
+	return m
+}
+
+// Servers returns the server names in sorted order.
+func (m *MultiClient) Servers() []string {
+	return append([]string(nil), m.names...)
+}
+
+// Server returns the client for the named server, or nil if there is none.
+func (m *MultiClient) Server(name string) *Client {
+	return m.servers[name]
+}
+
+// MapProject routes writes to the project with the given ID to the named
+// server.
+func (m *MultiClient) MapProject(projectID int64, server string) error {
+	if m.servers[server] == nil {
+		return fmt.Errorf("unknown server %q", server)
+	}
+	m.mu.Lock()
+	m.projects[serverProjectID{server, projectID}] = true
+	m.mu.Unlock()
+	return nil
+}
+
+// Route returns the server a project is mapped to and its client. It fails
+// if the project ID is mapped to no server or to several.
+func (m *MultiClient) Route(projectID int64) (string, *Client, error) {
+	var servers []string
+	m.mu.RLock()
+	for _, name := range m.names {
+		if m.projects[serverProjectID{name, projectID}] {
+			servers = append(servers, name)
+		}
+	}
+	m.mu.RUnlock()
+	switch len(servers) {
+	case 0:
+		return "", nil, fmt.Errorf("project %d is not mapped to a server", projectID)
+	case 1:
+		return servers[0], m.servers[servers[0]], nil
+	}
+	return "", nil, fmt.Errorf("project %d is mapped to servers %s", projectID, strings.Join(servers, ", "))
+}
+
+// Project returns a client scoped to a mapped project on its server.
+func (m *MultiClient) Project(projectID int64) (*ProjectClient, error) {
+	_, client, err := m.Route(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return client.Project(projectID), nil
+}
+
+// CreateProject creates a project on the named server and maps it there.
+func (m *MultiClient) CreateProject(server string, data *ProjectCreate) (*Project, error) {
+	client := m.servers[server]
+	if client == nil {
+		return nil, fmt.Errorf("unknown server %q", server)
+	}
+	project, err := client.CreateProject(data)
+	if err != nil {
+		return nil, err
+	}
+	m.MapProject(project.ID, server)
+	return project, nil
+}
+
+// MultiError collects the errors of a fan-out, keyed by server name.
+type MultiError struct {
+	Errors map[string]error
+}
+
+func (e *MultiError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = name + ": " + e.Errors[name].Error()
+	}
+	return "servers failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the server errors, so errors.Is and errors.As match any
+// of them.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ServerProject is a project and the server it is on.
+type ServerProject struct {
+	Server string
+	Project
+}
+
+// ServerFinding is a finding and the server it is on.
+type ServerFinding struct {
+	Server string
+	Finding
+}
+
+// ListProjects returns the projects of every server and maps each to its
+// server. If some servers fail, the projects of the others are returned with
+// a *MultiError.
+func (m *MultiClient) ListProjects(ctx context.Context) ([]ServerProject, error) {
+	results := make([][]ServerProject, len(m.names))
+	err := m.fanOut(func(i int, client *Client) error {
+		projects, err := client.ListProjectsContext(ctx)
+		for _, p := range projects {
+			results[i] = append(results[i], ServerProject{Server: m.names[i], Project: p})
+			m.MapProject(p.ID, m.names[i])
+		}
+		return err
+	})
+	var all []ServerProject
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	return all, err
+}
+
+// ListFindings returns the findings matching filter on every server. If
+// filter sets a ProjectID, only the project's server is asked. If some
+// servers fail, the findings of the others are returned with a *MultiError.
+func (m *MultiClient) ListFindings(ctx context.Context, filter *FindingsFilter) ([]ServerFinding, error) {
+	if filter != nil && filter.ProjectID != 0 {
+		server, client, err := m.Route(filter.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		findings, err := client.ListFindingsContext(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all := make([]ServerFinding, len(findings))
+		for i, f := range findings {
+			all[i] = ServerFinding{Server: server, Finding: f}
+		}
+		return all, nil
+	}
+
+	results := make([][]ServerFinding, len(m.names))
+	err := m.fanOut(func(i int, client *Client) error {
+		findings, err := client.ListFindingsContext(ctx, filter)
+		for _, f := range findings {
+			results[i] = append(results[i], ServerFinding{Server: m.names[i], Finding: f})
+		}
+		return err
+	})
+	var all []ServerFinding
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	return all, err
+}
+
+// fanOut calls fn for every server in parallel with the server's index in
+// m.names and the server's client, and collects the errors.
+func (m *MultiClient) fanOut(fn func(i int, client *Client) error) error {
+	errs := make([]error, len(m.names))
+	var wg sync.WaitGroup
+	for i, name := range m.names {
+		wg.Add(1)
+		go func(i int, client *Client) {
+			defer wg.Done()
+			errs[i] = fn(i, client)
+		}(i, m.servers[name])
+	}
+	wg.Wait()
+
+	failed := &MultiError{Errors: map[string]error{}}
+	for i, err := range errs {
+		if err != nil {
+			failed.Errors[m.names[i]] = err
+		}
+	}
+	if len(failed.Errors) == 0 {
+		return nil
+	}
+	return failed
+}
@@ -0,0 +1,102 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMultiClient(t *testing.T) {
+	eu := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /findings":
+			w.Write([]byte(`[{"id": 1, "project_id": 10}, {"id": 2, "project_id": 10}]`))
+		case "GET /projects":
+			w.Write([]byte(`[{"id": 10, "name": "payments"}]`))
+		case "POST /projects":
+			w.Write([]byte(`{"id": 10, "name": "payments"}`))
+		case "POST /projects/10/sessions":
+			w.Write([]byte(`{"id": 5, "project_id": 10}`))
+		default:
+			t.Errorf("Unexpected eu request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer eu.Close()
+	us := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/findings":
+			w.Write([]byte(`[{"id": 1, "project_id": 10}]`))
+		case "/projects":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			t.Errorf("Unexpected us request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer us.Close()
+
+	servers := map[string]*Client{
+		"us": NewClient(us.URL),
+		"eu": NewClient(eu.URL),
+	}
+	multi := NewMultiClient(servers)
+	delete(servers, "eu")
+	if multi.Server("eu") == nil {
+		t.Errorf("Expected the servers to be copied")
+	}
+	ctx := context.Background()
+
+	findings, err := multi.ListFindings(ctx, nil)
+	if err != nil || len(findings) != 3 {
+		t.Fatalf("Expected findings from both servers, got %v (%v)", findings, err)
+	}
+	if findings[0].Server != "eu" || findings[2].Server != "us" || findings[2].ID != 1 {
+		t.Errorf("Expected findings tagged with their server, got %+v", findings)
+	}
+
+	if _, err := multi.Project(10); err == nil {
+		t.Errorf("Expected error for unmapped project")
+	}
+	if _, err := multi.CreateProject("eu", &ProjectCreate{Name: "payments"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	project, err := multi.Project(10)
+	if err != nil {
+		t.Fatalf("Expected project to be mapped, got %v", err)
+	}
+	if _, err := project.CreateSession(&SessionCreate{Name: "s"}); err != nil {
+		t.Errorf("Expected session to be created on eu, got %v", err)
+	}
+	if findings, err := multi.ListFindings(ctx, &FindingsFilter{ProjectID: 10}); err != nil || len(findings) != 2 {
+		t.Errorf("Expected findings of eu only, got %v (%v)", findings, err)
+	}
+
+	// Project IDs are per server, so the same ID on another server is
+	// ambiguous.
+	multi.MapProject(10, "us")
+	if _, err := multi.Project(10); err == nil {
+		t.Errorf("Expected error for project mapped to two servers")
+	}
+
+	projects, err := multi.ListProjects(ctx)
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) || len(multiErr.Errors) != 1 || multiErr.Errors["us"] == nil {
+		t.Fatalf("Expected us to fail, got %v", err)
+	}
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("Expected MultiError to unwrap to ErrServer, got %v", err)
+	}
+	if len(projects) != 1 || projects[0].Server != "eu" || projects[0].Name != "payments" {
+		t.Errorf("Expected the projects of eu, got %+v", projects)
+	}
+	// Listing projects maps them to their servers.
+	multi = NewMultiClient(map[string]*Client{"us": NewClient(us.URL), "eu": NewClient(eu.URL)})
+	multi.ListProjects(ctx)
+	if server, _, err := multi.Route(10); err != nil || server != "eu" {
+		t.Errorf("Expected listed project to be mapped to eu, got %q (%v)", server, err)
+	}
+	if findings, err := multi.ListFindings(ctx, &FindingsFilter{ProjectID: 10}); err != nil || len(findings) != 2 {
+		t.Errorf("Expected findings of eu only, got %v (%v)", findings, err)
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package aiptx
+
+const raceEnabled = false
@@ -0,0 +1,244 @@
+package aiptx
+
+import (
+	"crypto/tls"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Options
+// =============================================================================
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// Connection pool defaults. net/http keeps only two idle connections per host,
+// which forces constant reconnection when many goroutines talk to the single
+// AIPTX host.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 100
+)
+
+// newTransport returns the default transport used by NewClient.
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = DefaultMaxIdleConns
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	return t
+}
+
+// newHTTPClient returns the default HTTP client used by NewClient.
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: newTransport(),
+	}
+}
+
+// transport returns the client's *http.Transport, or nil if the client uses
+// a custom RoundTripper. Transport options have no effect in that case. A
+// nil Transport, which means http.DefaultTransport, is replaced by a copy of
+// it.
+//
+// A client created by With shares its parent's transport until a transport
+// option is applied to it; the transport is then copied so the parent and
+// its other clones are unaffected.
+func (c *Client) transport() *http.Transport {
+	if c.HTTPClient == nil {
+		return nil
+	}
+	if c.HTTPClient.Transport == nil {
+		t, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil
+		}
+		c.HTTPClient.Transport = t.Clone()
+		c.ownsTransport = true
+	}
+	t, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	if !c.ownsTransport {
+		t = t.Clone()
+		c.HTTPClient.Transport = t
+		c.ownsTransport = true
+	}
+	return t
+}
+
+// With returns a copy of the client with opts applied. The copy shares the
+// parent's connection pool, so creating one per request is cheap:
+//
+//	tenant := client.With(aiptx.WithAPIKey(customer.APIKey), aiptx.WithOrganization(customer.Org))
+//	findings, err := tenant.ListFindings(nil)
+func (c *Client) With(opts ...ClientOption) *Client {
+	c.lock().RLock()
+	clone := *c
+	if c.HTTPClient != nil {
+		httpClient := *c.HTTPClient
+		clone.HTTPClient = &httpClient
+	}
+	c.lock().RUnlock()
+	clone.mu = &sync.RWMutex{}
+	clone.ownsTransport = false
+	for _, opt := range opts {
+		opt(&clone)
+	}
+	return &clone
+}
+
+// WithAPIKey sets the API key sent as a bearer token.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) {
+		c.APIKey = apiKey
+	}
+}
+
+// WithBaseURL sets the server base URL, which may name a unix domain socket
+// as in NewClient.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.setBaseURL(baseURL)
+	}
+}
+
+// WithOrganization scopes requests to an organization on multi-tenant
+// servers. It is sent in the X-AIPTX-Organization header.
+func WithOrganization(org string) ClientOption {
+	return func(c *Client) {
+		c.Organization = org
+	}
+}
+
+// WithHTTPClient makes the client send requests with a copy of httpClient,
+// whose transport is left unmodified: transport options applied after it,
+// such as WithMaxIdleConns, configure a copy of the transport instead, and
+// have no effect if it is not an *http.Transport. A nil Transport stands for
+// http.DefaultTransport, as usual. Unix socket base URLs, WithDialer and the
+// DNS options still apply to the copy. A nil httpClient restores the default
+// client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient == nil {
+			c.HTTPClient = newHTTPClient()
+			c.ownsTransport = true
+		} else {
+			hc := *httpClient
+			c.HTTPClient = &hc
+			c.ownsTransport = false
+		}
+		if c.socketPath != "" || c.dial != nil || c.resolver != nil || c.dnsTTL > 0 {
+			c.configureDialer()
+		}
+	}
+}
+
+// WithLogger makes the client log warnings about the server, such as a
+// version outside the range this SDK supports, to logger. Like setting
+// Hooks.OnVersionSkew, it makes the client fetch the server version before
+// its first request.
+func WithLogger(logger *log.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// logf logs a warning to the client's logger, if it has one.
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, args...)
+	}
+}
+
+// WithTimeout sets the time limit for each request, including reading the
+// response body. Zero means no limit. The default is 30 seconds.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		if c.HTTPClient == nil {
+			httpClient := *http.DefaultClient
+			c.HTTPClient = &httpClient
+		}
+		c.HTTPClient.Timeout = d
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request, for
+// example to identify the application in server logs.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept open.
+func WithMaxIdleConns(n int) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.MaxIdleConns = n
+		}
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections kept
+// open to the server.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.MaxIdleConnsPerHost = n
+		}
+	}
+}
+
+// WithMaxConnsPerHost limits the total number of connections to the server,
+// including those in use. Requests beyond the limit wait for a free
+// connection. Zero means no limit.
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.MaxConnsPerHost = n
+		}
+	}
+}
+
+// WithIdleConnTimeout sets how long idle connections are kept open.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.IdleConnTimeout = d
+		}
+	}
+}
+
+// WithTLSSessionCache enables TLS session resumption with an LRU cache of
+// the given capacity, avoiding full handshakes on new connections.
+func WithTLSSessionCache(capacity int) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = &tls.Config{}
+			}
+			t.TLSClientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(capacity)
+		}
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2. HTTP/2 multiplexes concurrent
+// requests over a single connection; disabling it spreads them over a pool of
+// HTTP/1.1 connections instead, which some proxies handle better.
+func WithHTTP2(enabled bool) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.ForceAttemptHTTP2 = enabled
+			if enabled {
+				t.TLSNextProto = nil
+			} else {
+				t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransportOptions(t *testing.T) {
+	client := NewClient("",
+		WithMaxIdleConnsPerHost(500),
+		WithMaxConnsPerHost(1000),
+		WithIdleConnTimeout(time.Minute),
+		WithTLSSessionCache(64),
+		WithHTTP2(false),
+	)
+
+	tr := client.HTTPClient.Transport.(*http.Transport)
+	if tr.MaxIdleConnsPerHost != 500 || tr.MaxConnsPerHost != 1000 {
+		t.Errorf("Expected pool limits to be applied, got %d/%d", tr.MaxIdleConnsPerHost, tr.MaxConnsPerHost)
+	}
+	if tr.IdleConnTimeout != time.Minute {
+		t.Errorf("Expected idle timeout of 1m, got %s", tr.IdleConnTimeout)
+	}
+	if tr.TLSClientConfig == nil || tr.TLSClientConfig.ClientSessionCache == nil {
+		t.Errorf("Expected TLS session cache")
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Errorf("Expected HTTP/2 to be disabled")
+	}
+
+	if http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost == 500 {
+		t.Errorf("Options must not modify http.DefaultTransport")
+	}
+}
+
+func TestClientWith(t *testing.T) {
+	var auth, org string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, org = r.Header.Get("Authorization"), r.Header.Get("X-AIPTX-Organization")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	parent := NewClient(server.URL, WithAPIKey("service-key"))
+	tenant := parent.With(WithAPIKey("customer-key"), WithOrganization("acme"))
+
+	tenant.ListTools()
+	if auth != "Bearer customer-key" || org != "acme" {
+		t.Errorf("Expected tenant credentials, got %q %q", auth, org)
+	}
+	parent.ListTools()
+	if auth != "Bearer service-key" || org != "" {
+		t.Errorf("Expected parent credentials to be unchanged, got %q %q", auth, org)
+	}
+
+	if tenant.HTTPClient.Transport != parent.HTTPClient.Transport {
+		t.Errorf("Expected clone to share the transport")
+	}
+	tuned := parent.With(WithMaxConnsPerHost(7))
+	if tuned.HTTPClient.Transport == parent.HTTPClient.Transport {
+		t.Errorf("Expected transport options to copy the transport")
+	}
+	if parent.HTTPClient.Transport.(*http.Transport).MaxConnsPerHost == 7 {
+		t.Errorf("Transport options on a clone must not affect the parent")
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	var ua string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	custom := &http.Client{Timeout: time.Second}
+	client := NewClient(server.URL,
+		WithAPIKey("test-key"),
+		WithHTTPClient(custom),
+		WithTimeout(time.Minute),
+		WithUserAgent("scanner-bot/1.4"),
+	)
+	if client.APIKey != "test-key" {
+		t.Errorf("Expected API key 'test-key', got '%s'", client.APIKey)
+	}
+	if client.HTTPClient.Timeout != time.Minute {
+		t.Errorf("Expected timeout of 1m, got %s", client.HTTPClient.Timeout)
+	}
+	if custom.Timeout != time.Second {
+		t.Errorf("Options must not modify the caller's http.Client")
+	}
+
+	if _, err := client.Health(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ua != "scanner-bot/1.4" {
+		t.Errorf("Expected User-Agent 'scanner-bot/1.4', got '%s'", ua)
+	}
+}
@@ -0,0 +1,35 @@
+package aiptx
+
+import "net/http"
+
+// =============================================================================
+// Ownership
+// =============================================================================
+
+// WithOwnerTag tags the projects, sessions and scans the client creates,
+// such as "ci-pipeline-42", so automation can later find its own resources
+// with ListOptions.OwnerTag and clean them up. The server also records the
+// user or API key that created each resource in its CreatedBy field.
+func WithOwnerTag(tag string) ClientOption {
+	return func(c *Client) {
+		c.ownerTag = tag
+	}
+}
+
+// OwnerTag returns the owner tag set with WithOwnerTag.
+func (c *Client) OwnerTag() string {
+	return c.ownerTag
+}
+
+// ownerHeader returns the headers identifying the owner of a resource
+// being created: the client instance and its owner tag, if any.
+func (c *Client) ownerHeader() http.Header {
+	header := http.Header{}
+	if tag := c.InstanceTag(); tag != "" {
+		header.Set("X-AIPTX-Instance", tag)
+	}
+	if c.ownerTag != "" {
+		header.Set("X-AIPTX-Owner-Tag", c.ownerTag)
+	}
+	return header
+}
@@ -0,0 +1,72 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithOwnerTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			if tag := r.Header.Get("X-AIPTX-Owner-Tag"); tag != "ci-pipeline-42" {
+				t.Errorf("Expected owner tag 'ci-pipeline-42' on %s, got %q", r.URL.Path, tag)
+			}
+		}
+		switch r.URL.Path {
+		case "/projects":
+			if r.Method == "GET" {
+				if tag := r.URL.Query().Get("owner_tag"); tag != "ci-pipeline-42" {
+					t.Errorf("Expected owner_tag filter, got %q", tag)
+				}
+				w.Write([]byte(`[{"id": 1, "name": "p", "owner_tag": "ci-pipeline-42", "created_by": "ci-bot"}]`))
+				return
+			}
+			w.Write([]byte(`{"id": 1, "name": "p", "owner_tag": "ci-pipeline-42", "created_by": "ci-bot"}`))
+		case "/projects/1/sessions":
+			w.Write([]byte(`{"id": 2, "owner_tag": "ci-pipeline-42"}`))
+		case "/scan":
+			w.Write([]byte(`{"id": "scan-1", "status": "queued", "owner_tag": "ci-pipeline-42"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"), WithOwnerTag("ci-pipeline-42"))
+	if client.OwnerTag() != "ci-pipeline-42" {
+		t.Errorf("Expected owner tag 'ci-pipeline-42', got '%s'", client.OwnerTag())
+	}
+	project, err := client.CreateProject(&ProjectCreate{Name: "p", Target: "example.com"})
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if project.OwnerTag != "ci-pipeline-42" || project.CreatedBy != "ci-bot" {
+		t.Errorf("Unexpected ownership %q, %q", project.OwnerTag, project.CreatedBy)
+	}
+	if _, err := client.CreateSession(1, &SessionCreate{Name: "s"}); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+
+	projects, err := client.ListProjectsWithOptions(&ListOptions{OwnerTag: client.OwnerTag()})
+	if err != nil {
+		t.Fatalf("ListProjectsWithOptions failed: %v", err)
+	}
+	if len(projects) != 1 || projects[0].OwnerTag != "ci-pipeline-42" {
+		t.Errorf("Expected the tagged project, got %+v", projects)
+	}
+}
+
+func TestOwnerHeaderWithoutTag(t *testing.T) {
+	client := NewClient("")
+	header := client.ownerHeader()
+	if header.Get("X-AIPTX-Owner-Tag") != "" {
+		t.Errorf("Expected no owner tag header, got %q", header.Get("X-AIPTX-Owner-Tag"))
+	}
+	if header.Get("X-AIPTX-Instance") != client.InstanceTag() {
+		t.Errorf("Expected instance header %s, got %q", client.InstanceTag(), header.Get("X-AIPTX-Instance"))
+	}
+}
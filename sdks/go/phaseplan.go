@@ -0,0 +1,91 @@
+package aiptx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// =============================================================================
+// Phase Plans
+// =============================================================================
+
+// PhasePlan describes the flow of a scan: its stages run one after another,
+// and the phases within a stage run concurrently. Build one with Then:
+//
+//	plan := new(aiptx.PhasePlan).
+//	    Then(aiptx.PhaseConfig{Name: aiptx.PhaseRecon}).
+//	    Then(aiptx.PhaseConfig{Name: aiptx.PhaseScan, DenyTools: []string{"sqlmap"}},
+//	        aiptx.PhaseConfig{Name: aiptx.PhaseExploit, AllowTools: []string{"metasploit"}}).
+//	    Then(aiptx.PhaseConfig{Name: aiptx.PhaseReport})
+//
+// Phases left out of the plan do not run.
+type PhasePlan struct {
+	Stages []PhaseStage `json:"stages"`
+}
+
+// PhaseStage is a set of phases that run concurrently.
+type PhaseStage struct {
+	Phases []PhaseConfig `json:"phases"`
+}
+
+// PhaseConfig selects a phase and the tools it may use.
+type PhaseConfig struct {
+	Name Phase `json:"name"`
+	// AllowTools restricts the phase to these tools. If empty, every tool
+	// of the phase may run.
+	AllowTools []string `json:"allow_tools,omitempty"`
+	// DenyTools are tools the phase must not run.
+	DenyTools []string `json:"deny_tools,omitempty"`
+}
+
+// Then appends a stage running phases concurrently after the previous
+// stages, and returns p.
+func (p *PhasePlan) Then(phases ...PhaseConfig) *PhasePlan {
+	p.Stages = append(p.Stages, PhaseStage{Phases: phases})
+	return p
+}
+
+// Phase returns the configuration of the named phase, or nil if the plan
+// does not run it.
+func (p *PhasePlan) Phase(name Phase) *PhaseConfig {
+	for i := range p.Stages {
+		for j := range p.Stages[i].Phases {
+			if p.Stages[i].Phases[j].Name == name {
+				return &p.Stages[i].Phases[j]
+			}
+		}
+	}
+	return nil
+}
+
+func (p *PhasePlan) validate() error {
+	if len(p.Stages) == 0 {
+		return errors.New("phase plan has no stages")
+	}
+	seen := map[Phase]bool{}
+	for i, stage := range p.Stages {
+		if len(stage.Phases) == 0 {
+			return fmt.Errorf("phase plan stage %d has no phases", i+1)
+		}
+		for _, phase := range stage.Phases {
+			if phase.Name == "" {
+				return fmt.Errorf("phase plan stage %d has a phase without a name", i+1)
+			}
+			if !phase.Name.Valid() {
+				return fmt.Errorf("%w: unknown phase %q in phase plan", ErrInvalidValue, phase.Name)
+			}
+			if seen[phase.Name] {
+				return fmt.Errorf("phase %q appears more than once in the phase plan", phase.Name)
+			}
+			seen[phase.Name] = true
+			for _, tool := range phase.DenyTools {
+				for _, allowed := range phase.AllowTools {
+					if tool == allowed {
+						return fmt.Errorf("phase %q both allows and denies tool %q", phase.Name, tool)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStartScanPhasePlan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScanRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		plan := req.PhasePlan
+		if plan == nil || len(plan.Stages) != 2 || len(plan.Stages[1].Phases) != 2 {
+			t.Fatalf("Expected two stages, the second with two phases, got %+v", plan)
+		}
+		if scan := plan.Phase("scan"); scan == nil || len(scan.DenyTools) != 1 || scan.DenyTools[0] != "sqlmap" {
+			t.Errorf("Expected scan phase to deny sqlmap, got %+v", scan)
+		}
+		w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+	}))
+	defer server.Close()
+
+	plan := new(PhasePlan).
+		Then(PhaseConfig{Name: "recon"}).
+		Then(PhaseConfig{Name: "scan", DenyTools: []string{"sqlmap"}}, PhaseConfig{Name: "exploit", AllowTools: []string{"metasploit"}})
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com", PhasePlan: plan}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+}
+
+func TestPhasePlanValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		plan *PhasePlan
+	}{
+		{"empty", &PhasePlan{}},
+		{"empty stage", new(PhasePlan).Then()},
+		{"unnamed phase", new(PhasePlan).Then(PhaseConfig{})},
+		{"duplicate phase", new(PhasePlan).Then(PhaseConfig{Name: "recon"}).Then(PhaseConfig{Name: "recon"})},
+		{"allowed and denied", new(PhasePlan).Then(PhaseConfig{Name: "scan", AllowTools: []string{"nmap"}, DenyTools: []string{"nmap"}})},
+	}
+	for _, tt := range tests {
+		if err := tt.plan.validate(); err == nil {
+			t.Errorf("%s: Expected validation error", tt.name)
+		}
+	}
+
+	client := NewClient("http://127.0.0.1:0", WithAPIKey("test-key"))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com", PhasePlan: &PhasePlan{}}); err == nil {
+		t.Errorf("Expected StartScan to reject an invalid plan")
+	}
+	if new(PhasePlan).Phase("recon") != nil {
+		t.Errorf("Expected no config for a phase outside the plan")
+	}
+}
@@ -0,0 +1,250 @@
+// Package pipeline feeds AIPTX events, such as new findings, into message
+// queues and data lakes:
+//
+//	p := pipeline.New(client, &pipeline.NATSSink{Conn: nc, Subject: "aiptx"})
+//	p.Cursor = aiptx.NewFileCursorStore("/var/lib/aiptx/pipeline.cursor")
+//	if err := p.Run(ctx, aiptx.WithEventTypes(aiptx.EventFindingCreated)); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Events are published in batches and delivered at least once: the cursor is
+// saved only after the sink accepts a batch, so a batch that was being
+// published when the process died is published again after a restart.
+// Consumers should deduplicate by Message.ID.
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// Defaults for a Pipeline created with New.
+const (
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+	DefaultMaxRetries    = 5
+	DefaultRetryDelay    = time.Second
+	DefaultMaxRetryDelay = 30 * time.Second
+)
+
+// Message is an event prepared for a queue.
+type Message struct {
+	// ID is the event ID, which consumers can use to drop redeliveries.
+	ID string
+	// Key groups the messages of a project, for Kafka partitioning or SQS
+	// FIFO message groups. It is empty for events without a project.
+	Key  string
+	Type string
+	Time time.Time
+	// Value is the JSON-encoded aiptx.Event.
+	Value []byte
+}
+
+// Sink publishes batches of messages. Publish must return an error unless
+// every message was accepted; the whole batch is then retried.
+type Sink interface {
+	Publish(ctx context.Context, batch []Message) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, batch []Message) error
+
+// Publish calls f.
+func (f SinkFunc) Publish(ctx context.Context, batch []Message) error {
+	return f(ctx, batch)
+}
+
+// Pipeline reads the event stream of a client and publishes it to a sink.
+type Pipeline struct {
+	Client *aiptx.Client
+	Sink   Sink
+	// Cursor records the last published event, so a restarted pipeline
+	// resumes after it. Without one, the pipeline starts from the oldest
+	// event the server retains.
+	Cursor aiptx.CursorStore
+
+	// BatchSize is the most messages published at once, and FlushInterval
+	// the longest a message waits for its batch to fill.
+	BatchSize     int
+	FlushInterval time.Duration
+
+	// Rate limits publishing to this many messages per second, to protect
+	// the sink. Zero means no limit.
+	Rate float64
+
+	// MaxRetries is how many times a failed batch is retried before Run
+	// gives up. The delay starts at RetryDelay and doubles after each
+	// failure up to MaxRetryDelay.
+	MaxRetries    int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	next time.Time
+}
+
+// New returns a pipeline that publishes the events of client to sink.
+func New(client *aiptx.Client, sink Sink) *Pipeline {
+	return &Pipeline{
+		Client:        client,
+		Sink:          sink,
+		BatchSize:     DefaultBatchSize,
+		FlushInterval: DefaultFlushInterval,
+		MaxRetries:    DefaultMaxRetries,
+		RetryDelay:    DefaultRetryDelay,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+	}
+}
+
+// Run publishes events until ctx is cancelled, the event stream fails or a
+// batch cannot be published. Options such as aiptx.WithEventTypes are passed
+// to the stream. Events not yet published when Run returns are published by
+// the next run with the same Cursor.
+func (p *Pipeline) Run(ctx context.Context, opts ...aiptx.EventOption) error {
+	err := p.run(ctx, opts)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (p *Pipeline) run(ctx context.Context, opts []aiptx.EventOption) error {
+	if p.Cursor != nil {
+		cursor, err := p.Cursor.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("loading pipeline cursor: %w", err)
+		}
+		if cursor != "" {
+			opts = append(opts, aiptx.WithCursor(cursor))
+		}
+	}
+
+	streamCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	stream := p.Client.Events(streamCtx, opts...)
+	events := make(chan aiptx.Event)
+	go func() {
+		defer close(events)
+		for stream.Next() {
+			select {
+			case events <- stream.Event():
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var batch []Message
+	var cursor string
+	flush := func(ctx context.Context) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := p.publish(ctx, batch); err != nil {
+			return err
+		}
+		batch = nil
+		if p.Cursor != nil {
+			if err := p.Cursor.Save(ctx, cursor); err != nil {
+				return fmt.Errorf("saving pipeline cursor: %w", err)
+			}
+		}
+		return nil
+	}
+
+	timer := time.NewTimer(p.FlushInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				if err := flush(ctx); err != nil {
+					return err
+				}
+				return stream.Err()
+			}
+			if ev.Type == aiptx.EventReconnected {
+				continue
+			}
+			msg, err := NewMessage(ev)
+			if err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				timer.Reset(p.FlushInterval)
+			}
+			batch = append(batch, msg)
+			cursor = ev.ID
+			if len(batch) >= max(p.BatchSize, 1) {
+				if err := flush(ctx); err != nil {
+					return err
+				}
+			}
+		case <-timer.C:
+			if err := flush(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// NewMessage converts an event into a message.
+func NewMessage(ev aiptx.Event) (Message, error) {
+	value, err := json.Marshal(ev)
+	if err != nil {
+		return Message{}, fmt.Errorf("encoding event %s: %w", ev.ID, err)
+	}
+	msg := Message{ID: ev.ID, Type: ev.Type, Time: ev.Time, Value: value}
+	if ev.ProjectID != 0 {
+		msg.Key = "project-" + strconv.FormatInt(ev.ProjectID, 10)
+	}
+	return msg, nil
+}
+
+// publish waits for the rate limit and publishes batch, retrying failures.
+func (p *Pipeline) publish(ctx context.Context, batch []Message) error {
+	if wait := time.Until(p.next); wait > 0 {
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	delay := p.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err := p.Sink.Publish(ctx, batch)
+		if err == nil {
+			break
+		}
+		if attempt >= p.MaxRetries {
+			return fmt.Errorf("publishing %d messages: %w", len(batch), err)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+		if delay *= 2; p.MaxRetryDelay > 0 && delay > p.MaxRetryDelay {
+			delay = p.MaxRetryDelay
+		}
+	}
+
+	if p.Rate > 0 {
+		p.next = time.Now().Add(time.Duration(float64(len(batch)) / p.Rate * float64(time.Second)))
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
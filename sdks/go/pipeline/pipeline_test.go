@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestPipeline(t *testing.T) {
+	cursors := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors <- cursor
+		if cursor == "" {
+			w.Write([]byte(`{"events": [
+				{"id": "e1", "type": "finding.created", "project_id": 1},
+				{"id": "e2", "type": "finding.created", "project_id": 1},
+				{"id": "e3", "type": "scan.started", "project_id": 2},
+				{"id": "e4", "type": "finding.created", "project_id": 2},
+				{"id": "e5", "type": "session.completed"}
+			]}`))
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(50 * time.Millisecond):
+		}
+		w.Write([]byte(`{"events": []}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var batches [][]Message
+	failures := 1
+	sink := SinkFunc(func(ctx context.Context, batch []Message) error {
+		if failures > 0 {
+			failures--
+			return errors.New("broker unavailable")
+		}
+		batches = append(batches, batch)
+		if batch[len(batch)-1].ID == "e5" {
+			cancel()
+		}
+		return nil
+	})
+
+	store := &aiptx.MemoryCursorStore{}
+	p := New(aiptx.NewClient(server.URL), sink)
+	p.Cursor = store
+	p.BatchSize = 2
+	p.FlushInterval = 20 * time.Millisecond
+	p.RetryDelay = time.Millisecond
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(batches) != 3 || len(batches[0]) != 2 || len(batches[1]) != 2 || len(batches[2]) != 1 {
+		t.Fatalf("Expected batches of 2, 2 and 1, got %v", batches)
+	}
+	first := batches[0][0]
+	if first.ID != "e1" || first.Type != aiptx.EventFindingCreated || first.Key != "project-1" {
+		t.Errorf("Unexpected message %+v", first)
+	}
+	var ev aiptx.Event
+	if err := json.Unmarshal(first.Value, &ev); err != nil || ev.ID != "e1" {
+		t.Errorf("Expected the encoded event, got %s", first.Value)
+	}
+	if batches[2][0].Key != "" {
+		t.Errorf("Expected no key for an event without a project, got %q", batches[2][0].Key)
+	}
+	if cursor, _ := store.Load(context.Background()); cursor != "e5" {
+		t.Errorf("Expected cursor e5 after publishing, got %q", cursor)
+	}
+
+	// A restarted pipeline resumes after the last published event.
+	for len(cursors) > 0 {
+		<-cursors
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		if cursor := <-cursors; cursor != "e5" {
+			t.Errorf("Expected restart from e5, got %q", cursor)
+		}
+		cancel()
+	}()
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+}
+
+func TestPipelineGivesUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"events": [{"id": "e1", "type": "finding.created"}]}`))
+	}))
+	defer server.Close()
+
+	attempts := 0
+	p := New(aiptx.NewClient(server.URL), SinkFunc(func(ctx context.Context, batch []Message) error {
+		attempts++
+		return errors.New("broker unavailable")
+	}))
+	p.BatchSize = 1
+	p.MaxRetries = 2
+	p.RetryDelay = time.Millisecond
+	if err := p.Run(context.Background()); err == nil {
+		t.Fatal("Expected error after retries")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,134 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// =============================================================================
+// NATS
+// =============================================================================
+
+// NATSPublisher publishes a message to a NATS subject. *nats.Conn satisfies
+// it.
+type NATSPublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATSSink publishes each message to the subject Subject.<event type>, such
+// as aiptx.finding.created.
+type NATSSink struct {
+	Conn    NATSPublisher
+	Subject string
+}
+
+// Publish publishes the messages in order.
+func (s *NATSSink) Publish(ctx context.Context, batch []Message) error {
+	for _, msg := range batch {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.Conn.Publish(s.Subject+"."+msg.Type, msg.Value); err != nil {
+			return fmt.Errorf("nats: publishing %s: %w", msg.ID, err)
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// Kafka
+// =============================================================================
+
+// KafkaRecord is a record to produce to a Kafka topic.
+type KafkaRecord struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// KafkaProducer writes records to Kafka, returning once all are
+// acknowledged. It is implemented with a few lines around the producer of
+// any Kafka client library.
+type KafkaProducer interface {
+	Produce(ctx context.Context, records []KafkaRecord) error
+}
+
+// KafkaSink produces messages to a topic, keyed by project so the events of
+// a project stay in order within a partition.
+type KafkaSink struct {
+	Producer KafkaProducer
+	Topic    string
+}
+
+// Publish produces the batch.
+func (s *KafkaSink) Publish(ctx context.Context, batch []Message) error {
+	records := make([]KafkaRecord, len(batch))
+	for i, msg := range batch {
+		records[i] = KafkaRecord{
+			Topic:   s.Topic,
+			Key:     []byte(msg.Key),
+			Value:   msg.Value,
+			Headers: map[string]string{"aiptx-event-id": msg.ID, "aiptx-event-type": msg.Type},
+		}
+	}
+	if err := s.Producer.Produce(ctx, records); err != nil {
+		return fmt.Errorf("kafka: producing %d records: %w", len(records), err)
+	}
+	return nil
+}
+
+// =============================================================================
+// SQS
+// =============================================================================
+
+// sqsMaxBatch is the most messages SQS accepts in one SendMessageBatch call.
+const sqsMaxBatch = 10
+
+// SQSEntry is a message to send to an SQS queue.
+type SQSEntry struct {
+	// ID identifies the entry within its batch.
+	ID   string
+	Body string
+	// GroupID and DeduplicationID are set for FIFO queues.
+	GroupID         string
+	DeduplicationID string
+}
+
+// SQSSender sends up to ten entries to a queue, returning an error unless
+// all were accepted. It is implemented with a few lines around
+// SendMessageBatch of the AWS SDK.
+type SQSSender interface {
+	SendMessageBatch(ctx context.Context, queueURL string, entries []SQSEntry) error
+}
+
+// SQSSink sends messages to a queue in batches of ten.
+type SQSSink struct {
+	Client   SQSSender
+	QueueURL string
+	// FIFO sets message groups by project and deduplicates by event ID.
+	FIFO bool
+}
+
+// Publish sends the batch.
+func (s *SQSSink) Publish(ctx context.Context, batch []Message) error {
+	for start := 0; start < len(batch); start += sqsMaxBatch {
+		chunk := batch[start:min(start+sqsMaxBatch, len(batch))]
+		entries := make([]SQSEntry, len(chunk))
+		for i, msg := range chunk {
+			entries[i] = SQSEntry{ID: strconv.Itoa(i), Body: string(msg.Value)}
+			if s.FIFO {
+				entries[i].GroupID = msg.Key
+				if entries[i].GroupID == "" {
+					entries[i].GroupID = "aiptx"
+				}
+				entries[i].DeduplicationID = msg.ID
+			}
+		}
+		if err := s.Client.SendMessageBatch(ctx, s.QueueURL, entries); err != nil {
+			return fmt.Errorf("sqs: sending %d messages: %w", len(entries), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+)
+
+type natsConn struct{ subjects []string }
+
+func (c *natsConn) Publish(subject string, data []byte) error {
+	c.subjects = append(c.subjects, subject)
+	return nil
+}
+
+type sqsClient struct{ batches [][]SQSEntry }
+
+func (c *sqsClient) SendMessageBatch(ctx context.Context, queueURL string, entries []SQSEntry) error {
+	c.batches = append(c.batches, entries)
+	return nil
+}
+
+type kafkaProducer struct{ records []KafkaRecord }
+
+func (p *kafkaProducer) Produce(ctx context.Context, records []KafkaRecord) error {
+	p.records = append(p.records, records...)
+	return nil
+}
+
+func messages(n int) []Message {
+	batch := make([]Message, n)
+	for i := range batch {
+		batch[i] = Message{ID: "e" + string(rune('a'+i)), Type: "finding.created", Key: "project-1", Value: []byte(`{}`)}
+	}
+	batch[n-1].Type, batch[n-1].Key = "scan.started", ""
+	return batch
+}
+
+func TestNATSSink(t *testing.T) {
+	conn := &natsConn{}
+	sink := &NATSSink{Conn: conn, Subject: "aiptx"}
+	if err := sink.Publish(context.Background(), messages(2)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if len(conn.subjects) != 2 || conn.subjects[0] != "aiptx.finding.created" || conn.subjects[1] != "aiptx.scan.started" {
+		t.Errorf("Unexpected subjects %v", conn.subjects)
+	}
+}
+
+func TestKafkaSink(t *testing.T) {
+	producer := &kafkaProducer{}
+	sink := &KafkaSink{Producer: producer, Topic: "aiptx-events"}
+	if err := sink.Publish(context.Background(), messages(2)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	r := producer.records[0]
+	if len(producer.records) != 2 || r.Topic != "aiptx-events" || string(r.Key) != "project-1" || r.Headers["aiptx-event-id"] != "ea" {
+		t.Errorf("Unexpected records %+v", producer.records)
+	}
+}
+
+func TestSQSSink(t *testing.T) {
+	client := &sqsClient{}
+	sink := &SQSSink{Client: client, QueueURL: "https://sqs.example/queue.fifo", FIFO: true}
+	if err := sink.Publish(context.Background(), messages(23)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if len(client.batches) != 3 || len(client.batches[0]) != 10 || len(client.batches[2]) != 3 {
+		t.Fatalf("Expected batches of 10, 10 and 3, got %d", len(client.batches))
+	}
+	first, last := client.batches[0][0], client.batches[2][2]
+	if first.GroupID != "project-1" || first.DeduplicationID != "ea" || last.GroupID != "aiptx" {
+		t.Errorf("Unexpected FIFO attributes %+v %+v", first, last)
+	}
+}
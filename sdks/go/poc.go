@@ -0,0 +1,66 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Proof of Concept
+// =============================================================================
+
+// Proof-of-concept languages.
+const (
+	PoCBash   = "bash"
+	PoCCurl   = "curl"
+	PoCPython = "python"
+	PoCHTTP   = "http"
+)
+
+// PoC is the proof-of-concept script reproducing a finding, such as the
+// exploit code or curl command generated during the scan. It is kept with
+// the finding so the issue can be retested after a fix.
+type PoC struct {
+	FindingID int64  `json:"finding_id"`
+	Language  string `json:"language"`
+	Script    string `json:"script"`
+	SHA256    string `json:"sha256,omitempty"`
+	// GeneratedBy names the tool or model that wrote the script, if it was
+	// not uploaded by a user.
+	GeneratedBy string    `json:"generated_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	unknown *rawFields
+}
+
+// UploadPoC stores script, written in lang, as the proof of concept of a
+// finding, replacing any previous one.
+func (c *Client) UploadPoC(findingID int64, lang, script string) (*PoC, error) {
+	return c.UploadPoCContext(context.Background(), findingID, lang, script)
+}
+
+// UploadPoCContext is like UploadPoC but uses ctx for its request.
+func (c *Client) UploadPoCContext(ctx context.Context, findingID int64, lang, script string) (*PoC, error) {
+	body := map[string]string{"language": lang, "script": script}
+	var poc PoC
+	if err := c.requestContext(ctx, "PUT", fmt.Sprintf("/findings/%d/poc", findingID), body, &poc); err != nil {
+		return nil, c.featureError(ctx, err, FeatureExploitation)
+	}
+	return &poc, nil
+}
+
+// GetPoC returns the proof of concept of a finding. It returns an error
+// matching ErrNotFound if the finding has none.
+func (c *Client) GetPoC(findingID int64) (*PoC, error) {
+	return c.GetPoCContext(context.Background(), findingID)
+}
+
+// GetPoCContext is like GetPoC but uses ctx for its request.
+func (c *Client) GetPoCContext(ctx context.Context, findingID int64) (*PoC, error) {
+	var poc PoC
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/findings/%d/poc", findingID), nil, &poc); err != nil {
+		return nil, err
+	}
+	return &poc, nil
+}
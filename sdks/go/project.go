@@ -0,0 +1,112 @@
+package aiptx
+
+// =============================================================================
+// Project Client
+// =============================================================================
+
+// ProjectClient is a view of a Client scoped to a single project. Paths and
+// filters are scoped to the project automatically.
+type ProjectClient struct {
+	client *Client
+	id     int64
+}
+
+// Project returns a client scoped to the project with the given ID. It does
+// not contact the server.
+func (c *Client) Project(id int64) *ProjectClient {
+	return &ProjectClient{client: c, id: id}
+}
+
+// ID returns the project ID.
+func (p *ProjectClient) ID() int64 {
+	return p.id
+}
+
+// Get returns the project.
+func (p *ProjectClient) Get() (*Project, error) {
+	return p.client.GetProject(p.id)
+}
+
+// Update updates the project.
+func (p *ProjectClient) Update(data *ProjectCreate) (*Project, error) {
+	return p.client.UpdateProject(p.id, data)
+}
+
+// Delete deletes the project.
+func (p *ProjectClient) Delete() error {
+	return p.client.DeleteProject(p.id)
+}
+
+// Sessions returns the project's sessions.
+func (p *ProjectClient) Sessions() ([]Session, error) {
+	return p.client.ListSessions(p.id)
+}
+
+// CreateSession creates a session in the project.
+func (p *ProjectClient) CreateSession(data *SessionCreate) (*Session, error) {
+	return p.client.CreateSession(p.id, data)
+}
+
+// Findings returns the project's findings matching filter, which may be nil.
+// Any ProjectID in filter is replaced.
+func (p *ProjectClient) Findings(filter *FindingsFilter) ([]Finding, error) {
+	scoped := FindingsFilter{}
+	if filter != nil {
+		scoped = *filter
+	}
+	scoped.ProjectID = p.id
+	return p.client.ListFindings(&scoped)
+}
+
+// StartScan starts a scan within the project. Any ProjectID in req is
+// replaced.
+func (p *ProjectClient) StartScan(req *ScanRequest) (*ScanStatus, error) {
+	if req == nil {
+		return nil, errNilScanRequest
+	}
+	scoped := *req
+	scoped.ProjectID = p.id
+	return p.client.StartScan(&scoped)
+}
+
+// SetBaseline makes the scan with the given ID the project's baseline.
+func (p *ProjectClient) SetBaseline(scanID string) (*Baseline, error) {
+	return p.client.SetBaseline(p.id, scanID)
+}
+
+// Baseline returns the project's baseline.
+func (p *ProjectClient) Baseline() (*Baseline, error) {
+	return p.client.GetBaseline(p.id)
+}
+
+// AttackSurfaceScore returns the project's attack surface score.
+func (p *ProjectClient) AttackSurfaceScore() (*AttackSurfaceScore, error) {
+	return p.client.GetAttackSurfaceScore(p.id)
+}
+
+// Burndown returns the project's open findings by severity over time. opts
+// may be nil.
+func (p *ProjectClient) Burndown(opts *BurndownOptions) (*Burndown, error) {
+	return p.client.GetBurndown(p.id, opts)
+}
+
+// SLABreaches returns the project's findings that missed their SLA.
+func (p *ProjectClient) SLABreaches() ([]SLABreach, error) {
+	return p.client.ListSLABreaches(p.id)
+}
+
+// CreateReport starts generating a report for the project.
+func (p *ProjectClient) CreateReport(opts *ReportOptions) (*Report, error) {
+	return p.client.CreateReport(p.id, opts)
+}
+
+// Reports returns the project's reports.
+func (p *ProjectClient) Reports() ([]Report, error) {
+	return p.client.ListReports(p.id)
+}
+
+// HostReport returns the findings, services and credentials of one host of
+// the project.
+func (p *ProjectClient) HostReport(host string) (*HostReport, error) {
+	return p.client.GetHostReport(p.id, host)
+}
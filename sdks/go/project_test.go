@@ -0,0 +1,48 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProjectClient(t *testing.T) {
+	var findingsQuery string
+	var scan ScanRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/findings":
+			findingsQuery = r.URL.RawQuery
+			w.Write([]byte(`[]`))
+		case "/scan":
+			json.NewDecoder(r.Body).Decode(&scan)
+			w.Write([]byte(`{"id": "scan-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	filter := &FindingsFilter{ProjectID: 1, Tool: "nuclei"}
+	project := NewClient(server.URL).Project(42)
+
+	if _, err := project.Findings(filter); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if findingsQuery != "project_id=42&tool=nuclei" {
+		t.Errorf("Expected scoped query, got %s", findingsQuery)
+	}
+	if filter.ProjectID != 1 {
+		t.Errorf("Caller's filter must not be modified")
+	}
+
+	if _, err := project.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scan.ProjectID != 42 || scan.Target != "example.com" {
+		t.Errorf("Expected scoped scan request, got %+v", scan)
+	}
+
+	if _, err := project.StartScan(nil); err == nil {
+		t.Error("Expected error for nil scan request")
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package aiptx
+
+// raceEnabled reports whether the race detector is on. Its instrumentation
+// allocates, so allocation budgets are not checked under it.
+const raceEnabled = true
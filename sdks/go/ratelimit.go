@@ -0,0 +1,94 @@
+package aiptx
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Rate Limits
+// =============================================================================
+
+// RateLimit is the client's request quota as last reported by the server in
+// the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset
+// response headers.
+type RateLimit struct {
+	// Limit is the number of requests allowed per window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is when the current window ends and Remaining returns to Limit.
+	// It is zero if the server did not say.
+	Reset time.Time
+	// ObservedAt is when the response carrying these values was received.
+	ObservedAt time.Time
+}
+
+// Exhausted reports whether no requests were left at now, before the
+// window resets.
+func (r RateLimit) Exhausted(now time.Time) bool {
+	return r.Remaining <= 0 && (r.Reset.IsZero() || now.Before(r.Reset))
+}
+
+// rateLimit holds the latest RateLimit seen by a client. It is shared by
+// clients created with With.
+type rateLimit struct {
+	mu     sync.Mutex
+	latest RateLimit
+	seen   bool
+}
+
+// RateLimit returns the request quota reported with the client's latest
+// response, so schedulers can pace requests instead of running into 429
+// responses:
+//
+//	if rl, ok := client.RateLimit(); ok && rl.Exhausted(time.Now()) {
+//	    time.Sleep(time.Until(rl.Reset))
+//	}
+//
+// It returns false if no response has reported the quota yet.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	if c.rateLimit == nil {
+		return RateLimit{}, false
+	}
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	return c.rateLimit.latest, c.rateLimit.seen
+}
+
+// recordRateLimit records the quota reported by a response, if any.
+func (c *Client) recordRateLimit(resp *http.Response) {
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	if remaining == "" || c.rateLimit == nil {
+		return
+	}
+	now := time.Now()
+	rl := RateLimit{ObservedAt: now}
+	rl.Remaining, _ = strconv.Atoi(remaining)
+	rl.Limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	rl.Reset = parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now)
+
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	// Responses to concurrent requests may arrive out of order; keep the
+	// newest.
+	if !c.rateLimit.latest.ObservedAt.After(now) {
+		c.rateLimit.latest, c.rateLimit.seen = rl, true
+	}
+}
+
+// parseRateLimitReset parses an X-RateLimit-Reset header, which servers
+// give either as a Unix time or as seconds from now.
+func parseRateLimitReset(s string, now time.Time) time.Time {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}
+	}
+	// No window lasts until 2001, so smaller values are relative.
+	if n < 1e9 {
+		return now.Add(time.Duration(n) * time.Second)
+	}
+	return time.Unix(n, 0)
+}
@@ -0,0 +1,62 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	remaining := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tools" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, ok := client.RateLimit(); ok {
+		t.Errorf("Expected no rate limit before the first response")
+	}
+
+	client.Health()
+	rl, ok := client.RateLimit()
+	if !ok || rl.Limit != 100 || rl.Remaining != 2 || !rl.Reset.Equal(reset) {
+		t.Errorf("Expected 2 of 100 requests left until %s, got %+v", reset, rl)
+	}
+	if rl.Exhausted(time.Now()) {
+		t.Errorf("Expected quota not to be exhausted")
+	}
+
+	// Responses without the headers leave the last values, and clients
+	// created with With share them.
+	remaining = 0
+	client.With(WithAPIKey("other")).Health()
+	client.ListTools()
+	rl, _ = client.RateLimit()
+	if rl.Remaining != 0 || !rl.Exhausted(time.Now()) || rl.Exhausted(reset) {
+		t.Errorf("Expected quota to be exhausted until %s, got %+v", reset, rl)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	if got := parseRateLimitReset("30", now); !got.Equal(now.Add(30 * time.Second)) {
+		t.Errorf("Expected relative reset, got %s", got)
+	}
+	if got := parseRateLimitReset("1700000060", now); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected absolute reset, got %s", got)
+	}
+	if got := parseRateLimitReset("soon", now); !got.IsZero() {
+		t.Errorf("Expected zero reset, got %s", got)
+	}
+}
@@ -0,0 +1,54 @@
+package aiptx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// =============================================================================
+// Read-Only Mode
+// =============================================================================
+
+// ErrReadOnlyClient is returned for requests that could change server state,
+// such as starting a scan, made with a client created with WithReadOnly.
+var ErrReadOnlyClient = errors.New("client is read-only")
+
+// WithReadOnly makes the client refuse every request with a method other
+// than GET, HEAD or OPTIONS before it is sent, returning an error wrapping
+// ErrReadOnlyClient. Use it for dashboards and analyst tooling that must be
+// incapable of launching scans or changing findings, whatever the
+// permissions of their API key. Clients created from a read-only client
+// with With are read-only too.
+//
+// The GraphQL API, which sends queries with POST, is unavailable to
+// read-only clients.
+func WithReadOnly() ClientOption {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// ReadOnly reports whether the client was created with WithReadOnly.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly returns an error if a read-only client would send a request
+// with method.
+func (c *Client) checkReadOnly(method, path string) error {
+	if !c.readOnly || !mutating(method) {
+		return nil
+	}
+	return fmt.Errorf("%w: refusing %s %s", ErrReadOnlyClient, method, path)
+}
+
+// mutating reports whether a request with method could change server
+// state.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package aiptx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithReadOnly())
+	if !client.ReadOnly() || NewClient(server.URL).ReadOnly() {
+		t.Errorf("Expected only the WithReadOnly client to be read-only")
+	}
+	if _, err := client.ListProjects(); err != nil {
+		t.Fatalf("Expected reads to be allowed, got %v", err)
+	}
+
+	_, err := client.StartScan(&ScanRequest{Target: "example.com"})
+	if !errors.Is(err, ErrReadOnlyClient) {
+		t.Errorf("Expected ErrReadOnlyClient, got %v", err)
+	}
+	if err := client.With(WithAPIKey("admin-key")).DeleteProject(1); !errors.Is(err, ErrReadOnlyClient) {
+		t.Errorf("Expected clients derived with With to stay read-only, got %v", err)
+	}
+	if _, err := client.UpdateRemediation(1, &RemediationUpdate{Status: RemediationFixed}); !errors.Is(err, ErrReadOnlyClient) {
+		t.Errorf("Expected ErrReadOnlyClient for PATCH, got %v", err)
+	}
+
+	if len(methods) != 1 || methods[0] != "GET" {
+		t.Errorf("Expected only the GET to reach the server, got %v", methods)
+	}
+}
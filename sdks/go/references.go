@@ -0,0 +1,59 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// =============================================================================
+// Finding References
+// =============================================================================
+
+// Reference links a finding to external material, such as an advisory, CVE
+// entry or vendor fix, instead of pasting links into its description.
+type Reference struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+	// Source names the publisher, such as "nvd", "github" or "vendor".
+	Source string `json:"source,omitempty"`
+}
+
+// AddFindingReference adds ref to the references of a finding and returns
+// the updated finding. ref.URL must be an absolute http or https URL; a
+// reference with the same URL is replaced.
+func (c *Client) AddFindingReference(findingID int64, ref Reference) (*Finding, error) {
+	return c.AddFindingReferenceContext(context.Background(), findingID, ref)
+}
+
+// AddFindingReferenceContext is like AddFindingReference but uses ctx for its
+// request.
+func (c *Client) AddFindingReferenceContext(ctx context.Context, findingID int64, ref Reference) (*Finding, error) {
+	u, err := url.Parse(ref.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: reference URL %q is not an http or https URL", ErrInvalidValue, ref.URL)
+	}
+	var finding Finding
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/findings/%d/references", findingID), ref, &finding); err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+// RemoveFindingReference removes the reference with the given URL from a
+// finding and returns the updated finding. It returns an error matching
+// ErrNotFound if the finding has no such reference.
+func (c *Client) RemoveFindingReference(findingID int64, refURL string) (*Finding, error) {
+	return c.RemoveFindingReferenceContext(context.Background(), findingID, refURL)
+}
+
+// RemoveFindingReferenceContext is like RemoveFindingReference but uses ctx for its
+// request.
+func (c *Client) RemoveFindingReferenceContext(ctx context.Context, findingID int64, refURL string) (*Finding, error) {
+	path := withQuery(fmt.Sprintf("/findings/%d/references", findingID), url.Values{"url": {refURL}})
+	var finding Finding
+	if err := c.requestContext(ctx, "DELETE", path, nil, &finding); err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
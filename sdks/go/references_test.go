@@ -0,0 +1,52 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindingReferences(t *testing.T) {
+	refs := []Reference{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/findings/7/references" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case "POST":
+			var ref Reference
+			json.NewDecoder(r.Body).Decode(&ref)
+			refs = append(refs, ref)
+		case "DELETE":
+			if r.URL.Query().Get("url") != refs[0].URL {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			refs = refs[1:]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "references": refs})
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	ref := Reference{URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228", Title: "CVE-2021-44228", Source: "nvd"}
+	finding, err := client.AddFindingReference(7, ref)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(finding.References) != 1 || finding.References[0] != ref {
+		t.Errorf("Expected reference %+v, got %+v", ref, finding.References)
+	}
+
+	finding, err = client.RemoveFindingReference(7, ref.URL)
+	if err != nil || len(finding.References) != 0 {
+		t.Errorf("Expected reference to be removed, got %+v (%v)", finding, err)
+	}
+
+	for _, bad := range []string{"", "nvd.nist.gov/vuln", "javascript:alert(1)"} {
+		if _, err := client.AddFindingReference(7, Reference{URL: bad}); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("Expected ErrInvalidValue for %q, got %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Remediation
+// =============================================================================
+
+// RemediationStatus is the state of the work to fix a finding.
+type RemediationStatus string
+
+// Remediation statuses, following a finding from discovery to a verified
+// fix. RemediationAccepted closes a finding whose risk is accepted instead.
+const (
+	RemediationOpen       RemediationStatus = "open"
+	RemediationInProgress RemediationStatus = "in_progress"
+	RemediationFixed      RemediationStatus = "fixed"
+	RemediationVerified   RemediationStatus = "verified"
+	RemediationAccepted   RemediationStatus = "accepted"
+)
+
+// Closed reports whether no more remediation work is expected.
+func (s RemediationStatus) Closed() bool {
+	return s == RemediationVerified || s == RemediationAccepted
+}
+
+// Remediation tracks who is fixing a finding and by when.
+type Remediation struct {
+	Owner     string            `json:"owner,omitempty"`
+	DueDate   time.Time         `json:"due_date,omitempty"`
+	Status    RemediationStatus `json:"status"`
+	Notes     string            `json:"notes,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at,omitempty"`
+}
+
+// Overdue reports whether the due date has passed at now while the
+// finding is still awaiting a fix. A fix that has not been verified yet is
+// not overdue.
+func (r *Remediation) Overdue(now time.Time) bool {
+	if r == nil || r.DueDate.IsZero() {
+		return false
+	}
+	switch r.Status {
+	case RemediationFixed, RemediationVerified, RemediationAccepted:
+		return false
+	}
+	return now.After(r.DueDate)
+}
+
+// RemediationUpdate changes the remediation of a finding. Nil and empty
+// fields are left unchanged.
+type RemediationUpdate struct {
+	Owner   *string           `json:"owner,omitempty"`
+	DueDate *time.Time        `json:"due_date,omitempty"`
+	Status  RemediationStatus `json:"status,omitempty"`
+	Notes   *string           `json:"notes,omitempty"`
+}
+
+// UpdateRemediation updates the remediation of a finding and returns the
+// updated finding.
+func (c *Client) UpdateRemediation(findingID int64, update *RemediationUpdate) (*Finding, error) {
+	return c.UpdateRemediationContext(context.Background(), findingID, update)
+}
+
+// UpdateRemediationContext is like UpdateRemediation but uses ctx for
+// its request.
+func (c *Client) UpdateRemediationContext(ctx context.Context, findingID int64, update *RemediationUpdate) (*Finding, error) {
+	var finding Finding
+	if err := c.requestContext(ctx, "PATCH", fmt.Sprintf("/findings/%d/remediation", findingID), update, &finding); err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+// AssignFinding assigns the fix of a finding to owner, due by due. A zero due
+// leaves the due date unchanged.
+func (c *Client) AssignFinding(findingID int64, owner string, due time.Time) (*Finding, error) {
+	return c.AssignFindingContext(context.Background(), findingID, owner, due)
+}
+
+// AssignFindingContext is like AssignFinding but uses ctx for its request.
+func (c *Client) AssignFindingContext(ctx context.Context, findingID int64, owner string, due time.Time) (*Finding, error) {
+	update := &RemediationUpdate{Owner: &owner}
+	if !due.IsZero() {
+		update.DueDate = &due
+	}
+	return c.UpdateRemediationContext(ctx, findingID, update)
+}
+
+// SetRemediationStatus moves a finding to status, recording notes such as
+// how it was fixed. Empty notes are left unchanged.
+func (c *Client) SetRemediationStatus(findingID int64, status RemediationStatus, notes string) (*Finding, error) {
+	return c.SetRemediationStatusContext(context.Background(), findingID, status, notes)
+}
+
+// SetRemediationStatusContext is like SetRemediationStatus but uses ctx for
+// its request.
+func (c *Client) SetRemediationStatusContext(ctx context.Context, findingID int64, status RemediationStatus, notes string) (*Finding, error) {
+	update := &RemediationUpdate{Status: status}
+	if notes != "" {
+		update.Notes = &notes
+	}
+	return c.UpdateRemediationContext(ctx, findingID, update)
+}
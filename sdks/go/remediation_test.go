@@ -0,0 +1,70 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRemediationOverdue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Hour)
+
+	tests := []struct {
+		r    *Remediation
+		want bool
+	}{
+		{nil, false},
+		{&Remediation{Status: RemediationOpen}, false},
+		{&Remediation{Status: RemediationOpen, DueDate: due}, true},
+		{&Remediation{Status: RemediationInProgress, DueDate: due}, true},
+		{&Remediation{Status: RemediationFixed, DueDate: due}, false},
+		{&Remediation{Status: RemediationOpen, DueDate: now.Add(time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Overdue(now); got != tt.want {
+			t.Errorf("Expected Overdue(%+v) = %v, got %v", tt.r, tt.want, got)
+		}
+	}
+
+	filter := &FindingsFilter{Overdue: true, Owner: "alice"}
+	late := Finding{Remediation: &Remediation{Owner: "alice", Status: RemediationOpen, DueDate: time.Now().Add(-time.Hour)}}
+	if !filter.Matches(late) || filter.Matches(Finding{}) {
+		t.Errorf("Expected Matches to check remediation")
+	}
+}
+
+func TestUpdateRemediation(t *testing.T) {
+	var update map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/findings/7/remediation" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		update = nil
+		json.NewDecoder(r.Body).Decode(&update)
+		w.Write([]byte(`{"id": 7, "remediation": {"owner": "alice", "status": "fixed", "notes": "patched log4j"}}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	finding, err := client.SetRemediationStatus(7, RemediationFixed, "patched log4j")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(update) != 2 || update["status"] != "fixed" || update["notes"] != "patched log4j" {
+		t.Errorf("Expected status and notes only, got %v", update)
+	}
+	if finding.Remediation == nil || finding.Remediation.Status != RemediationFixed {
+		t.Errorf("Expected fixed finding, got %+v", finding.Remediation)
+	}
+
+	due := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := client.AssignFinding(7, "alice", due); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(update) != 2 || update["owner"] != "alice" || update["due_date"] != "2024-07-01T00:00:00Z" {
+		t.Errorf("Expected owner and due date only, got %v", update)
+	}
+}
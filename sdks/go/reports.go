@@ -0,0 +1,114 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// =============================================================================
+// Reports
+// =============================================================================
+
+// Report formats.
+const (
+	ReportPDF      = "pdf"
+	ReportHTML     = "html"
+	ReportMarkdown = "markdown"
+	ReportJSON     = "json"
+)
+
+// ReportOptions configures report generation.
+type ReportOptions struct {
+	Format          string `json:"format,omitempty"`
+	Template        string `json:"template,omitempty"`
+	IncludeEvidence bool   `json:"include_evidence,omitempty"`
+	// Language is the BCP 47 tag of the language the report's labels, such
+	// as severities and section headings, are written in: "de" or "ja",
+	// including regional tags such as "de-AT". Other languages fall back
+	// to English, the default. Findings are not translated.
+	Language string `json:"language,omitempty"`
+}
+
+// languageTag matches the shape of a BCP 47 language tag.
+var languageTag = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// Report represents a generated engagement report.
+type Report struct {
+	ID          string    `json:"id"`
+	ProjectID   int64     `json:"project_id"`
+	Format      string    `json:"format"`
+	Status      string    `json:"status"`
+	Size        int64     `json:"size,omitempty"`
+	SHA256      string    `json:"sha256,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// CreateReport starts generating a report for a project. Reports are built
+// asynchronously; poll GetReport until Status is "completed" before
+// downloading it.
+func (c *Client) CreateReport(projectID int64, opts *ReportOptions) (*Report, error) {
+	return c.CreateReportContext(context.Background(), projectID, opts)
+}
+
+// CreateReportContext is like CreateReport but uses ctx for its request.
+func (c *Client) CreateReportContext(ctx context.Context, projectID int64, opts *ReportOptions) (*Report, error) {
+	if opts == nil {
+		opts = &ReportOptions{}
+	}
+	if opts.Language != "" && !languageTag.MatchString(opts.Language) {
+		return nil, fmt.Errorf("%w: report language %q is not a language tag such as \"de\"", ErrInvalidValue, opts.Language)
+	}
+	var report Report
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/projects/%d/reports", projectID), opts, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetReport returns a report by ID.
+func (c *Client) GetReport(id string) (*Report, error) {
+	return c.GetReportContext(context.Background(), id)
+}
+
+// GetReportContext is like GetReport but uses ctx for its request.
+func (c *Client) GetReportContext(ctx context.Context, id string) (*Report, error) {
+	var report Report
+	if err := c.requestContext(ctx, "GET", "/reports/"+id, nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListReports returns the reports of a project.
+func (c *Client) ListReports(projectID int64) ([]Report, error) {
+	return c.ListReportsContext(context.Background(), projectID)
+}
+
+// ListReportsContext is like ListReports but uses ctx for its request.
+func (c *Client) ListReportsContext(ctx context.Context, projectID int64) ([]Report, error) {
+	var reports []Report
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/projects/%d/reports", projectID), nil, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// SendReport emails a completed report to the given addresses. Recipients
+// do not need AIPTX accounts; they receive the report as an attachment.
+func (c *Client) SendReport(reportID string, recipients []string) error {
+	return c.SendReportContext(context.Background(), reportID, recipients)
+}
+
+// SendReportContext is like SendReport but uses ctx for its request.
+func (c *Client) SendReportContext(ctx context.Context, reportID string, recipients []string) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("sending report %s needs at least one recipient", reportID)
+	}
+	body := map[string][]string{"recipients": recipients}
+	return c.requestContext(ctx, "POST", "/reports/"+reportID+"/send", body, nil)
+}
@@ -0,0 +1,34 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateReportLanguage(t *testing.T) {
+	var sent ReportOptions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"id": "r-1", "format": "pdf", "status": "pending"}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	if _, err := client.CreateReport(42, &ReportOptions{Format: ReportPDF, Language: "ja-JP"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sent.Language != "ja-JP" {
+		t.Errorf("Expected language to be sent, got %+v", sent)
+	}
+
+	sent = ReportOptions{}
+	if _, err := client.CreateReport(42, &ReportOptions{Language: "german"}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+	if sent.Format != "" {
+		t.Errorf("Expected no request for an invalid language")
+	}
+}
@@ -0,0 +1,108 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Retests
+// =============================================================================
+
+// Retest statuses.
+const (
+	RetestPending   = "pending"
+	RetestRunning   = "running"
+	RetestCompleted = "completed"
+	RetestFailed    = "failed"
+)
+
+// RetestOutcome is the result of a completed retest.
+type RetestOutcome string
+
+// Retest outcomes. When a retest completes, the server updates the finding:
+// a reproduced finding is marked verified and its remediation reopened, and
+// a finding that no longer reproduces has its remediation marked verified.
+const (
+	RetestReproduced    RetestOutcome = "reproduced"
+	RetestNotReproduced RetestOutcome = "not_reproduced"
+	RetestInconclusive  RetestOutcome = "inconclusive"
+)
+
+// Retest is a narrowly scoped scan that checks whether a single finding
+// still reproduces.
+type Retest struct {
+	ID        string `json:"id"`
+	FindingID int64  `json:"finding_id"`
+	ScanID    string `json:"scan_id,omitempty"`
+	Status    string `json:"status"`
+	// Outcome is set once Status is RetestCompleted.
+	Outcome     RetestOutcome `json:"outcome,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	StartedAt   time.Time     `json:"started_at"`
+	CompletedAt time.Time     `json:"completed_at,omitempty"`
+	// Finding is the finding as updated by the outcome, once completed.
+	Finding *Finding `json:"finding,omitempty"`
+
+	unknown *rawFields
+}
+
+// Done reports whether the retest has finished, successfully or not.
+func (r *Retest) Done() bool {
+	return r.Status == RetestCompleted || r.Status == RetestFailed
+}
+
+// RetestFinding starts re-verifying a finding with a scan scoped to just
+// that vulnerability, typically after a fix. Use WaitForRetest to wait for
+// the outcome.
+func (c *Client) RetestFinding(findingID int64) (*Retest, error) {
+	return c.RetestFindingContext(context.Background(), findingID)
+}
+
+// RetestFindingContext is like RetestFinding but uses ctx for its request.
+func (c *Client) RetestFindingContext(ctx context.Context, findingID int64) (*Retest, error) {
+	var retest Retest
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/findings/%d/retest", findingID), nil, &retest); err != nil {
+		return nil, err
+	}
+	return &retest, nil
+}
+
+// GetRetest returns a retest by ID.
+func (c *Client) GetRetest(id string) (*Retest, error) {
+	return c.GetRetestContext(context.Background(), id)
+}
+
+// GetRetestContext is like GetRetest but uses ctx for its request.
+func (c *Client) GetRetestContext(ctx context.Context, id string) (*Retest, error) {
+	return c.getRetest(ctx, id)
+}
+
+func (c *Client) getRetest(ctx context.Context, id string) (*Retest, error) {
+	var retest Retest
+	if err := c.requestContext(ctx, "GET", "/retests/"+id, nil, &retest); err != nil {
+		return nil, err
+	}
+	return &retest, nil
+}
+
+// WaitForRetest polls a retest every interval until it is done or ctx ends.
+// A failed retest is returned with an error containing its Error message.
+func (c *Client) WaitForRetest(ctx context.Context, id string, interval time.Duration) (*Retest, error) {
+	for {
+		retest, err := c.getRetest(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if retest.Status == RetestFailed {
+			return retest, fmt.Errorf("retest %s failed: %s", id, retest.Error)
+		}
+		if retest.Done() {
+			return retest, nil
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return retest, err
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package aiptx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetestFinding(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /findings/7/retest":
+			w.Write([]byte(`{"id": "rt-1", "finding_id": 7, "status": "pending"}`))
+		case "GET /retests/rt-1":
+			if polls++; polls < 3 {
+				w.Write([]byte(`{"id": "rt-1", "finding_id": 7, "status": "running"}`))
+				return
+			}
+			w.Write([]byte(`{"id": "rt-1", "finding_id": 7, "status": "completed", "outcome": "not_reproduced",
+				"finding": {"id": 7, "remediation": {"status": "verified"}}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	retest, err := client.RetestFinding(7)
+	if err != nil || retest.Done() {
+		t.Fatalf("Expected pending retest, got %+v (%v)", retest, err)
+	}
+	retest, err = client.WaitForRetest(context.Background(), retest.ID, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if polls != 3 || retest.Outcome != RetestNotReproduced {
+		t.Errorf("Expected outcome after 3 polls, got %d polls and %+v", polls, retest)
+	}
+	if retest.Finding == nil || retest.Finding.Remediation.Status != RemediationVerified {
+		t.Errorf("Expected updated finding, got %+v", retest.Finding)
+	}
+}
+
+func TestWaitForRetestFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "rt-2", "status": "failed", "error": "target unreachable"}`))
+	}))
+	defer server.Close()
+
+	retest, err := NewClient(server.URL).WaitForRetest(context.Background(), "rt-2", time.Millisecond)
+	if err == nil || retest == nil || retest.Error != "target unreachable" {
+		t.Errorf("Expected failed retest error, got %+v (%v)", retest, err)
+	}
+}
@@ -0,0 +1,303 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Retries
+// =============================================================================
+
+// Jitter selects how retry delays are randomised. Randomisation keeps many
+// clients that failed at the same moment from retrying in lockstep.
+type Jitter int
+
+// Jitter strategies, as described in "Exponential Backoff And Jitter" on the
+// AWS Architecture Blog.
+const (
+	// JitterNone uses the exponential delay as is.
+	JitterNone Jitter = iota
+	// JitterFull picks a delay between zero and the exponential delay.
+	JitterFull
+	// JitterEqual keeps half the exponential delay and randomises the rest.
+	JitterEqual
+	// JitterDecorrelated picks a delay between the base delay and three times
+	// the previous delay.
+	JitterDecorrelated
+)
+
+// RetryPolicy controls how failed requests are retried. Idempotent requests
+// (GET, HEAD, OPTIONS, PUT and DELETE) are retried after network errors, 408
+// responses or 5xx responses other than 501 and 505. Requests of any method
+// are retried after 429 Too Many Requests, which the server sends before
+// acting on a request, waiting as long as its Retry-After header asks.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including
+	// the first. Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. It doubles for each
+	// further retry up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Jitter selects how delays are randomised.
+	Jitter Jitter
+
+	// MaxRetryAfter caps how long a rate-limited request waits to be
+	// retried. Requests asked to wait longer fail with the *RateLimitError
+	// instead. Zero means no cap.
+	MaxRetryAfter time.Duration
+	// NoRateLimitRetry disables retrying rate-limited requests, so 429
+	// responses fail at once with a *RateLimitError.
+	NoRateLimitRetry bool
+
+	// Budget, if set, caps the share of requests that may be retried. Share
+	// one budget between clients to cap retries across all of them.
+	Budget *RetryBudget
+}
+
+// DefaultRetryPolicy is the retry policy of clients created by NewClient:
+// up to three attempts, with full jitter over 250ms and 500ms delays, and
+// waiting up to two minutes when rate limited.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:   3,
+	BaseDelay:     250 * time.Millisecond,
+	MaxDelay:      10 * time.Second,
+	Jitter:        JitterFull,
+	MaxRetryAfter: 2 * time.Minute,
+}
+
+// WithRetryPolicy sets the client's retry policy, replacing
+// DefaultRetryPolicy. Pass the zero RetryPolicy to disable retries.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// retryState tracks the retries of a single request.
+type retryState struct {
+	policy     RetryPolicy
+	idempotent bool
+	attempt    int
+	prev       time.Duration
+}
+
+func newRetryState(policy RetryPolicy, method string) *retryState {
+	if policy.Budget != nil {
+		policy.Budget.record()
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return &retryState{policy: policy, idempotent: true, attempt: 1}
+	}
+	return &retryState{policy: policy, attempt: 1}
+}
+
+// next reports whether the request should be retried after err, and after
+// what delay.
+func (r *retryState) next(ctx context.Context, err error) (time.Duration, bool) {
+	if r.attempt >= r.policy.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return r.rateLimited(rateErr)
+	}
+	if !r.idempotent || !isTransient(err) {
+		return 0, false
+	}
+	if r.policy.Budget != nil && !r.policy.Budget.withdraw() {
+		return 0, false
+	}
+	r.attempt++
+	r.prev = r.policy.delay(r.attempt-1, r.prev)
+	return r.prev, true
+}
+
+// rateLimited reports whether a rate-limited request should be retried, and
+// after what delay. Rate-limited retries do not draw on the retry budget,
+// since they wait as long as the server asks.
+func (r *retryState) rateLimited(err *RateLimitError) (time.Duration, bool) {
+	if r.policy.NoRateLimitRetry {
+		return 0, false
+	}
+	if r.policy.MaxRetryAfter > 0 && err.RetryAfter > r.policy.MaxRetryAfter {
+		return 0, false
+	}
+	r.attempt++
+	delay := err.RetryAfter
+	if delay <= 0 {
+		r.prev = r.policy.delay(r.attempt-1, r.prev)
+		delay = r.prev
+	}
+	return delay, true
+}
+
+// delay returns the delay before the given retry (starting at 1), given the
+// previous delay.
+func (p RetryPolicy) delay(retry int, prev time.Duration) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		return 0
+	}
+	limit := p.MaxDelay
+	if limit <= 0 {
+		limit = time.Duration(1<<63 - 1)
+	}
+
+	exp := base
+	for i := 1; i < retry && exp < limit; i++ {
+		exp *= 2
+	}
+	if exp > limit {
+		exp = limit
+	}
+
+	var d time.Duration
+	switch p.Jitter {
+	case JitterFull:
+		d = randDuration(0, exp)
+	case JitterEqual:
+		d = exp/2 + randDuration(0, exp-exp/2)
+	case JitterDecorrelated:
+		if prev < base {
+			prev = base
+		}
+		d = randDuration(base, prev*3)
+	default:
+		d = exp
+	}
+	if d > limit {
+		d = limit
+	}
+	return d
+}
+
+// randDuration returns a random duration in [min, max).
+func randDuration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(rand.Int63n(int64(max-min)))
+}
+
+// isTransient reports whether err is a network failure or a server response
+// that is likely to succeed when retried.
+func isTransient(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusRequestTimeout:
+			return true
+		case http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+			return false
+		}
+		return apiErr.StatusCode >= 500
+	}
+	var transportErr *TransportError
+	return errors.As(err, &transportErr)
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// =============================================================================
+// Retry Budget
+// =============================================================================
+
+const budgetBuckets = 10
+
+// RetryBudget limits retries to a fraction of recent requests, so that an
+// outage does not multiply load on the server with synchronised retries from
+// every client. It is safe for concurrent use and is typically shared by all
+// clients in a process.
+type RetryBudget struct {
+	ratio      float64
+	minRetries int
+	width      time.Duration
+
+	mu      sync.Mutex
+	buckets [budgetBuckets]budgetBucket
+}
+
+type budgetBucket struct {
+	start    int64
+	requests int
+	retries  int
+}
+
+// NewRetryBudget creates a budget allowing retries for up to ratio (e.g. 0.1
+// for 10%) of the requests made within window, plus minRetries per window so
+// that clients with little traffic can still retry.
+func NewRetryBudget(ratio float64, window time.Duration, minRetries int) *RetryBudget {
+	width := window / budgetBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return &RetryBudget{ratio: ratio, minRetries: minRetries, width: width}
+}
+
+// bucket returns the bucket for now, resetting it if it has expired.
+func (b *RetryBudget) bucket(now time.Time) *budgetBucket {
+	start := now.UnixNano() / int64(b.width)
+	bucket := &b.buckets[start%budgetBuckets]
+	if bucket.start != start {
+		*bucket = budgetBucket{start: start}
+	}
+	return bucket
+}
+
+// totals sums the buckets within the window ending at now.
+func (b *RetryBudget) totals(now time.Time) (requests, retries int) {
+	current := now.UnixNano() / int64(b.width)
+	for _, bucket := range b.buckets {
+		if current-bucket.start < budgetBuckets {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+	return requests, retries
+}
+
+func (b *RetryBudget) record() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucket(time.Now()).requests++
+}
+
+// withdraw reports whether a retry is allowed and, if so, records it.
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	requests, retries := b.totals(now)
+	allowed := int(b.ratio * float64(requests))
+	if allowed < b.minRetries {
+		allowed = b.minRetries
+	}
+	if retries >= allowed {
+		return false
+	}
+	b.bucket(now).retries++
+	return true
+}
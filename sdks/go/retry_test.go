@@ -0,0 +1,218 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		Jitter:      JitterFull,
+	}))
+	client.Hooks.OnRetry = func(req *http.Request, attempt int, err error, delay time.Duration) {
+		attempts = append(attempts, attempt)
+	}
+
+	health, err := client.Health()
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("Expected status 'ok', got '%s'", health.Status)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	if len(attempts) != 2 || attempts[0] != 2 || attempts[1] != 3 {
+		t.Errorf("Expected retry attempts [2 3], got %v", attempts)
+	}
+}
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err == nil {
+		t.Fatal("Expected error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected POST not to be retried, got %d calls", calls)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 5,
+		Budget:      NewRetryBudget(0, time.Minute, 2),
+	}))
+	client.Health()
+	client.Health()
+
+	// Two requests plus the two retries the budget allows.
+	if calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRetryBudgetRatio(t *testing.T) {
+	budget := NewRetryBudget(0.5, time.Minute, 0)
+	for i := 0; i < 10; i++ {
+		budget.record()
+	}
+	allowed := 0
+	for budget.withdraw() {
+		allowed++
+	}
+	if allowed != 5 {
+		t.Errorf("Expected 5 retries allowed, got %d", allowed)
+	}
+}
+
+func TestRetryJitter(t *testing.T) {
+	base, max := 100*time.Millisecond, time.Second
+
+	tests := []struct {
+		jitter   Jitter
+		retry    int
+		min, max time.Duration
+	}{
+		{JitterNone, 1, base, base},
+		{JitterNone, 3, 4 * base, 4 * base},
+		{JitterNone, 10, max, max},
+		{JitterFull, 3, 0, 4 * base},
+		{JitterEqual, 3, 2 * base, 4 * base},
+		{JitterDecorrelated, 1, base, 3 * base},
+	}
+
+	for _, tt := range tests {
+		policy := RetryPolicy{BaseDelay: base, MaxDelay: max, Jitter: tt.jitter}
+		for i := 0; i < 100; i++ {
+			d := policy.delay(tt.retry, 0)
+			if d < tt.min || d > tt.max {
+				t.Errorf("Jitter %d retry %d: expected delay in [%s, %s], got %s", tt.jitter, tt.retry, tt.min, tt.max, d)
+				break
+			}
+		}
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Path == "/tools":
+			w.WriteHeader(http.StatusNotImplemented)
+		case calls == 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"status": "ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if client.RetryPolicy != DefaultRetryPolicy {
+		t.Errorf("Expected DefaultRetryPolicy, got %+v", client.RetryPolicy)
+	}
+	if _, err := client.Health(); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 500 to be retried, got %d calls", calls)
+	}
+
+	calls = 0
+	if _, err := client.ListTools(); err == nil {
+		t.Fatal("Expected error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 501 not to be retried, got %d calls", calls)
+	}
+}
+
+func TestRetryRateLimited(t *testing.T) {
+	calls := 0
+	retryAfter := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": "scan-1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:   3,
+		BaseDelay:     time.Millisecond,
+		MaxRetryAfter: time.Minute,
+	}))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Fatalf("Expected rate-limited POST to be retried, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+
+	// The retry waits as long as Retry-After asks.
+	calls, retryAfter = 0, "30"
+	ctx, cancel := context.WithCancel(context.Background())
+	var delay time.Duration
+	client.Hooks.OnRetry = func(req *http.Request, attempt int, err error, d time.Duration) {
+		delay = d
+		cancel()
+	}
+	if _, err := client.HealthContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected wait to be cancelled, got %v", err)
+	}
+	if delay != 30*time.Second {
+		t.Errorf("Expected a 30s delay, got %s", delay)
+	}
+
+	// Longer waits than MaxRetryAfter fail at once.
+	calls, retryAfter = 0, "120"
+	client.Hooks.OnRetry = nil
+	var rateErr *RateLimitError
+	if _, err := client.Health(); !errors.As(err, &rateErr) || calls != 1 {
+		t.Errorf("Expected *RateLimitError without retry, got %v after %d calls", err, calls)
+	}
+
+	calls, retryAfter = 0, ""
+	client.RetryPolicy.NoRateLimitRetry = true
+	if _, err := client.Health(); !errors.As(err, &rateErr) || calls != 1 {
+		t.Errorf("Expected NoRateLimitRetry to disable retries, got %v after %d calls", err, calls)
+	}
+}
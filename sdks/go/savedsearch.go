@@ -0,0 +1,102 @@
+package aiptx
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// Saved Searches
+// =============================================================================
+
+// SavedSearch is a findings filter stored on the server under a name, so
+// triage views such as "unverified highs, last 7 days" can be shared between
+// the SDK, the CLI and the web UI.
+type SavedSearch struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Query is the filter encoded as findings query parameters.
+	Query     string    `json:"query"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	unknown *rawFields
+}
+
+// CreateSavedSearch stores filter under name. Use relative fields such as
+// DiscoveredWithin for views that should move with time. The paging options
+// of filter are not saved.
+func (c *Client) CreateSavedSearch(name string, filter *FindingsFilter) (*SavedSearch, error) {
+	return c.CreateSavedSearchContext(context.Background(), name, filter)
+}
+
+// CreateSavedSearchContext is like CreateSavedSearch but uses ctx for its
+// request.
+func (c *Client) CreateSavedSearchContext(ctx context.Context, name string, filter *FindingsFilter) (*SavedSearch, error) {
+	query := ""
+	if filter != nil {
+		f := *filter
+		f.ListOptions = ListOptions{}
+		query = f.values().Encode()
+	}
+	body := map[string]string{"name": name, "query": query}
+	var search SavedSearch
+	if err := c.requestContext(ctx, "POST", "/saved-searches", body, &search); err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// GetSavedSearch returns a saved search by ID.
+func (c *Client) GetSavedSearch(id string) (*SavedSearch, error) {
+	return c.GetSavedSearchContext(context.Background(), id)
+}
+
+// GetSavedSearchContext is like GetSavedSearch but uses ctx for its request.
+func (c *Client) GetSavedSearchContext(ctx context.Context, id string) (*SavedSearch, error) {
+	var search SavedSearch
+	if err := c.requestContext(ctx, "GET", "/saved-searches/"+id, nil, &search); err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// ListSavedSearches returns the saved searches visible to the caller.
+func (c *Client) ListSavedSearches() ([]SavedSearch, error) {
+	return c.ListSavedSearchesContext(context.Background())
+}
+
+// ListSavedSearchesContext is like ListSavedSearches but uses ctx for its
+// request.
+func (c *Client) ListSavedSearchesContext(ctx context.Context) ([]SavedSearch, error) {
+	var searches []SavedSearch
+	if err := c.requestContext(ctx, "GET", "/saved-searches", nil, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// DeleteSavedSearch deletes a saved search.
+func (c *Client) DeleteSavedSearch(id string) error {
+	return c.DeleteSavedSearchContext(context.Background(), id)
+}
+
+// DeleteSavedSearchContext is like DeleteSavedSearch but uses ctx for its
+// request.
+func (c *Client) DeleteSavedSearchContext(ctx context.Context, id string) error {
+	return c.requestContext(ctx, "DELETE", "/saved-searches/"+id, nil, nil)
+}
+
+// RunSavedSearch returns the findings currently matching a saved search.
+func (c *Client) RunSavedSearch(id string) ([]Finding, error) {
+	return c.RunSavedSearchContext(context.Background(), id)
+}
+
+// RunSavedSearchContext is like RunSavedSearch but uses ctx for its request.
+func (c *Client) RunSavedSearchContext(ctx context.Context, id string) ([]Finding, error) {
+	var findings []Finding
+	if err := c.requestContext(ctx, "GET", "/saved-searches/"+id+"/findings", nil, &findings); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
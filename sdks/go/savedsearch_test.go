@@ -0,0 +1,52 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSavedSearch(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /saved-searches":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			query = body["query"]
+			json.NewEncoder(w).Encode(map[string]string{"id": "ss-1", "name": body["name"], "query": query})
+		case "GET /saved-searches/ss-1/findings":
+			w.Write([]byte(`[{"id": 1, "severity": "high"}]`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	unverified := false
+	search, err := client.CreateSavedSearch("Unverified highs, last 7 days", &FindingsFilter{
+		MinSeverity:      SeverityHigh,
+		Verified:         &unverified,
+		DiscoveredWithin: 7 * 24 * time.Hour,
+		ListOptions:      ListOptions{Limit: 50, Cursor: "abc"},
+	})
+	if err != nil || search.ID != "ss-1" {
+		t.Fatalf("Expected saved search ss-1, got %+v (%v)", search, err)
+	}
+	params, _ := url.ParseQuery(query)
+	if params.Get("min_severity") != "high" || params.Get("verified") != "false" || params.Get("discovered_within") != "604800" {
+		t.Errorf("Expected encoded filter, got %s", query)
+	}
+	if params.Has("limit") || params.Has("cursor") {
+		t.Errorf("Expected paging options not to be saved, got %s", query)
+	}
+
+	findings, err := client.RunSavedSearch(search.ID)
+	if err != nil || len(findings) != 1 {
+		t.Errorf("Expected one finding, got %+v (%v)", findings, err)
+	}
+}
@@ -0,0 +1,85 @@
+package aiptx
+
+import "fmt"
+
+// =============================================================================
+// Scan Errors
+// =============================================================================
+
+// ScanErrorCode classifies why a scan failed, so automation can branch on
+// the cause instead of parsing ScanStatus.Error.
+type ScanErrorCode string
+
+// Scan error codes. Servers predating error codes leave ScanStatus.ErrorCode
+// empty, which ScanStatus.Err reports as ScanErrUnknown.
+const (
+	// ScanErrTargetUnreachable means the target did not respond, for
+	// example because it was down or blocked the scanner.
+	ScanErrTargetUnreachable ScanErrorCode = "target_unreachable"
+	// ScanErrAuthFailed means the credentials for an authenticated scan
+	// were rejected.
+	ScanErrAuthFailed ScanErrorCode = "auth_failed"
+	// ScanErrToolCrashed means a scanning tool exited abnormally.
+	// ScanStatus.ErrorTool names it.
+	ScanErrToolCrashed ScanErrorCode = "tool_crashed"
+	// ScanErrLLMQuotaExceeded means the LLM provider refused requests
+	// because the account's quota or budget is exhausted.
+	ScanErrLLMQuotaExceeded ScanErrorCode = "llm_quota_exceeded"
+	// ScanErrTimeout means the scan exceeded its maximum duration.
+	ScanErrTimeout ScanErrorCode = "timeout"
+	// ScanErrInternal means the server failed for another reason.
+	ScanErrInternal ScanErrorCode = "internal"
+	ScanErrUnknown  ScanErrorCode = "unknown"
+)
+
+// Retryable reports whether a scan that failed with code may succeed if it
+// is simply started again. Failures that need a person to act, such as
+// rejected credentials or an exhausted LLM quota, are not retryable.
+func (code ScanErrorCode) Retryable() bool {
+	switch code {
+	case ScanErrTargetUnreachable, ScanErrToolCrashed, ScanErrInternal:
+		return true
+	}
+	return false
+}
+
+// ScanError is the failure of a scan, as returned by ScanStatus.Err.
+type ScanError struct {
+	ScanID  string
+	Code    ScanErrorCode
+	Message string
+	// Tool is the tool that crashed, for ScanErrToolCrashed.
+	Tool string
+}
+
+func (e *ScanError) Error() string {
+	if e.Tool != "" {
+		return fmt.Sprintf("scan %s failed (%s: %s): %s", e.ScanID, e.Code, e.Tool, e.Message)
+	}
+	return fmt.Sprintf("scan %s failed (%s): %s", e.ScanID, e.Code, e.Message)
+}
+
+// Retryable reports whether the scan may succeed if started again.
+func (e *ScanError) Retryable() bool {
+	return e.Code.Retryable()
+}
+
+// Err returns a *ScanError if the scan failed or was aborted, and nil
+// otherwise:
+//
+//	var scanErr *aiptx.ScanError
+//	if errors.As(status.Err(), &scanErr) && scanErr.Retryable() {
+//	    client.StartScan(req)
+//	}
+func (s *ScanStatus) Err() error {
+	code := s.ErrorCode
+	switch {
+	case s.Status == ScanAbortedTimeout && code == "":
+		code = ScanErrTimeout
+	case s.Status != ScanFailed && s.Status != ScanAbortedTimeout:
+		return nil
+	case code == "":
+		code = ScanErrUnknown
+	}
+	return &ScanError{ScanID: s.ID, Code: code, Message: s.Error, Tool: s.ErrorTool}
+}
@@ -0,0 +1,64 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestScanStatusErr(t *testing.T) {
+	var status ScanStatus
+	err := json.Unmarshal([]byte(`{
+		"id": "scan-1",
+		"status": "failed",
+		"error": "nuclei exited with signal 9",
+		"error_code": "tool_crashed",
+		"error_tool": "nuclei"
+	}`), &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scanErr *ScanError
+	if !errors.As(status.Err(), &scanErr) {
+		t.Fatalf("Expected *ScanError, got %v", status.Err())
+	}
+	if scanErr.Code != ScanErrToolCrashed || scanErr.Tool != "nuclei" || !scanErr.Retryable() {
+		t.Errorf("Unexpected scan error %+v", scanErr)
+	}
+	want := "scan scan-1 failed (tool_crashed: nuclei): nuclei exited with signal 9"
+	if scanErr.Error() != want {
+		t.Errorf("Expected %q, got %q", want, scanErr.Error())
+	}
+}
+
+func TestScanStatusErrCodes(t *testing.T) {
+	tests := []struct {
+		status    ScanStatus
+		code      ScanErrorCode
+		retryable bool
+	}{
+		{ScanStatus{Status: ScanFailed, ErrorCode: ScanErrAuthFailed}, ScanErrAuthFailed, false},
+		{ScanStatus{Status: ScanFailed, ErrorCode: ScanErrLLMQuotaExceeded}, ScanErrLLMQuotaExceeded, false},
+		{ScanStatus{Status: ScanFailed, ErrorCode: ScanErrTargetUnreachable}, ScanErrTargetUnreachable, true},
+		{ScanStatus{Status: ScanFailed}, ScanErrUnknown, false},
+		{ScanStatus{Status: ScanAbortedTimeout}, ScanErrTimeout, false},
+	}
+	for _, tt := range tests {
+		var scanErr *ScanError
+		if !errors.As(tt.status.Err(), &scanErr) {
+			t.Errorf("Expected *ScanError for %+v", tt.status)
+			continue
+		}
+		if scanErr.Code != tt.code || scanErr.Retryable() != tt.retryable {
+			t.Errorf("Expected code %s (retryable %v), got %s (retryable %v)", tt.code, tt.retryable, scanErr.Code, scanErr.Retryable())
+		}
+	}
+
+	for _, s := range []ScanState{ScanQueued, ScanRunning, ScanCompleted, ScanCancelled} {
+		status := ScanStatus{Status: s, Error: "ignored"}
+		if err := status.Err(); err != nil {
+			t.Errorf("Expected no error for status %s, got %v", s, err)
+		}
+	}
+}
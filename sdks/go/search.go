@@ -0,0 +1,97 @@
+package aiptx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// =============================================================================
+// Search
+// =============================================================================
+
+// Kinds of search results.
+const (
+	SearchFindings = "finding"
+	SearchAssets   = "asset"
+	SearchNotes    = "note"
+)
+
+// SearchOptions configures SearchAllWithOptions.
+type SearchOptions struct {
+	// Kinds restricts results to the given kinds, e.g. SearchFindings.
+	Kinds []string
+	// Limit is the maximum number of results. Zero uses the server's
+	// default.
+	Limit int
+}
+
+// SearchResult is one match of an organization-wide search.
+type SearchResult struct {
+	Kind      string `json:"kind"`
+	ID        string `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Title     string `json:"title"`
+	// Snippet is an excerpt of the matching text.
+	Snippet string  `json:"snippet,omitempty"`
+	Score   float64 `json:"score"`
+	// Data is the matched resource, which Decode unmarshals.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Decode unmarshals the matched resource into v, such as a *Finding for
+// results of kind SearchFindings.
+func (r *SearchResult) Decode(v interface{}) error {
+	if len(r.Data) == 0 {
+		return fmt.Errorf("search result %s %s has no data", r.Kind, r.ID)
+	}
+	return json.Unmarshal(r.Data, v)
+}
+
+// SearchResults are the matches of an organization-wide search.
+type SearchResults struct {
+	Results []SearchResult `json:"results"`
+	Total   int            `json:"total"`
+	// Restricted is the number of matches in projects the caller may not
+	// access, which are left out of Results.
+	Restricted int `json:"restricted,omitempty"`
+}
+
+// SearchAll searches findings, assets and notes across every project the
+// caller has access to, e.g. for "log4shell".
+func (c *Client) SearchAll(query string) (*SearchResults, error) {
+	return c.SearchAllContext(context.Background(), query)
+}
+
+// SearchAllContext is like SearchAll but uses ctx for its request.
+func (c *Client) SearchAllContext(ctx context.Context, query string) (*SearchResults, error) {
+	return c.SearchAllWithOptionsContext(ctx, query, nil)
+}
+
+// SearchAllWithOptions is SearchAll configured by opts, which may be nil.
+func (c *Client) SearchAllWithOptions(query string, opts *SearchOptions) (*SearchResults, error) {
+	return c.SearchAllWithOptionsContext(context.Background(), query, opts)
+}
+
+// SearchAllWithOptionsContext is like SearchAllWithOptions but uses ctx for its
+// request.
+func (c *Client) SearchAllWithOptionsContext(ctx context.Context, query string, opts *SearchOptions) (*SearchResults, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	if opts != nil {
+		for _, kind := range opts.Kinds {
+			params.Add("kind", kind)
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	var results SearchResults
+	if err := c.requestContext(ctx, "GET", withQuery("/search", params), nil, &results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
@@ -0,0 +1,37 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("q") != "log4shell" || len(q["kind"]) != 1 {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"total": 3, "restricted": 1, "results": [
+			{"kind": "finding", "id": "7", "project_id": 42, "title": "CVE-2021-44228", "score": 0.9,
+			 "data": {"id": 7, "value": "CVE-2021-44228"}},
+			{"kind": "finding", "id": "9", "project_id": 3, "title": "CVE-2021-44228", "score": 0.8}
+		]}`))
+	}))
+	defer server.Close()
+
+	results, err := NewClient(server.URL).SearchAllWithOptions("log4shell", &SearchOptions{Kinds: []string{SearchFindings}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if results.Total != 3 || results.Restricted != 1 || len(results.Results) != 2 {
+		t.Errorf("Expected two visible of three results, got %+v", results)
+	}
+	var f Finding
+	if err := results.Results[0].Decode(&f); err != nil || f.ID != 7 {
+		t.Errorf("Expected finding 7, got %+v (%v)", f, err)
+	}
+	if err := results.Results[1].Decode(&f); err == nil {
+		t.Errorf("Expected error for a result without data")
+	}
+}
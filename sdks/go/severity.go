@@ -0,0 +1,61 @@
+package aiptx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Severity
+// =============================================================================
+
+// Severity is the severity level of a finding.
+type Severity string
+
+// Severity levels, from least to most severe.
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// severityOrder lists severities from least to most severe.
+var severityOrder = []Severity{
+	SeverityInfo,
+	SeverityLow,
+	SeverityMedium,
+	SeverityHigh,
+	SeverityCritical,
+}
+
+// rank returns the position of s in severityOrder, or -1 if s is unknown.
+func (s Severity) rank() int {
+	for i, sev := range severityOrder {
+		if sev == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// String returns the severity as sent to the server.
+func (s Severity) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is one of the severity levels above.
+func (s Severity) Valid() bool {
+	return s.rank() >= 0
+}
+
+// ParseSeverity returns the severity named s, ignoring case, or an error
+// wrapping ErrInvalidValue.
+func ParseSeverity(s string) (Severity, error) {
+	sev := Severity(strings.ToLower(s))
+	if !sev.Valid() {
+		return "", fmt.Errorf("%w: unknown severity %q", ErrInvalidValue, s)
+	}
+	return sev, nil
+}
@@ -0,0 +1,62 @@
+// Package severity maps findings to process exit codes, so shell scripts and
+// CI jobs wrapping the SDK can fail on findings the same way the CLI does:
+//
+//	os.Exit(severity.ExitCode(findings, severity.Policy{}))
+package severity
+
+import "github.com/aiptx/aiptx-go"
+
+// Exit codes, from clean to most severe.
+const (
+	ExitClean    = 0 // no findings counted
+	ExitLow      = 1 // low or medium findings
+	ExitHigh     = 2 // high findings
+	ExitCritical = 3 // critical findings
+)
+
+// Policy selects the findings that count toward the exit code. The zero
+// value counts every open finding. False positives, merged findings and
+// informational findings never count.
+type Policy struct {
+	// Filter, if set, counts only matching findings. Set its MinSeverity
+	// to fail only on, say, high and critical findings.
+	Filter *aiptx.FindingsFilter
+	// IncludeResolved counts findings whose remediation is fixed, verified
+	// or accepted.
+	IncludeResolved bool
+}
+
+// ExitCode returns the exit code for the most severe finding that counts
+// under policy.
+func ExitCode(findings []aiptx.Finding, policy Policy) int {
+	code := ExitClean
+	for _, f := range findings {
+		if policy.counts(f) {
+			code = max(code, Code(f.Severity))
+		}
+	}
+	return code
+}
+
+// Code returns the exit code for a single severity.
+func Code(s aiptx.Severity) int {
+	switch s {
+	case aiptx.SeverityCritical:
+		return ExitCritical
+	case aiptx.SeverityHigh:
+		return ExitHigh
+	case aiptx.SeverityMedium, aiptx.SeverityLow:
+		return ExitLow
+	}
+	return ExitClean
+}
+
+func (p Policy) counts(f aiptx.Finding) bool {
+	if f.FalsePositive || f.MergedInto != 0 {
+		return false
+	}
+	if r := f.Remediation; !p.IncludeResolved && r != nil && (r.Status == aiptx.RemediationFixed || r.Status.Closed()) {
+		return false
+	}
+	return p.Filter == nil || p.Filter.Matches(f)
+}
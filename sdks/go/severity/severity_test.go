@@ -0,0 +1,37 @@
+package severity
+
+import (
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestExitCode(t *testing.T) {
+	findings := []aiptx.Finding{
+		{ID: 1, Severity: "info"},
+		{ID: 2, Severity: "medium"},
+		{ID: 3, Severity: "critical", FalsePositive: true},
+		{ID: 4, Severity: "critical", Remediation: &aiptx.Remediation{Status: aiptx.RemediationFixed}},
+		{ID: 5, Severity: "high"},
+	}
+
+	tests := []struct {
+		name     string
+		findings []aiptx.Finding
+		policy   Policy
+		want     int
+	}{
+		{"empty", nil, Policy{}, ExitClean},
+		{"info only", findings[:1], Policy{}, ExitClean},
+		{"medium", findings[:2], Policy{}, ExitLow},
+		{"ignores false positives and resolved", findings[:4], Policy{}, ExitLow},
+		{"high", findings, Policy{}, ExitHigh},
+		{"include resolved", findings, Policy{IncludeResolved: true}, ExitCritical},
+		{"min severity", findings[:4], Policy{Filter: &aiptx.FindingsFilter{MinSeverity: aiptx.SeverityHigh}}, ExitClean},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.findings, tt.policy); got != tt.want {
+			t.Errorf("%s: Expected exit code %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package aiptx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// =============================================================================
+// Shutdown
+// =============================================================================
+
+// ErrClientShutdown is returned when starting a scan or session on a client
+// that has been shut down.
+var ErrClientShutdown = errors.New("client is shut down")
+
+// instance tracks the scans and sessions started by a client, so Shutdown
+// can stop them. Clients created by With share their parent's instance.
+// Clients created as struct literals have none, and track nothing.
+type instance struct {
+	tag string
+
+	mu       sync.Mutex
+	closed   bool
+	scans    map[string]bool
+	sessions map[int64]bool
+	// creating counts the scans and sessions being started, which Shutdown
+	// waits for so that it cancels them too.
+	creating sync.WaitGroup
+}
+
+func newInstance() *instance {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return &instance{
+		tag:      "aiptx-go-" + hex.EncodeToString(b),
+		scans:    map[string]bool{},
+		sessions: map[int64]bool{},
+	}
+}
+
+// InstanceTag returns the tag identifying this client instance. It is sent
+// in the X-AIPTX-Instance header when creating projects, sessions and scans,
+// so the server records which instance owns them.
+func (c *Client) InstanceTag() string {
+	if c.instance == nil {
+		return ""
+	}
+	return c.instance.tag
+}
+
+// create sends a request that starts a scan or session, tagged with the
+// client instance and owner tag. If it succeeds, register is called to track
+// the new resource before Shutdown can take its snapshot.
+func (c *Client) create(ctx context.Context, path string, in, out interface{}, register func()) error {
+	if !c.instance.begin() {
+		return ErrClientShutdown
+	}
+	defer c.instance.end()
+	if err := c.requestHeader(ctx, "POST", path, c.ownerHeader(), in, out); err != nil {
+		return err
+	}
+	register()
+	return nil
+}
+
+// begin marks the start of a create, reporting false if the instance has
+// been shut down.
+func (i *instance) begin() bool {
+	if i == nil {
+		return true
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.closed {
+		return false
+	}
+	i.creating.Add(1)
+	return true
+}
+
+// end marks the end of a create started by begin.
+func (i *instance) end() {
+	if i != nil {
+		i.creating.Done()
+	}
+}
+
+func (i *instance) addScan(id string) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.scans[id] = true
+}
+
+func (i *instance) addSession(id int64) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.sessions[id] = true
+}
+
+// scanStatus stops tracking a scan once it has finished.
+func (i *instance) scanStatus(id string, status ScanState) {
+	if i != nil && status.Finished() {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		delete(i.scans, id)
+	}
+}
+
+// sessionStatus stops tracking a session once it has finished.
+func (i *instance) sessionStatus(id int64, status ScanState) {
+	if i == nil {
+		return
+	}
+	switch status {
+	case ScanCompleted, ScanFailed, ScanCancelled:
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		delete(i.sessions, id)
+	}
+}
+
+// CancelScan cancels a queued or running scan.
+func (c *Client) CancelScan(scanID string) (*ScanStatus, error) {
+	return c.CancelScanContext(context.Background(), scanID)
+}
+
+// CancelScanContext is like CancelScan but uses ctx for its request.
+func (c *Client) CancelScanContext(ctx context.Context, scanID string) (*ScanStatus, error) {
+	var status ScanStatus
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/scans/%s/cancel", scanID), nil, &status); err != nil {
+		return nil, err
+	}
+	c.instance.scanStatus(scanID, ScanCancelled)
+	return &status, nil
+}
+
+// CancelSession cancels a running session.
+func (c *Client) CancelSession(id int64) (*Session, error) {
+	return c.CancelSessionContext(context.Background(), id)
+}
+
+// CancelSessionContext is like CancelSession but uses ctx for its request.
+func (c *Client) CancelSessionContext(ctx context.Context, id int64) (*Session, error) {
+	var session Session
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/sessions/%d/cancel", id), nil, &session); err != nil {
+		return nil, err
+	}
+	c.instance.sessionStatus(id, ScanCancelled)
+	return &session, nil
+}
+
+// Shutdown cancels the scans and sessions started by this client instance,
+// including through clients created from it by With, that have not been seen
+// to finish. Call it before the process exits, for example when a controller
+// pod receives SIGTERM, so autonomous sessions are not left running without
+// an owner. Starting scans or sessions after Shutdown returns
+// ErrClientShutdown.
+//
+// Scans and sessions being started when Shutdown is called are waited for
+// and cancelled too. Resources that already finished or no longer exist are
+// skipped. Shutdown returns the errors of the cancellations that failed, and stops early if
+// ctx is done.
+func (c *Client) Shutdown(ctx context.Context) error {
+	i := c.instance
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	i.closed = true
+	i.mu.Unlock()
+
+	created := make(chan struct{})
+	go func() {
+		i.creating.Wait()
+		close(created)
+	}()
+	select {
+	case <-created:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	i.mu.Lock()
+	scans := make([]string, 0, len(i.scans))
+	for id := range i.scans {
+		scans = append(scans, id)
+	}
+	sessions := make([]int64, 0, len(i.sessions))
+	for id := range i.sessions {
+		sessions = append(sessions, id)
+	}
+	i.mu.Unlock()
+
+	var errs []error
+	for _, id := range scans {
+		if _, err := c.CancelScanContext(ctx, id); err != nil && !finished(err) {
+			errs = append(errs, fmt.Errorf("cancelling scan %s: %w", id, err))
+		}
+	}
+	for _, id := range sessions {
+		if _, err := c.CancelSessionContext(ctx, id); err != nil && !finished(err) {
+			errs = append(errs, fmt.Errorf("cancelling session %d: %w", id, err))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// finished reports whether a cancellation failed because the resource had
+// already finished or was deleted.
+func finished(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict)
+}
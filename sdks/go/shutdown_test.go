@@ -0,0 +1,150 @@
+package aiptx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	var mu sync.Mutex
+	var cancelled []string
+	var client *Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && !strings.HasSuffix(r.URL.Path, "/cancel") {
+			if tag := r.Header.Get("X-AIPTX-Instance"); tag != client.InstanceTag() {
+				t.Errorf("Expected instance tag %s, got %q", client.InstanceTag(), tag)
+			}
+		}
+		switch r.URL.Path {
+		case "/scan":
+			var req ScanRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Target == "done.example.com" {
+				w.Write([]byte(`{"id": "scan-done", "status": "queued"}`))
+				return
+			}
+			w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+		case "/scans/scan-done":
+			w.Write([]byte(`{"id": "scan-done", "status": "completed"}`))
+		case "/projects/1/sessions":
+			w.Write([]byte(`{"id": 9, "status": "running"}`))
+		case "/scans/scan-1/cancel", "/sessions/9/cancel":
+			mu.Lock()
+			cancelled = append(cancelled, r.URL.Path)
+			mu.Unlock()
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client = NewClient(server.URL, WithAPIKey("test-key"))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+	if _, err := client.StartScan(&ScanRequest{Target: "done.example.com"}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+	if _, err := client.GetScanStatus("scan-done"); err != nil {
+		t.Fatalf("GetScanStatus failed: %v", err)
+	}
+	if _, err := client.With(WithOrganization("acme")).CreateSession(1, &SessionCreate{Name: "auto"}); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if len(cancelled) != 2 || cancelled[0] != "/scans/scan-1/cancel" || cancelled[1] != "/sessions/9/cancel" {
+		t.Errorf("Expected the running scan and session to be cancelled, got %v", cancelled)
+	}
+
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); !errors.Is(err, ErrClientShutdown) {
+		t.Errorf("Expected ErrClientShutdown, got %v", err)
+	}
+}
+
+func TestShutdownSkipsFinished(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scan":
+			w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+		case "/scans/scan-1/cancel":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"detail": "scan already finished"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com"}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected finished scans to be skipped, got %v", err)
+	}
+}
+
+func TestShutdownWaitsForStart(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cancelled := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scan":
+			close(started)
+			<-release
+			w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+		case "/scans/scan-1/cancel":
+			cancelled <- r.URL.Path
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	scanErr := make(chan error, 1)
+	go func() {
+		_, err := client.StartScan(&ScanRequest{Target: "example.com"})
+		scanErr <- err
+	}()
+	<-started
+
+	// Shutdown is called while the scan is being started.
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- client.Shutdown(context.Background())
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if err := <-scanErr; err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	select {
+	case <-cancelled:
+	default:
+		t.Error("Expected the scan started during Shutdown to be cancelled")
+	}
+}
+
+func TestInstanceTag(t *testing.T) {
+	a, b := NewClient(""), NewClient("")
+	if !strings.HasPrefix(a.InstanceTag(), "aiptx-go-") || a.InstanceTag() == b.InstanceTag() {
+		t.Errorf("Expected distinct instance tags, got %s and %s", a.InstanceTag(), b.InstanceTag())
+	}
+	if a.With().InstanceTag() != a.InstanceTag() {
+		t.Errorf("Expected clones to share the instance tag")
+	}
+}
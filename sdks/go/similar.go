@@ -0,0 +1,73 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// =============================================================================
+// Similar Findings
+// =============================================================================
+
+// Similarity search scopes.
+const (
+	ScopeProject      = "project"
+	ScopeOrganization = "organization"
+)
+
+// SimilarOptions configures FindSimilarFindings.
+type SimilarOptions struct {
+	// Scope is ScopeProject or ScopeOrganization. It defaults to
+	// ScopeOrganization, so shared infrastructure that shows up in many
+	// engagements is found across projects.
+	Scope string
+	// MinScore excludes matches with a lower score, from 0 to 1.
+	MinScore float64
+	// Limit is the maximum number of matches. Zero uses the server's
+	// default.
+	Limit int
+}
+
+// SimilarFinding is a finding that likely reports the same vulnerability as
+// another one.
+type SimilarFinding struct {
+	Finding Finding `json:"finding"`
+	// Score is the similarity, from 0 to 1, where 1 is an exact duplicate.
+	Score float64 `json:"score"`
+	// MatchedOn lists the attributes that matched, such as "value",
+	// "type" or "host".
+	MatchedOn []string `json:"matched_on,omitempty"`
+}
+
+// FindSimilarFindings returns findings that likely report the same
+// vulnerability as the finding with the given ID, most similar first. opts
+// may be nil.
+func (c *Client) FindSimilarFindings(findingID int64, opts *SimilarOptions) ([]SimilarFinding, error) {
+	return c.FindSimilarFindingsContext(context.Background(), findingID, opts)
+}
+
+// FindSimilarFindingsContext is like FindSimilarFindings but uses ctx for its
+// request.
+func (c *Client) FindSimilarFindingsContext(ctx context.Context, findingID int64, opts *SimilarOptions) ([]SimilarFinding, error) {
+	params := url.Values{}
+	params.Set("scope", ScopeOrganization)
+	if opts != nil {
+		if opts.Scope != "" {
+			params.Set("scope", opts.Scope)
+		}
+		if opts.MinScore > 0 {
+			params.Set("min_score", strconv.FormatFloat(opts.MinScore, 'f', -1, 64))
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	var similar []SimilarFinding
+	if err := c.requestContext(ctx, "GET", withQuery(fmt.Sprintf("/findings/%d/similar", findingID), params), nil, &similar); err != nil {
+		return nil, err
+	}
+	return similar, nil
+}
@@ -0,0 +1,33 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindSimilarFindings(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`[{"finding": {"id": 8, "project_id": 3}, "score": 0.97, "matched_on": ["value", "type"]}]`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	similar, err := client.FindSimilarFindings(7, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query != "scope=organization" {
+		t.Errorf("Expected organization scope by default, got %s", query)
+	}
+	if len(similar) != 1 || similar[0].Finding.ProjectID != 3 || similar[0].Score != 0.97 {
+		t.Errorf("Expected finding 8 from project 3, got %+v", similar)
+	}
+
+	client.FindSimilarFindings(7, &SimilarOptions{Scope: ScopeProject, MinScore: 0.8, Limit: 5})
+	if query != "limit=5&min_score=0.8&scope=project" {
+		t.Errorf("Expected options in query, got %s", query)
+	}
+}
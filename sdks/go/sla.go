@@ -0,0 +1,108 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// SLAs
+// =============================================================================
+
+// SLAPolicy sets how many days findings of each severity may stay unfixed,
+// e.g. critical findings within 7 days. Severities without a deadline have
+// no SLA.
+type SLAPolicy struct {
+	DeadlineDays map[Severity]int `json:"deadline_days"`
+
+	unknown *rawFields
+}
+
+// Deadline returns when finding must be fixed under the policy, and false if
+// its severity has no SLA.
+func (p *SLAPolicy) Deadline(finding Finding) (time.Time, bool) {
+	days, ok := p.DeadlineDays[finding.Severity]
+	if !ok || finding.DiscoveredAt.IsZero() {
+		return time.Time{}, false
+	}
+	return finding.DiscoveredAt.AddDate(0, 0, days), true
+}
+
+// SLABreach is a finding that was not fixed within its SLA.
+type SLABreach struct {
+	FindingID    int64             `json:"finding_id"`
+	ProjectID    int64             `json:"project_id"`
+	Severity     Severity          `json:"severity"`
+	Owner        string            `json:"owner,omitempty"`
+	Status       RemediationStatus `json:"status"`
+	DiscoveredAt time.Time         `json:"discovered_at"`
+	Deadline     time.Time         `json:"deadline"`
+	// FixedAt is set if the finding was fixed after its deadline.
+	FixedAt time.Time `json:"fixed_at,omitempty"`
+}
+
+// Overdue returns how long past its deadline the finding was fixed, or has
+// been open at now if it is not fixed.
+func (b *SLABreach) Overdue(now time.Time) time.Duration {
+	if !b.FixedAt.IsZero() {
+		now = b.FixedAt
+	}
+	return now.Sub(b.Deadline)
+}
+
+// slaPath returns the path of the SLA policy of a project, or of the
+// organization if projectID is zero.
+func slaPath(projectID int64) string {
+	if projectID == 0 {
+		return "/sla"
+	}
+	return fmt.Sprintf("/projects/%d/sla", projectID)
+}
+
+// GetSLAPolicy returns the SLA policy of a project, or the organization's
+// default policy if projectID is zero. Projects without their own policy
+// return the default.
+func (c *Client) GetSLAPolicy(projectID int64) (*SLAPolicy, error) {
+	return c.GetSLAPolicyContext(context.Background(), projectID)
+}
+
+// GetSLAPolicyContext is like GetSLAPolicy but uses ctx for its request.
+func (c *Client) GetSLAPolicyContext(ctx context.Context, projectID int64) (*SLAPolicy, error) {
+	var policy SLAPolicy
+	if err := c.requestContext(ctx, "GET", slaPath(projectID), nil, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// SetSLAPolicy sets the SLA policy of a project, or the organization's
+// default policy if projectID is zero.
+func (c *Client) SetSLAPolicy(projectID int64, policy *SLAPolicy) (*SLAPolicy, error) {
+	return c.SetSLAPolicyContext(context.Background(), projectID, policy)
+}
+
+// SetSLAPolicyContext is like SetSLAPolicy but uses ctx for its request.
+func (c *Client) SetSLAPolicyContext(ctx context.Context, projectID int64, policy *SLAPolicy) (*SLAPolicy, error) {
+	var updated SLAPolicy
+	if err := c.requestContext(ctx, "PUT", slaPath(projectID), policy, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// ListSLABreaches returns the findings of a project that missed their SLA
+// deadline, whether still open or fixed late. If projectID is zero, breaches
+// across all projects are returned.
+func (c *Client) ListSLABreaches(projectID int64) ([]SLABreach, error) {
+	return c.ListSLABreachesContext(context.Background(), projectID)
+}
+
+// ListSLABreachesContext is like ListSLABreaches but uses ctx for its request.
+func (c *Client) ListSLABreachesContext(ctx context.Context, projectID int64) ([]SLABreach, error) {
+	var breaches []SLABreach
+	if err := c.requestContext(ctx, "GET", slaPath(projectID)+"/breaches", nil, &breaches); err != nil {
+		return nil, err
+	}
+	return breaches, nil
+}
@@ -0,0 +1,54 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSLAPolicy(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/sla":
+			var policy map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&policy)
+			json.NewEncoder(w).Encode(policy)
+		case "/projects/42/sla/breaches":
+			w.Write([]byte(`[{"finding_id": 7, "project_id": 42, "severity": "critical", "status": "open",
+				"deadline": "2024-01-08T00:00:00Z"}]`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	policy, err := client.SetSLAPolicy(0, &SLAPolicy{DeadlineDays: map[Severity]int{SeverityCritical: 7, SeverityHigh: 30}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if policy.DeadlineDays[SeverityCritical] != 7 {
+		t.Errorf("Expected critical deadline of 7 days, got %v", policy.DeadlineDays)
+	}
+
+	found := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if deadline, ok := policy.Deadline(Finding{Severity: "critical", DiscoveredAt: found}); !ok || !deadline.Equal(found.AddDate(0, 0, 7)) {
+		t.Errorf("Expected deadline a week after discovery, got %v", deadline)
+	}
+	if _, ok := policy.Deadline(Finding{Severity: "low", DiscoveredAt: found}); ok {
+		t.Errorf("Expected no SLA for low findings")
+	}
+
+	breaches, err := client.Project(42).SLABreaches()
+	if err != nil || len(breaches) != 1 || breaches[0].Severity != SeverityCritical {
+		t.Fatalf("Expected one critical breach, got %+v (%v)", breaches, err)
+	}
+	if d := breaches[0].Overdue(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)); d != 48*time.Hour {
+		t.Errorf("Expected two days overdue, got %v", d)
+	}
+	if paths[0] != "PUT /sla" {
+		t.Errorf("Expected organization policy update, got %v", paths)
+	}
+}
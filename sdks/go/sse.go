@@ -0,0 +1,285 @@
+package aiptx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Server-Sent Events
+// =============================================================================
+
+// errStreamStalled is the cause of a dropped connection that stayed silent
+// for longer than its keepalive allows.
+var errStreamStalled = errors.New("stream stalled")
+
+// sseMaxLine bounds the length of a line and of the data of an event, to
+// protect against a misbehaving server, as wsMaxMessage does for WebSocket
+// messages.
+const sseMaxLine = 16 << 20
+
+// sseEvent is an event of a text/event-stream response.
+type sseEvent struct {
+	ID   string
+	Type string
+	Data string
+}
+
+// sseStream reads a text/event-stream endpoint, reconnecting after stalls
+// and transient failures as configured by its Keepalive and resuming after
+// the last event received.
+type sseStream struct {
+	client    *Client
+	path      string
+	keepalive Keepalive
+	// handle is called for each event. An error ends the stream.
+	handle func(sseEvent) error
+
+	lastID string
+	// retry is the reconnection delay requested by the server. It is a
+	// floor; the keepalive's backoff still applies.
+	retry time.Duration
+}
+
+// sseHandlerError wraps an error returned by handle, so that it ends the
+// stream instead of causing a reconnection.
+type sseHandlerError struct {
+	err error
+}
+
+func (e *sseHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// run reads events until ctx is done, handle fails or the stream fails in a
+// way reconnecting cannot fix.
+func (s *sseStream) run(ctx context.Context) error {
+	reconnect := newReconnector(s.keepalive)
+	s.client.checkVersion(ctx)
+
+	for {
+		received, err := s.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var handlerErr *sseHandlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+		if received {
+			reconnect.reset()
+		}
+		if err := reconnect.wait(ctx, err, s.retry); err != nil {
+			return err
+		}
+	}
+}
+
+// connect makes one connection and reads events from it until it ends,
+// reporting whether any event was received. Dropped and stalled connections
+// are reported as a *TransportError. The time spent in handle does not count
+// towards a stall.
+func (s *sseStream) connect(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stall := s.keepalive.PingInterval + s.keepalive.StallTimeout
+	var timer *time.Timer
+	if s.keepalive.StallTimeout > 0 {
+		timer = time.AfterFunc(stall, func() { cancel(errStreamStalled) })
+		defer timer.Stop()
+	}
+
+	header := http.Header{"Accept": {"text/event-stream"}, "Cache-Control": {"no-cache"}}
+	if s.lastID != "" {
+		header.Set("Last-Event-ID", s.lastID)
+	}
+	req, resp, err := s.client.attempt(ctx, "GET", s.path, "", header, nil)
+	if err != nil {
+		if cause := context.Cause(ctx); errors.Is(cause, errStreamStalled) {
+			err = &TransportError{Method: "GET", URL: s.path, Err: cause}
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	received := false
+	var ev sseEvent
+	var data strings.Builder
+	var body io.Reader = resp.Body
+	if timer != nil {
+		// Any data, even part of a long line, shows the connection is alive.
+		body = &activityReader{r: resp.Body, active: func() { timer.Reset(stall) }}
+	}
+	r := bufio.NewReader(body)
+	for {
+		line, err := readLine(r)
+		if errors.Is(err, errLineTooLong) {
+			return received, err
+		}
+		if err != nil {
+			if cause := context.Cause(ctx); cause != nil {
+				err = cause
+			}
+			return received, &TransportError{Method: "GET", URL: req.URL.Redacted(), Err: err}
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			// A blank line dispatches the event.
+			if data.Len() > 0 {
+				ev.Data = strings.TrimSuffix(data.String(), "\n")
+				if ev.ID != "" {
+					s.lastID = ev.ID
+				}
+				received = true
+				if timer != nil {
+					timer.Stop()
+				}
+				if err := s.handle(ev); err != nil {
+					return received, &sseHandlerError{err}
+				}
+				if timer != nil {
+					timer.Reset(stall)
+				}
+			}
+			ev = sseEvent{}
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comments keep idle connections alive.
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				ev.ID = value
+			case "event":
+				ev.Type = value
+			case "data":
+				if data.Len()+len(value) > sseMaxLine {
+					return received, errLineTooLong
+				}
+				data.WriteString(value)
+				data.WriteByte('\n')
+			case "retry":
+				if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+					s.retry = time.Duration(ms) * time.Millisecond
+				}
+			}
+		}
+	}
+}
+
+// activityReader calls active after every read that returns data.
+type activityReader struct {
+	r      io.Reader
+	active func()
+}
+
+func (a *activityReader) Read(p []byte) (int, error) {
+	n, err := a.r.Read(p)
+	if n > 0 {
+		a.active()
+	}
+	return n, err
+}
+
+// errLineTooLong ends a stream whose lines or event data exceed sseMaxLine.
+var errLineTooLong = fmt.Errorf("event stream: line exceeds %d bytes", sseMaxLine)
+
+// readLine reads a line of at most sseMaxLine bytes, including its end.
+func readLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if len(line)+len(chunk) > sseMaxLine {
+			return "", errLineTooLong
+		}
+		line = append(line, chunk...)
+		if err != bufio.ErrBufferFull {
+			return string(line), err
+		}
+	}
+}
+
+// =============================================================================
+// Finding Subscriptions
+// =============================================================================
+
+// SubscribeFindings sends findings matching filter to the returned channel
+// as soon as they are discovered, read from the server-sent events stream
+// at /events/findings. filter may be nil; it is applied by the server and
+// checked again on each finding, and its paging options are ignored.
+//
+// Dropped and stalled connections are reconnected as configured by
+// DefaultKeepalive, resuming after the last finding received. Both channels
+// are closed when the subscription ends, after the error that ended it is
+// sent on the error channel. Cancel ctx to stop:
+//
+//	findings, errc := client.SubscribeFindings(ctx, &aiptx.FindingsFilter{
+//	    MinSeverity: aiptx.SeverityCritical,
+//	})
+//	for f := range findings {
+//	    alert(f)
+//	}
+//	if err := <-errc; err != nil && ctx.Err() == nil {
+//	    return err
+//	}
+//
+// Unlike WatchFindings, which long-polls the event stream, a subscription
+// holds a single connection open, so proxies between the client and the
+// server must not buffer responses.
+func (c *Client) SubscribeFindings(ctx context.Context, filter *FindingsFilter) (<-chan Finding, <-chan error) {
+	findings := make(chan Finding)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(findings)
+		defer close(errc)
+		errc <- c.subscribeFindings(ctx, filter, findings)
+	}()
+	return findings, errc
+}
+
+func (c *Client) subscribeFindings(ctx context.Context, filter *FindingsFilter, findings chan<- Finding) error {
+	var f FindingsFilter
+	if filter != nil {
+		f = *filter
+	}
+	if err := f.validate(); err != nil {
+		return err
+	}
+	f.ListOptions = ListOptions{}
+
+	s := &sseStream{
+		client:    c.With(WithTimeout(0)),
+		path:      withQuery("/events/findings", f.values()),
+		keepalive: DefaultKeepalive,
+	}
+	s.handle = func(ev sseEvent) error {
+		if ev.Type != "" && ev.Type != EventFindingCreated {
+			return nil
+		}
+		var finding Finding
+		if err := json.Unmarshal([]byte(ev.Data), &finding); err != nil {
+			return fmt.Errorf("decoding finding event %q: %w", ev.ID, err)
+		}
+		if !f.Matches(finding) {
+			return nil
+		}
+		select {
+		case findings <- finding:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.run(ctx)
+}
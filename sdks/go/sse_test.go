@@ -0,0 +1,183 @@
+package aiptx
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubscribeFindings(t *testing.T) {
+	defer func(k Keepalive) { DefaultKeepalive = k }(DefaultKeepalive)
+	DefaultKeepalive.ReconnectDelay = time.Millisecond
+
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/findings" {
+			t.Errorf("Expected /events/findings, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected event stream to be requested, got %q", r.Header.Get("Accept"))
+		}
+		q := r.URL.Query()
+		if q.Get("project_id") != "42" || q.Get("min_severity") != "high" || q.Get("limit") != "" {
+			t.Errorf("Expected server-side filter without paging, got %s", r.URL.RawQuery)
+		}
+
+		connections++
+		switch connections {
+		case 1:
+			if id := r.Header.Get("Last-Event-ID"); id != "" {
+				t.Errorf("Expected no Last-Event-ID on the first connection, got %q", id)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			// The server ignores the filter, so the client filters instead.
+			fmt.Fprint(w, "retry: 1\n\n")
+			fmt.Fprint(w, ": keepalive\n\n")
+			fmt.Fprint(w, "id: 1\nevent: finding.created\ndata: {\"id\": 1, \"project_id\": 42, \"severity\": \"low\"}\n\n")
+			fmt.Fprint(w, "id: 2\nevent: finding.created\ndata: {\"id\": 2, \"project_id\": 42,\ndata:  \"severity\": \"critical\"}\n\n")
+			fmt.Fprint(w, "id: 3\nevent: finding.updated\ndata: {\"id\": 1, \"project_id\": 42, \"severity\": \"high\"}\n\n")
+			// The connection drops and is resumed.
+		case 2:
+			if id := r.Header.Get("Last-Event-ID"); id != "3" {
+				t.Errorf("Expected Last-Event-ID 3, got %q", id)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 4\r\ndata: {\"id\": 4, \"project_id\": 42, \"severity\": \"high\"}\r\n\r\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	findings, errc := client.SubscribeFindings(context.Background(), &FindingsFilter{
+		ProjectID:   42,
+		MinSeverity: SeverityHigh,
+		ListOptions: ListOptions{Limit: 10},
+	})
+
+	var ids []int64
+	for f := range findings {
+		ids = append(ids, f.ID)
+	}
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 4 {
+		t.Errorf("Expected findings 2 and 4, got %v", ids)
+	}
+	if err := <-errc; !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the error that ended the subscription, got %v", err)
+	}
+	if connections != 3 {
+		t.Errorf("Expected 3 connections, got %d", connections)
+	}
+}
+
+func TestSubscribeFindingsCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\ndata: {\"id\": 1, \"severity\": \"critical\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(server.URL)
+	findings, errc := client.SubscribeFindings(ctx, nil)
+
+	if f := <-findings; f.ID != 1 {
+		t.Errorf("Expected finding 1, got %d", f.ID)
+	}
+	cancel()
+	for range findings {
+		t.Errorf("Expected no more findings")
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSubscribeFindingsInvalidFilter(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0")
+	findings, errc := client.SubscribeFindings(context.Background(), &FindingsFilter{Severity: "severe"})
+	for range findings {
+	}
+	if err := <-errc; !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+}
+
+func TestSSEStream(t *testing.T) {
+	connections := 0
+	var reconnected time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections++
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch connections {
+		case 1:
+			fmt.Fprint(w, "retry: 400\n\nid: 1\ndata: slow\n\nid: 2\ndata: next\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case 2:
+			reconnected = time.Now()
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var events []string
+	var second time.Time
+	s := &sseStream{
+		client: NewClient(server.URL),
+		path:   "/events",
+		keepalive: Keepalive{
+			PingInterval:   100 * time.Millisecond,
+			StallTimeout:   100 * time.Millisecond,
+			ReconnectDelay: time.Millisecond,
+			MaxReconnects:  2,
+		},
+		handle: func(ev sseEvent) error {
+			events = append(events, ev.Data)
+			if len(events) == 1 {
+				// A slow consumer does not stall the stream.
+				time.Sleep(300 * time.Millisecond)
+			}
+			if len(events) == 2 {
+				second = time.Now()
+			}
+			return nil
+		},
+	}
+	err := s.run(ctx)
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("Expected ErrBadRequest, got %v", err)
+	}
+	if len(events) != 2 || events[1] != "next" {
+		t.Errorf("Expected both events before the stall, got %v", events)
+	}
+	if connections != 2 {
+		t.Errorf("Expected 2 connections, got %d", connections)
+	}
+	// The stall is detected 200ms after the second event, and the server's
+	// retry raises the reconnection delay above the backoff.
+	if wait := reconnected.Sub(second); wait < 600*time.Millisecond {
+		t.Errorf("Expected reconnection after the server's retry, got %s", wait)
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("data: ok\n" + strings.Repeat("x", sseMaxLine) + "\n"))
+	if line, err := readLine(r); err != nil || line != "data: ok\n" {
+		t.Errorf("Expected first line, got %q (%v)", line, err)
+	}
+	if _, err := readLine(r); !errors.Is(err, errLineTooLong) {
+		t.Errorf("Expected errLineTooLong, got %v", err)
+	}
+}
@@ -0,0 +1,339 @@
+package aiptx
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Attack Graph
+// =============================================================================
+
+// Attack graph node kinds.
+const (
+	NodeHost    = "host"
+	NodeService = "service"
+	NodeFinding = "finding"
+)
+
+// AttackGraph is the graph of hosts, services and findings of a project,
+// linked by how an attacker can move between them.
+type AttackGraph struct {
+	ProjectID int64             `json:"project_id"`
+	Nodes     []AttackGraphNode `json:"nodes"`
+	Edges     []AttackGraphEdge `json:"edges"`
+
+	unknown *rawFields
+}
+
+// AttackGraphNode is a host, service or finding in an attack graph.
+// FindingID is set for finding nodes.
+type AttackGraphNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Label     string `json:"label"`
+	FindingID int64  `json:"finding_id,omitempty"`
+}
+
+// AttackGraphEdge is a directed step from one node to another, such as a
+// host exposing a finding or one finding leading to another.
+type AttackGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// GetAttackGraph returns the attack graph of a project.
+func (c *Client) GetAttackGraph(projectID int64) (*AttackGraph, error) {
+	return c.GetAttackGraphContext(context.Background(), projectID)
+}
+
+// GetAttackGraphContext is like GetAttackGraph but uses ctx for its request.
+func (c *Client) GetAttackGraphContext(ctx context.Context, projectID int64) (*AttackGraph, error) {
+	var graph AttackGraph
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/projects/%d/attack-graph", projectID), nil, &graph); err != nil {
+		return nil, err
+	}
+	return &graph, nil
+}
+
+// =============================================================================
+// STIX
+// =============================================================================
+
+// STIXBundle is a STIX 2.1 bundle, ready to be marshalled to JSON and
+// published to a TAXII collection.
+type STIXBundle struct {
+	Type    string       `json:"type"`
+	ID      string       `json:"id"`
+	Objects []STIXObject `json:"objects"`
+}
+
+// STIXObject is a STIX 2.1 domain or relationship object. Only the
+// properties of the object types AIPTX exports are modelled.
+type STIXObject struct {
+	Type        string    `json:"type"`
+	SpecVersion string    `json:"spec_version"`
+	ID          string    `json:"id"`
+	Created     time.Time `json:"created"`
+	Modified    time.Time `json:"modified"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+
+	// InfrastructureTypes is set on infrastructure objects.
+	InfrastructureTypes []string `json:"infrastructure_types,omitempty"`
+	// KillChainPhases is set on attack patterns.
+	KillChainPhases []STIXKillChainPhase `json:"kill_chain_phases,omitempty"`
+	// ExternalReferences links vulnerabilities to CVE or CWE entries.
+	ExternalReferences []STIXExternalReference `json:"external_references,omitempty"`
+	// Confidence is set on vulnerabilities reported by AI, from 1 to 100.
+	Confidence int `json:"confidence,omitempty"`
+
+	// RelationshipType, SourceRef and TargetRef are set on relationships.
+	RelationshipType string `json:"relationship_type,omitempty"`
+	SourceRef        string `json:"source_ref,omitempty"`
+	TargetRef        string `json:"target_ref,omitempty"`
+}
+
+// STIXKillChainPhase places an attack pattern in a kill chain.
+type STIXKillChainPhase struct {
+	KillChainName string `json:"kill_chain_name"`
+	PhaseName     string `json:"phase_name"`
+}
+
+// STIXExternalReference points to an entry in an external catalogue.
+type STIXExternalReference struct {
+	SourceName string `json:"source_name"`
+	ExternalID string `json:"external_id,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// ObjectsOfType returns the objects of type typ, such as "vulnerability".
+func (b *STIXBundle) ObjectsOfType(typ string) []STIXObject {
+	var objects []STIXObject
+	for _, o := range b.Objects {
+		if o.Type == typ {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+// stixKillChain is the kill chain AIPTX stages map onto.
+const stixKillChain = "lockheed-martin-cyber-kill-chain"
+
+// stixPhases maps kill chain stages to Lockheed Martin phase names.
+var stixPhases = map[KillChainStage]string{
+	StageRecon:         "reconnaissance",
+	StageWeaponization: "weaponization",
+	StageExploitation:  "exploitation",
+	StageC2:            "command-and-control",
+	StageExfiltration:  "actions-on-objectives",
+}
+
+var (
+	cvePattern = regexp.MustCompile(`(?i)\bCVE-\d{4}-\d{4,}\b`)
+	cwePattern = regexp.MustCompile(`(?i)\bCWE-(\d+)\b`)
+)
+
+// stixNamespace seeds the deterministic object IDs, so exporting the same
+// project twice produces the same IDs and TAXII servers update objects
+// instead of duplicating them.
+var stixNamespace = [16]byte{0x8a, 0x1c, 0x3e, 0x57, 0x0d, 0x42, 0x4b, 0x6e, 0x9f, 0x21, 0xa7, 0x5c, 0xe0, 0x13, 0x88, 0x4d}
+
+// ExportSTIX returns the findings of a project and its attack graph as a
+// STIX 2.1 bundle. Each finding becomes a vulnerability, each finding type
+// an attack pattern in the kill chain stage of its findings, and the target
+// and each host in the attack graph an infrastructure object. Relationships
+// connect infrastructure to its vulnerabilities, attack patterns to the
+// vulnerabilities they target, and vulnerabilities along the graph's edges.
+//
+// Projects without an attack graph export the project target as their only
+// infrastructure.
+func (c *Client) ExportSTIX(projectID int64) (*STIXBundle, error) {
+	return c.ExportSTIXContext(context.Background(), projectID)
+}
+
+// ExportSTIXContext is like ExportSTIX but uses ctx for its request.
+func (c *Client) ExportSTIXContext(ctx context.Context, projectID int64) (*STIXBundle, error) {
+	project, err := c.GetProjectContext(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	_, err = c.ExportFindingsResumable(ctx, &FindingsFilter{ProjectID: projectID}, &MemoryCursorStore{}, func(page []Finding) error {
+		findings = append(findings, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	graph, err := c.GetAttackGraphContext(ctx, projectID)
+	if errors.Is(err, ErrNotFound) {
+		graph = &AttackGraph{}
+	} else if err != nil {
+		return nil, err
+	}
+	return buildSTIX(project, findings, graph), nil
+}
+
+// buildSTIX converts a project's findings and attack graph into a bundle.
+func buildSTIX(project *Project, findings []Finding, graph *AttackGraph) *STIXBundle {
+	b := &STIXBundle{Type: "bundle", ID: "bundle--" + randomUUID()}
+	// Objects take their times from the project and findings, or from the
+	// export when the server did not report one.
+	exported := time.Now().UTC()
+	created := stixTime(project.CreatedAt, exported)
+
+	target := stixObject("infrastructure", fmt.Sprintf("project/%d/target", project.ID), created)
+	target.Name = project.Target
+	target.Description = project.Name
+	target.InfrastructureTypes = []string{"unknown"}
+	b.Objects = append(b.Objects, target)
+
+	vulns := map[int64]string{}
+	patterns := map[string]int{}
+	for _, f := range findings {
+		v := stixObject("vulnerability", fmt.Sprintf("finding/%d", f.ID), stixTime(f.DiscoveredAt, exported))
+		v.Name = f.Type + ": " + f.Value
+		v.Description = f.Description
+		v.Labels = []string{"severity:" + f.Severity.String()}
+		if f.Confidence > 0 {
+			v.Confidence = max(int(math.Round(f.Confidence*100)), 1)
+		}
+		if cve := cvePattern.FindString(f.Value + " " + f.Description); cve != "" {
+			v.Name = cve
+			v.ExternalReferences = append(v.ExternalReferences, STIXExternalReference{SourceName: "cve", ExternalID: cve})
+		}
+		if m := cwePattern.FindStringSubmatch(f.Description); m != nil {
+			v.ExternalReferences = append(v.ExternalReferences, STIXExternalReference{
+				SourceName: "cwe",
+				ExternalID: "CWE-" + m[1],
+				URL:        "https://cwe.mitre.org/data/definitions/" + m[1] + ".html",
+			})
+		}
+		b.Objects = append(b.Objects, v)
+		vulns[f.ID] = v.ID
+
+		key := f.Type + "/" + string(f.KillChainStage)
+		i, ok := patterns[key]
+		if !ok {
+			p := stixObject("attack-pattern", "pattern/"+key, created)
+			p.Name = f.Type
+			if phase, ok := stixPhases[f.KillChainStage]; ok {
+				p.KillChainPhases = []STIXKillChainPhase{{KillChainName: stixKillChain, PhaseName: phase}}
+			}
+			b.Objects = append(b.Objects, p)
+			i = len(b.Objects) - 1
+			patterns[key] = i
+		}
+		b.addRelationship("targets", b.Objects[i].ID, v.ID, created)
+	}
+
+	// Map graph nodes to objects. Hosts become infrastructure and findings
+	// their vulnerabilities; other nodes are only traversed.
+	refs := map[string]string{}
+	hosts := 0
+	for _, n := range graph.Nodes {
+		switch n.Kind {
+		case NodeHost:
+			h := stixObject("infrastructure", fmt.Sprintf("project/%d/host/%s", project.ID, n.ID), created)
+			h.Name = n.Label
+			h.InfrastructureTypes = []string{"unknown"}
+			b.Objects = append(b.Objects, h)
+			refs[n.ID] = h.ID
+			hosts++
+		case NodeFinding:
+			if id, ok := vulns[n.FindingID]; ok {
+				refs[n.ID] = id
+			}
+		}
+	}
+	linked := map[string]bool{}
+	for _, e := range graph.Edges {
+		from, to := refs[e.From], refs[e.To]
+		if from == "" || to == "" {
+			continue
+		}
+		kind := "related-to"
+		if stixType(from) == "infrastructure" && stixType(to) == "vulnerability" {
+			kind = "has"
+			linked[to] = true
+		}
+		b.addRelationship(kind, from, to, created)
+	}
+
+	// Without hosts, every vulnerability belongs to the target; otherwise
+	// only those the graph does not place on a host.
+	for _, f := range findings {
+		if id := vulns[f.ID]; hosts == 0 || !linked[id] {
+			b.addRelationship("has", target.ID, id, created)
+		}
+	}
+	return b
+}
+
+func (b *STIXBundle) addRelationship(kind, from, to string, created time.Time) {
+	r := stixObject("relationship", kind+"/"+from+"/"+to, created)
+	r.RelationshipType = kind
+	r.SourceRef = from
+	r.TargetRef = to
+	b.Objects = append(b.Objects, r)
+}
+
+func stixObject(typ, key string, created time.Time) STIXObject {
+	return STIXObject{
+		Type:        typ,
+		SpecVersion: "2.1",
+		ID:          typ + "--" + nameUUID(typ+"/"+key),
+		Created:     created,
+		Modified:    created,
+	}
+}
+
+// stixTime returns t in UTC, or fallback if t is zero.
+func stixTime(t, fallback time.Time) time.Time {
+	if t.IsZero() {
+		return fallback
+	}
+	return t.UTC()
+}
+
+// stixType returns the object type of a STIX ID.
+func stixType(id string) string {
+	typ, _, _ := strings.Cut(id, "--")
+	return typ
+}
+
+// nameUUID returns the version 5 UUID of name in stixNamespace.
+func nameUUID(name string) string {
+	h := sha1.New()
+	h.Write(stixNamespace[:])
+	h.Write([]byte(name))
+	var u [16]byte
+	copy(u[:], h.Sum(nil))
+	u[6] = u[6]&0x0f | 0x50
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u)
+}
+
+// randomUUID returns a version 4 UUID.
+func randomUUID() string {
+	var u [16]byte
+	rand.Read(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u)
+}
+
+func formatUUID(u [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
@@ -0,0 +1,111 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExportSTIX(t *testing.T) {
+	graph := `{"nodes": [
+		{"id": "h1", "kind": "host", "label": "10.0.0.5"},
+		{"id": "f1", "kind": "finding", "finding_id": 1},
+		{"id": "f2", "kind": "finding", "finding_id": 2}
+	], "edges": [
+		{"from": "h1", "to": "f1", "kind": "exposes"},
+		{"from": "f1", "to": "f2", "kind": "leads_to"}
+	]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/3":
+			w.Write([]byte(`{"id": 3, "name": "Acme", "target": "acme.example", "created_at": "2024-01-15T09:00:00Z"}`))
+		case "/findings":
+			if r.URL.Query().Get("project_id") != "3" {
+				t.Errorf("Expected project_id=3, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[
+				{"id": 1, "type": "rce", "value": "Log4Shell CVE-2021-44228", "severity": "critical", "kill_chain_stage": "exploitation"},
+				{"id": 2, "type": "credential", "value": "db password", "description": "CWE-798 hardcoded", "severity": "high", "confidence": 0.64}
+			]`))
+		case "/projects/3/attack-graph":
+			w.Write([]byte(graph))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	bundle, err := client.ExportSTIX(3)
+	if err != nil {
+		t.Fatalf("ExportSTIX failed: %v", err)
+	}
+	if bundle.Type != "bundle" || !strings.HasPrefix(bundle.ID, "bundle--") {
+		t.Errorf("Expected bundle, got %s %s", bundle.Type, bundle.ID)
+	}
+
+	vulns := bundle.ObjectsOfType("vulnerability")
+	if len(vulns) != 2 || vulns[0].Name != "CVE-2021-44228" || vulns[0].ExternalReferences[0].SourceName != "cve" {
+		t.Fatalf("Expected CVE vulnerability, got %+v", vulns)
+	}
+	if refs := vulns[1].ExternalReferences; len(refs) != 1 || refs[0].ExternalID != "CWE-798" {
+		t.Errorf("Expected CWE reference, got %+v", refs)
+	}
+	if vulns[0].Confidence != 0 || vulns[1].Confidence != 64 {
+		t.Errorf("Expected confidence 0 and 64, got %d and %d", vulns[0].Confidence, vulns[1].Confidence)
+	}
+	patterns := bundle.ObjectsOfType("attack-pattern")
+	if len(patterns) != 2 || patterns[0].KillChainPhases[0].PhaseName != "exploitation" || patterns[1].KillChainPhases != nil {
+		t.Errorf("Expected attack patterns with kill chain phases, got %+v", patterns)
+	}
+	infra := bundle.ObjectsOfType("infrastructure")
+	if len(infra) != 2 || infra[0].Name != "acme.example" || infra[1].Name != "10.0.0.5" {
+		t.Fatalf("Expected target and host infrastructure, got %+v", infra)
+	}
+	if types := infra[1].InfrastructureTypes; len(types) != 1 || types[0] != "unknown" {
+		t.Errorf("Expected infrastructure type unknown, got %v", types)
+	}
+	if infra[0].Created.Year() != 2024 || vulns[0].Created.IsZero() {
+		t.Errorf("Expected project and export times, got %v and %v", infra[0].Created, vulns[0].Created)
+	}
+
+	var rels []string
+	for _, r := range bundle.ObjectsOfType("relationship") {
+		rels = append(rels, r.SourceRef[:strings.Index(r.SourceRef, "--")]+" "+r.RelationshipType+" "+r.TargetRef[:strings.Index(r.TargetRef, "--")])
+	}
+	want := "attack-pattern targets vulnerability, attack-pattern targets vulnerability, " +
+		"infrastructure has vulnerability, vulnerability related-to vulnerability, infrastructure has vulnerability"
+	if got := strings.Join(rels, ", "); got != want {
+		t.Errorf("Expected relationships %s, got %s", want, got)
+	}
+
+	// IDs are stable across exports, so TAXII servers update objects.
+	again, err := client.ExportSTIX(3)
+	if err != nil || again.Objects[1].ID != bundle.Objects[1].ID || again.ID == bundle.ID {
+		t.Errorf("Expected stable object IDs and a new bundle ID")
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/3":
+			w.Write([]byte(`{"id": 3, "target": "acme.example"}`))
+		case "/findings":
+			w.Write([]byte(`[{"id": 1, "type": "xss", "value": "q"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	bundle, err = client.ExportSTIX(3)
+	if err != nil {
+		t.Fatalf("Expected export without attack graph, got %v", err)
+	}
+	if rels := bundle.ObjectsOfType("relationship"); len(rels) != 2 || rels[1].SourceRef != bundle.Objects[0].ID {
+		t.Errorf("Expected vulnerability to belong to the target, got %+v", rels)
+	}
+	for _, o := range bundle.Objects {
+		if o.Created.IsZero() || o.Modified.IsZero() {
+			t.Errorf("Expected undated objects to use the export time, got %+v", o)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// =============================================================================
+// Suppression Rules
+// =============================================================================
+
+// SuppressionRule marks findings as accepted so they are suppressed on future
+// scans, such as intentional self-signed certificates in a lab network.
+// Creating, deleting and applying rules is recorded in an audit trail.
+type SuppressionRule struct {
+	ID string `json:"id,omitempty"`
+	// Type is the finding type the rule applies to, e.g. "ssl_self_signed".
+	Type string `json:"type"`
+	// Pattern is matched against finding values. It is a glob such as
+	// "*.lab.example.com" or a CIDR such as "10.20.0.0/16".
+	Pattern string           `json:"pattern"`
+	Scope   SuppressionScope `json:"scope"`
+	// Expiry is when the rule stops applying, or nil if it never expires.
+	Expiry *time.Time `json:"expiry,omitempty"`
+	// Reason records why the findings are accepted. It is required.
+	Reason string `json:"reason"`
+
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Suppressed is the number of findings the rule has suppressed.
+	Suppressed int `json:"suppressed,omitempty"`
+
+	unknown *rawFields
+}
+
+// SuppressionScope limits a rule to some projects. The zero value applies
+// the rule to every project of the organization.
+type SuppressionScope struct {
+	ProjectIDs []int64 `json:"project_ids,omitempty"`
+}
+
+// Suppression audit actions.
+const (
+	SuppressionCreated = "created"
+	SuppressionDeleted = "deleted"
+	SuppressionApplied = "applied"
+	SuppressionExpired = "expired"
+)
+
+// SuppressionAuditEntry is one entry of a suppression rule's audit trail.
+type SuppressionAuditEntry struct {
+	RuleID string `json:"rule_id"`
+	Action string `json:"action"`
+	Actor  string `json:"actor,omitempty"`
+	// FindingID is set for SuppressionApplied entries.
+	FindingID int64     `json:"finding_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// CreateSuppressionRule creates a rule that suppresses matching findings on
+// future scans.
+func (c *Client) CreateSuppressionRule(rule *SuppressionRule) (*SuppressionRule, error) {
+	return c.CreateSuppressionRuleContext(context.Background(), rule)
+}
+
+// CreateSuppressionRuleContext is like CreateSuppressionRule but uses ctx for
+// its request.
+func (c *Client) CreateSuppressionRuleContext(ctx context.Context, rule *SuppressionRule) (*SuppressionRule, error) {
+	if rule.Reason == "" {
+		return nil, fmt.Errorf("suppression rule for %q has no reason", rule.Pattern)
+	}
+	var created SuppressionRule
+	if err := c.requestContext(ctx, "POST", "/suppressions", rule, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetSuppressionRule returns a suppression rule by ID.
+func (c *Client) GetSuppressionRule(id string) (*SuppressionRule, error) {
+	return c.GetSuppressionRuleContext(context.Background(), id)
+}
+
+// GetSuppressionRuleContext is like GetSuppressionRule but uses ctx for its
+// request.
+func (c *Client) GetSuppressionRuleContext(ctx context.Context, id string) (*SuppressionRule, error) {
+	var rule SuppressionRule
+	if err := c.requestContext(ctx, "GET", "/suppressions/"+id, nil, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// ListSuppressionRules returns the suppression rules matching opts, including
+// expired ones.
+func (c *Client) ListSuppressionRules(opts *ListOptions) ([]SuppressionRule, error) {
+	return c.ListSuppressionRulesContext(context.Background(), opts)
+}
+
+// ListSuppressionRulesContext is like ListSuppressionRules but uses ctx for
+// its request.
+func (c *Client) ListSuppressionRulesContext(ctx context.Context, opts *ListOptions) ([]SuppressionRule, error) {
+	var rules []SuppressionRule
+	if err := c.requestContext(ctx, "GET", withQuery("/suppressions", opts.values()), nil, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// DeleteSuppressionRule deletes a suppression rule, recording reason in the
+// audit trail. Findings it already suppressed stay suppressed.
+func (c *Client) DeleteSuppressionRule(id, reason string) error {
+	return c.DeleteSuppressionRuleContext(context.Background(), id, reason)
+}
+
+// DeleteSuppressionRuleContext is like DeleteSuppressionRule but uses ctx for
+// its request.
+func (c *Client) DeleteSuppressionRuleContext(ctx context.Context, id, reason string) error {
+	params := url.Values{}
+	if reason != "" {
+		params.Set("reason", reason)
+	}
+	return c.requestContext(ctx, "DELETE", withQuery("/suppressions/"+id, params), nil, nil)
+}
+
+// SuppressionAudit returns the audit trail of a suppression rule, oldest
+// entry first. It remains available after the rule is deleted.
+func (c *Client) SuppressionAudit(id string) ([]SuppressionAuditEntry, error) {
+	return c.SuppressionAuditContext(context.Background(), id)
+}
+
+// SuppressionAuditContext is like SuppressionAudit but uses ctx for its
+// request.
+func (c *Client) SuppressionAuditContext(ctx context.Context, id string) ([]SuppressionAuditEntry, error) {
+	var entries []SuppressionAuditEntry
+	if err := c.requestContext(ctx, "GET", "/suppressions/"+id+"/audit", nil, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
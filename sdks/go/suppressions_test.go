@@ -0,0 +1,67 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSuppressionRules(t *testing.T) {
+	var deleteReason string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /suppressions":
+			var rule map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&rule)
+			if _, ok := rule["expiry"]; ok {
+				t.Errorf("Expected no expiry for a permanent rule, got %v", rule)
+			}
+			rule["id"] = "sr-1"
+			json.NewEncoder(w).Encode(rule)
+		case "DELETE /suppressions/sr-1":
+			deleteReason = r.URL.Query().Get("reason")
+		case "GET /suppressions/sr-1/audit":
+			w.Write([]byte(`[{"rule_id": "sr-1", "action": "created", "actor": "alice"}, {"rule_id": "sr-1", "action": "deleted", "actor": "bob", "reason": "lab retired"}]`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	if _, err := client.CreateSuppressionRule(&SuppressionRule{Type: "ssl_self_signed", Pattern: "10.20.0.0/16"}); err == nil {
+		t.Errorf("Expected error for a rule without a reason")
+	}
+
+	rule, err := client.CreateSuppressionRule(&SuppressionRule{
+		Type:    "ssl_self_signed",
+		Pattern: "10.20.0.0/16",
+		Scope:   SuppressionScope{ProjectIDs: []int64{42}},
+		Reason:  "lab VLAN uses self-signed certificates",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rule.ID != "sr-1" || rule.Scope.ProjectIDs[0] != 42 {
+		t.Errorf("Expected created rule, got %+v", rule)
+	}
+
+	if err := client.DeleteSuppressionRule("sr-1", "lab retired"); err != nil || deleteReason != "lab retired" {
+		t.Errorf("Expected delete with reason, got %q (%v)", deleteReason, err)
+	}
+
+	audit, err := client.SuppressionAudit("sr-1")
+	if err != nil || len(audit) != 2 || audit[1].Action != SuppressionDeleted {
+		t.Errorf("Expected audit trail, got %+v (%v)", audit, err)
+	}
+}
+
+func TestSuppressionRuleExpiry(t *testing.T) {
+	expiry := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	data, _ := json.Marshal(&SuppressionRule{Reason: "temporary", Expiry: &expiry})
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	if fields["expiry"] != "2026-12-31T00:00:00Z" {
+		t.Errorf("Expected expiry to be encoded, got %s", data)
+	}
+}
@@ -0,0 +1,250 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	// Testing windows name IANA time zones, whose database minimal images
+	// lack.
+	_ "time/tzdata"
+)
+
+// =============================================================================
+// Testing Windows
+// =============================================================================
+
+// ErrOutsideTestingWindow is returned by StartScan when a project's testing
+// window is closed and the request does not set QueueOutsideWindow.
+var ErrOutsideTestingWindow = errors.New("outside testing window")
+
+// TestingWindow restricts when a project may be scanned, following the
+// customer's rules of engagement. The zero TestingWindow allows scanning at
+// any time.
+type TestingWindow struct {
+	// Timezone is the IANA time zone the window is given in, such as
+	// "Europe/Berlin". It defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// Days are the days of the week on which the window opens, with 0 for
+	// Sunday. If empty, it opens every day.
+	Days []time.Weekday `json:"days,omitempty"`
+	// Start and End are the daily opening and closing times, as "15:04". A
+	// window whose End is before its Start closes the next day. If they are
+	// equal, the window is open all day.
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	// Blackouts are dates, as "2006-01-02", on which the window does not
+	// open, such as change freezes.
+	Blackouts []string `json:"blackouts,omitempty"`
+}
+
+// window is a parsed TestingWindow.
+type window struct {
+	*TestingWindow
+	loc        *time.Location
+	start, end int // minutes after midnight
+}
+
+func (w *TestingWindow) parse() (*window, error) {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("testing window: %w", err)
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return nil, fmt.Errorf("testing window start: %w", err)
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return nil, fmt.Errorf("testing window end: %w", err)
+	}
+	for _, day := range w.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("testing window: invalid day %d", day)
+		}
+	}
+	for _, date := range w.Blackouts {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("testing window blackout: %w", err)
+		}
+	}
+	return &window{TestingWindow: w, loc: loc, start: start, end: end}, nil
+}
+
+// parseClock parses a "15:04" time of day into minutes after midnight.
+func parseClock(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains reports whether the window is open at t.
+func (w *TestingWindow) Contains(t time.Time) (bool, error) {
+	pw, err := w.parse()
+	if err != nil {
+		return false, err
+	}
+	return pw.contains(t), nil
+}
+
+// NextOpen returns the earliest time from t on at which the window is open,
+// which is t itself if the window is open then.
+func (w *TestingWindow) NextOpen(t time.Time) (time.Time, error) {
+	pw, err := w.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if pw.contains(t) {
+		return t, nil
+	}
+	local := t.In(pw.loc)
+	// Blackouts may close the window for any number of days; give up once
+	// every weekday of a year has been tried.
+	for i := 0; i <= 372; i++ {
+		open := time.Date(local.Year(), local.Month(), local.Day()+i, pw.start/60, pw.start%60, 0, 0, pw.loc)
+		if open.After(t) && pw.opensOn(open) {
+			return open, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("testing window never opens")
+}
+
+func (w *window) contains(t time.Time) bool {
+	local := t.In(w.loc)
+	now := local.Hour()*60 + local.Minute()
+	day := local
+	switch {
+	case w.start == w.end:
+	case w.start < w.end:
+		if now < w.start || now >= w.end {
+			return false
+		}
+	case now < w.end:
+		// Still open from the previous day.
+		day = local.AddDate(0, 0, -1)
+	case now < w.start:
+		return false
+	}
+	return w.opensOn(day)
+}
+
+// opensOn reports whether the window opens on the day of t.
+func (w *window) opensOn(t time.Time) bool {
+	if len(w.Days) > 0 {
+		found := false
+		for _, day := range w.Days {
+			if day == t.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	date := t.Format("2006-01-02")
+	for _, blackout := range w.Blackouts {
+		if blackout == date {
+			return false
+		}
+	}
+	return true
+}
+
+// testingWindowTTL is how long a client caches the testing window of a
+// project.
+const testingWindowTTL = time.Minute
+
+// testingWindows caches the testing windows of projects, so starting scans
+// does not fetch the project every time. It is shared by clients created
+// with With.
+type testingWindows struct {
+	mu      sync.Mutex
+	entries map[int64]testingWindowEntry
+}
+
+type testingWindowEntry struct {
+	window  *TestingWindow
+	expires time.Time
+}
+
+func (w *testingWindows) get(projectID int64) (*TestingWindow, bool) {
+	if w == nil {
+		return nil, false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	entry, ok := w.entries[projectID]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.window, true
+}
+
+func (w *testingWindows) put(projectID int64, window *TestingWindow) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.entries[projectID] = testingWindowEntry{window: window, expires: time.Now().Add(testingWindowTTL)}
+}
+
+// forget drops the cached window of a project that was changed.
+func (w *testingWindows) forget(projectID int64) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.entries, projectID)
+}
+
+// testingWindow returns the testing window of a project, which is nil if it
+// has none, from the cache if possible.
+func (c *Client) testingWindow(ctx context.Context, projectID int64) (*TestingWindow, error) {
+	if window, ok := c.testingWindows.get(projectID); ok {
+		return window, nil
+	}
+	project, err := c.GetProjectContext(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	c.testingWindows.put(projectID, project.TestingWindow)
+	return project.TestingWindow, nil
+}
+
+// checkTestingWindow enforces the testing window of the project a scan is
+// started in. Outside the window, it returns an error wrapping
+// ErrOutsideTestingWindow, or a copy of req queued until the window opens
+// if req sets QueueOutsideWindow. The scan is never started without a
+// checked window: if the project cannot be fetched, the error is returned.
+func (c *Client) checkTestingWindow(ctx context.Context, req *ScanRequest) (*ScanRequest, error) {
+	window, err := c.testingWindow(ctx, req.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("checking testing window of project %d: %w", req.ProjectID, err)
+	}
+	if window == nil {
+		return req, nil
+	}
+	now := time.Now()
+	open, err := window.NextOpen(now)
+	if err != nil {
+		return nil, err
+	}
+	if open.Equal(now) {
+		return req, nil
+	}
+	if !req.QueueOutsideWindow {
+		return nil, fmt.Errorf("%w: project %d opens at %s", ErrOutsideTestingWindow, req.ProjectID, open.Format(time.RFC3339))
+	}
+	queued := *req
+	queued.StartAfter = &open
+	return &queued, nil
+}
@@ -0,0 +1,158 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTestingWindow(t *testing.T) {
+	w := &TestingWindow{
+		Timezone:  "Europe/Berlin",
+		Days:      []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:     "22:00",
+		End:       "06:00",
+		Blackouts: []string{"2026-12-24"},
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	tests := []struct {
+		at   time.Time
+		open bool
+	}{
+		{time.Date(2026, 10, 12, 23, 0, 0, 0, berlin), true},  // Monday night
+		{time.Date(2026, 10, 13, 5, 59, 0, 0, berlin), true},  // opened Monday
+		{time.Date(2026, 10, 13, 12, 0, 0, 0, berlin), false}, // Tuesday noon
+		{time.Date(2026, 10, 12, 1, 0, 0, 0, berlin), false},  // opened Sunday
+		{time.Date(2026, 12, 24, 23, 0, 0, 0, berlin), false}, // blackout
+	}
+	for _, tt := range tests {
+		open, err := w.Contains(tt.at)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if open != tt.open {
+			t.Errorf("Expected open=%v at %s, got %v", tt.open, tt.at, open)
+		}
+	}
+
+	// The window next opens on Friday night, then not until Monday night.
+	next, err := w.NextOpen(time.Date(2026, 10, 16, 12, 0, 0, 0, berlin))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 10, 16, 22, 0, 0, 0, berlin); !next.Equal(want) {
+		t.Errorf("Expected next opening %s, got %s", want, next)
+	}
+	next, _ = w.NextOpen(time.Date(2026, 10, 17, 12, 0, 0, 0, berlin))
+	if want := time.Date(2026, 10, 19, 22, 0, 0, 0, berlin); !next.Equal(want) {
+		t.Errorf("Expected next opening %s, got %s", want, next)
+	}
+
+	if _, err := (&TestingWindow{Timezone: "Mars/Olympus"}).Contains(time.Now()); err == nil {
+		t.Errorf("Expected error for unknown time zone")
+	}
+	if _, err := (&TestingWindow{Days: []time.Weekday{}}).NextOpen(time.Now()); err != nil {
+		t.Errorf("Expected empty window to be always open, got %v", err)
+	}
+}
+
+func TestStartScanTestingWindow(t *testing.T) {
+	// A window that opened an hour ago and closes in two hours, on any day
+	// but tomorrow.
+	now := time.Now().UTC()
+	tomorrow := now.AddDate(0, 0, 1).Format("2006-01-02")
+	open := &TestingWindow{
+		Start:     now.Add(-time.Hour).Format("15:04"),
+		End:       now.Add(2 * time.Hour).Format("15:04"),
+		Blackouts: []string{tomorrow},
+	}
+	closed := &TestingWindow{Blackouts: []string{now.Format("2006-01-02"), tomorrow}}
+
+	var scan ScanRequest
+	scans, fetches := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/1":
+			fetches++
+			json.NewEncoder(w).Encode(Project{ID: 1, TestingWindow: open})
+		case "/projects/2":
+			json.NewEncoder(w).Encode(Project{ID: 2, TestingWindow: closed})
+		case "/scan":
+			scans++
+			scan = ScanRequest{}
+			json.NewDecoder(r.Body).Decode(&scan)
+			w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	if _, err := client.StartScan(&ScanRequest{ProjectID: 1, Target: "example.com"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scan.StartAfter != nil {
+		t.Errorf("Expected scan inside window to start immediately, got %s", scan.StartAfter)
+	}
+	if _, err := client.StartScan(&ScanRequest{ProjectID: 1, Target: "example.com"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("Expected the project to be fetched once, got %d", fetches)
+	}
+
+	_, err := client.StartScan(&ScanRequest{ProjectID: 2, Target: "example.com"})
+	if !errors.Is(err, ErrOutsideTestingWindow) {
+		t.Fatalf("Expected ErrOutsideTestingWindow, got %v", err)
+	}
+	if scans != 2 {
+		t.Errorf("Expected scan outside window not to be sent")
+	}
+
+	req := &ScanRequest{ProjectID: 2, Target: "example.com", QueueOutsideWindow: true}
+	if _, err := client.StartScan(req); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := time.Date(now.Year(), now.Month(), now.Day()+2, 0, 0, 0, 0, time.UTC)
+	if scan.StartAfter == nil || !scan.StartAfter.Equal(want) {
+		t.Errorf("Expected scan queued until %s, got %v", want, scan.StartAfter)
+	}
+	if req.StartAfter != nil {
+		t.Errorf("Caller's request must not be modified")
+	}
+}
+
+func TestStartScanTestingWindowUnavailable(t *testing.T) {
+	scans := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/1":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/projects/2":
+			w.WriteHeader(http.StatusNotFound)
+		case "/scan":
+			scans++
+			w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}))
+
+	// Scans are not started without a checked window, even if the failure
+	// is transient.
+	if _, err := client.StartScan(&ScanRequest{ProjectID: 1, Target: "example.com"}); !errors.Is(err, ErrServer) {
+		t.Errorf("Expected the server error when the project is unavailable, got %v", err)
+	}
+	if _, err := client.StartScan(&ScanRequest{ProjectID: 1, Target: "example.com", QueueOutsideWindow: true}); err == nil {
+		t.Errorf("Expected an error for a queued scan when the project is unavailable")
+	}
+	if _, err := client.StartScan(&ScanRequest{ProjectID: 2, Target: "example.com"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing project, got %v", err)
+	}
+	if scans != 0 {
+		t.Errorf("Expected no scan to be sent, got %d", scans)
+	}
+}
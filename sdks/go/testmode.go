@@ -0,0 +1,25 @@
+package aiptx
+
+import "context"
+
+// =============================================================================
+// Test Mode
+// =============================================================================
+
+// SetServerTestMode turns the server's test mode on or off. In test mode
+// every scan uses canned LLM responses, so integration tests of SDK
+// workflows are deterministic and do not spend LLM tokens. To mock the LLM
+// for a single scan, set ScanRequest.MockLLM instead.
+//
+// Test mode affects every user of the server and should only be enabled on
+// test deployments.
+func (c *Client) SetServerTestMode(enabled bool) error {
+	return c.SetServerTestModeContext(context.Background(), enabled)
+}
+
+// SetServerTestModeContext is like SetServerTestMode but uses ctx for its
+// request.
+func (c *Client) SetServerTestModeContext(ctx context.Context, enabled bool) error {
+	body := map[string]bool{"enabled": enabled}
+	return c.requestContext(ctx, "PUT", "/test-mode", body, nil)
+}
@@ -0,0 +1,45 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetServerTestMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/test-mode" {
+			t.Errorf("Expected PUT /test-mode, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]bool
+		json.NewDecoder(r.Body).Decode(&body)
+		if !body["enabled"] {
+			t.Errorf("Expected enabled true, got %v", body)
+		}
+		w.Write([]byte(`{"enabled": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	if err := client.SetServerTestMode(true); err != nil {
+		t.Fatalf("SetServerTestMode failed: %v", err)
+	}
+}
+
+func TestScanRequestMockLLM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["mock_llm"] != true {
+			t.Errorf("Expected mock_llm true, got %v", body["mock_llm"])
+		}
+		w.Write([]byte(`{"id": "scan-1", "status": "queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	if _, err := client.StartScan(&ScanRequest{Target: "example.com", AI: true, MockLLM: true}); err != nil {
+		t.Fatalf("StartScan failed: %v", err)
+	}
+}
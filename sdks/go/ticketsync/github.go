@@ -0,0 +1,198 @@
+package ticketsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// GitHub issue states.
+const (
+	IssueOpen   = "open"
+	IssueClosed = "closed"
+)
+
+// DefaultGitHubStates maps remediation statuses to issue states.
+var DefaultGitHubStates = map[aiptx.RemediationStatus]string{
+	aiptx.RemediationOpen:       IssueOpen,
+	aiptx.RemediationInProgress: IssueOpen,
+	aiptx.RemediationFixed:      IssueClosed,
+	aiptx.RemediationVerified:   IssueClosed,
+	aiptx.RemediationAccepted:   IssueClosed,
+}
+
+// DefaultGitHubRemoteStates maps issue states back to remediation statuses.
+// A closed issue marks the finding fixed, awaiting verification by a retest.
+var DefaultGitHubRemoteStates = map[string]aiptx.RemediationStatus{
+	IssueOpen:   aiptx.RemediationOpen,
+	IssueClosed: aiptx.RemediationFixed,
+}
+
+// GitHub tracks findings as issues in a GitHub repository, labelled "aiptx"
+// and linked to their finding by a hidden comment in the issue body, so no
+// label is created per finding.
+type GitHub struct {
+	// Owner and Repo name the repository, as in github.com/Owner/Repo.
+	Owner string
+	Repo  string
+	// Token is a token allowed to read and write issues.
+	Token string
+	// URL defaults to https://api.github.com. Set it to
+	// https://HOST/api/v3 for GitHub Enterprise Server.
+	URL string
+	// States and RemoteStates default to DefaultGitHubStates and
+	// DefaultGitHubRemoteStates.
+	States       map[aiptx.RemediationStatus]string
+	RemoteStates map[string]aiptx.RemediationStatus
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewGitHub returns a GitHub tracker creating issues in owner/repo.
+func NewGitHub(owner, repo, token string) *GitHub {
+	return &GitHub{Owner: owner, Repo: repo, Token: token}
+}
+
+// Name returns "GitHub".
+func (g *GitHub) Name() string {
+	return "GitHub"
+}
+
+type githubIssue struct {
+	Number    int       `json:"number"`
+	HTMLURL   string    `json:"html_url"`
+	State     string    `json:"state"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// Tickets returns the repository's issues labelled "aiptx".
+func (g *GitHub) Tickets(ctx context.Context) ([]Ticket, error) {
+	var tickets []Ticket
+	for page := 1; ; page++ {
+		params := url.Values{
+			"labels":   {trackerLabel},
+			"state":    {"all"},
+			"per_page": {"100"},
+			"page":     {strconv.Itoa(page)},
+		}
+		var issues []githubIssue
+		if err := do(ctx, g.HTTPClient, "GET", g.issuesURL()+"?"+params.Encode(), g.header(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil {
+				continue
+			}
+			if id, ok := parseLinkComment(issue.Body); ok {
+				tickets = append(tickets, g.ticket(issue, id))
+			}
+		}
+		if len(issues) < 100 {
+			return tickets, nil
+		}
+	}
+}
+
+// Create opens an issue for a finding, closing it right away if state is
+// closed.
+func (g *GitHub) Create(ctx context.Context, f aiptx.Finding, state string) (*Ticket, error) {
+	body := map[string]interface{}{
+		"title":  fmt.Sprintf("[%s] %s: %s", strings.ToUpper(string(f.Severity)), f.Type, f.Value),
+		"body":   f.Description + "\n\n" + linkComment(f.ID),
+		"labels": []string{trackerLabel},
+	}
+	var issue githubIssue
+	if err := do(ctx, g.HTTPClient, "POST", g.issuesURL(), g.header(), body, &issue); err != nil {
+		return nil, err
+	}
+	t := g.ticket(issue, f.ID)
+	if state != t.State {
+		if err := g.Update(ctx, &t, state); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
+
+// Update opens or closes an issue.
+func (g *GitHub) Update(ctx context.Context, t *Ticket, state string) error {
+	body := map[string]string{"state": state}
+	if err := do(ctx, g.HTTPClient, "PATCH", g.issuesURL()+"/"+t.ID, g.header(), body, nil); err != nil {
+		return err
+	}
+	t.State = state
+	return nil
+}
+
+// State returns the issue state for a remediation status.
+func (g *GitHub) State(status aiptx.RemediationStatus) string {
+	states := g.States
+	if states == nil {
+		states = DefaultGitHubStates
+	}
+	return states[status]
+}
+
+// Status returns the remediation status for an issue state.
+func (g *GitHub) Status(state string) (aiptx.RemediationStatus, bool) {
+	states := g.RemoteStates
+	if states == nil {
+		states = DefaultGitHubRemoteStates
+	}
+	status, ok := states[state]
+	return status, ok
+}
+
+// linkComment returns the hidden comment linking an issue body to a finding.
+func linkComment(findingID int64) string {
+	return "<!-- " + LinkID(findingID) + " -->"
+}
+
+// parseLinkComment returns the finding ID of the link comment in body, and
+// false if it has none.
+func parseLinkComment(body string) (int64, bool) {
+	_, rest, ok := strings.Cut(body, "<!-- "+LinkPrefix)
+	if !ok {
+		return 0, false
+	}
+	link, _, ok := strings.Cut(rest, " -->")
+	if !ok {
+		return 0, false
+	}
+	return ParseLinkID(LinkPrefix + link)
+}
+
+func (g *GitHub) ticket(issue githubIssue, findingID int64) Ticket {
+	number := strconv.Itoa(issue.Number)
+	return Ticket{
+		ID:        number,
+		Key:       "#" + number,
+		URL:       issue.HTMLURL,
+		FindingID: findingID,
+		State:     issue.State,
+		UpdatedAt: issue.UpdatedAt,
+	}
+}
+
+func (g *GitHub) issuesURL() string {
+	base := g.URL
+	if base == "" {
+		base = "https://api.github.com"
+	}
+	return fmt.Sprintf("%s/repos/%s/%s/issues", base, url.PathEscape(g.Owner), url.PathEscape(g.Repo))
+}
+
+func (g *GitHub) header() http.Header {
+	return http.Header{"Authorization": {"Bearer " + g.Token}}
+}
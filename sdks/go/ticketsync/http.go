@@ -0,0 +1,18 @@
+package ticketsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aiptx/aiptx-go/internal/jsonhttp"
+)
+
+// do sends in as JSON, if not nil, decodes the response into out, if not
+// nil, and fails on non-2xx responses.
+func do(ctx context.Context, client *http.Client, method, u string, header http.Header, in, out interface{}) error {
+	if err := jsonhttp.Do(ctx, client, method, u, header, in, out); err != nil {
+		return fmt.Errorf("ticketsync: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package ticketsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// DefaultJiraStates maps remediation statuses to the statuses of Jira's
+// default workflow.
+var DefaultJiraStates = map[aiptx.RemediationStatus]string{
+	aiptx.RemediationOpen:       "To Do",
+	aiptx.RemediationInProgress: "In Progress",
+	aiptx.RemediationFixed:      "Done",
+	aiptx.RemediationVerified:   "Done",
+	aiptx.RemediationAccepted:   "Done",
+}
+
+// DefaultJiraRemoteStates maps Jira statuses back to remediation statuses.
+// A done issue marks the finding fixed, awaiting verification by a retest.
+var DefaultJiraRemoteStates = map[string]aiptx.RemediationStatus{
+	"To Do":       aiptx.RemediationOpen,
+	"In Progress": aiptx.RemediationInProgress,
+	"Done":        aiptx.RemediationFixed,
+}
+
+// Jira tracks findings as issues in a Jira project, labelled "aiptx" and
+// with a label naming the finding. Issues change state through workflow
+// transitions, so every state in States must be reachable by a transition
+// from the others.
+type Jira struct {
+	// URL is the base URL of the site, e.g. https://acme.atlassian.net.
+	URL string
+	// Project is the key of the project issues are created in.
+	Project string
+	// Email and APIToken authenticate with basic authentication.
+	Email    string
+	APIToken string
+	// IssueType defaults to "Bug".
+	IssueType string
+	// States and RemoteStates default to DefaultJiraStates and
+	// DefaultJiraRemoteStates.
+	States       map[aiptx.RemediationStatus]string
+	RemoteStates map[string]aiptx.RemediationStatus
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewJira returns a Jira tracker creating issues in project.
+func NewJira(siteURL, project, email, apiToken string) *Jira {
+	return &Jira{URL: siteURL, Project: project, Email: email, APIToken: apiToken}
+}
+
+// Name returns "Jira".
+func (j *Jira) Name() string {
+	return "Jira"
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Labels  []string `json:"labels"`
+		Updated string   `json:"updated"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+// Tickets returns the project's issues labelled "aiptx".
+func (j *Jira) Tickets(ctx context.Context) ([]Ticket, error) {
+	jql := fmt.Sprintf("project = %q AND labels = %q", j.Project, trackerLabel)
+	var tickets []Ticket
+	for start := 0; ; {
+		params := url.Values{
+			"jql":        {jql},
+			"fields":     {"status,labels,updated"},
+			"startAt":    {strconv.Itoa(start)},
+			"maxResults": {"100"},
+		}
+		var page struct {
+			Issues []jiraIssue `json:"issues"`
+			Total  int         `json:"total"`
+		}
+		if err := do(ctx, j.HTTPClient, "GET", j.URL+"/rest/api/2/search?"+params.Encode(), j.header(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			for _, label := range issue.Fields.Labels {
+				if id, ok := ParseLinkID(label); ok {
+					updated, _ := time.Parse("2006-01-02T15:04:05.000-0700", issue.Fields.Updated)
+					tickets = append(tickets, Ticket{
+						ID:        issue.Key,
+						Key:       issue.Key,
+						URL:       j.URL + "/browse/" + issue.Key,
+						FindingID: id,
+						State:     issue.Fields.Status.Name,
+						UpdatedAt: updated,
+					})
+					break
+				}
+			}
+		}
+		start += len(page.Issues)
+		if len(page.Issues) == 0 || start >= page.Total {
+			return tickets, nil
+		}
+	}
+}
+
+// Create creates an issue for a finding and transitions it to state, unless
+// that is the state of an open finding, which new issues start in.
+func (j *Jira) Create(ctx context.Context, f aiptx.Finding, state string) (*Ticket, error) {
+	issueType := j.IssueType
+	if issueType == "" {
+		issueType = "Bug"
+	}
+	body := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": j.Project},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     fmt.Sprintf("[%s] %s: %s", strings.ToUpper(string(f.Severity)), f.Type, f.Value),
+			"description": f.Description,
+			"labels":      []string{trackerLabel, LinkID(f.ID)},
+		},
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := do(ctx, j.HTTPClient, "POST", j.URL+"/rest/api/2/issue", j.header(), body, &created); err != nil {
+		return nil, err
+	}
+	t := &Ticket{
+		ID:        created.Key,
+		Key:       created.Key,
+		URL:       j.URL + "/browse/" + created.Key,
+		FindingID: f.ID,
+		State:     j.State(aiptx.RemediationOpen),
+	}
+	if state != t.State {
+		if err := j.Update(ctx, t, state); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Update transitions an issue to state.
+func (j *Jira) Update(ctx context.Context, t *Ticket, state string) error {
+	path := j.URL + "/rest/api/2/issue/" + url.PathEscape(t.ID) + "/transitions"
+	var available struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := do(ctx, j.HTTPClient, "GET", path, j.header(), nil, &available); err != nil {
+		return err
+	}
+	for _, tr := range available.Transitions {
+		if tr.To.Name == state {
+			body := map[string]interface{}{"transition": map[string]string{"id": tr.ID}}
+			if err := do(ctx, j.HTTPClient, "POST", path, j.header(), body, nil); err != nil {
+				return err
+			}
+			t.State = state
+			return nil
+		}
+	}
+	return fmt.Errorf("ticketsync: no transition of %s to %q", t.Key, state)
+}
+
+// State returns the Jira status for a remediation status.
+func (j *Jira) State(status aiptx.RemediationStatus) string {
+	states := j.States
+	if states == nil {
+		states = DefaultJiraStates
+	}
+	return states[status]
+}
+
+// Status returns the remediation status for a Jira status.
+func (j *Jira) Status(state string) (aiptx.RemediationStatus, bool) {
+	states := j.RemoteStates
+	if states == nil {
+		states = DefaultJiraRemoteStates
+	}
+	status, ok := states[state]
+	return status, ok
+}
+
+func (j *Jira) header() http.Header {
+	req := &http.Request{Header: http.Header{}}
+	req.SetBasicAuth(j.Email, j.APIToken)
+	return req.Header
+}
@@ -0,0 +1,336 @@
+// Package ticketsync keeps findings and the tickets tracking their fixes in Jira,
+// GitHub or ServiceNow consistent in both directions:
+//
+//	tracker := ticketsync.NewJira("https://acme.atlassian.net", "SEC", user, token)
+//	r := ticketsync.New(client, tracker)
+//	r.Filter = &aiptx.FindingsFilter{MinSeverity: aiptx.SeverityHigh}
+//	r.Strategy = ticketsync.LastWriteWins
+//	if err := r.Run(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// A Reconciler opens a ticket for every finding that has none, and when a
+// finding's remediation status and its ticket's state disagree, resolves the
+// conflict according to its Strategy. Tickets are linked to findings through
+// the finding's LinkID, kept in a label, correlation ID or the ticket body, so
+// no state is kept outside the two systems. Webhook deliveries can be
+// reconciled as they arrive with Handle, between the periodic runs.
+//
+// The ServiceNow tracker is servicenow.Tracker, in the integrations/servicenow
+// package.
+package ticketsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// DefaultInterval is how often Run reconciles.
+const DefaultInterval = 5 * time.Minute
+
+// LinkPrefix prefixes the finding ID in the link of a ticket to its finding.
+const LinkPrefix = "aiptx-finding-"
+
+// trackerLabel marks the issues created for findings in trackers with
+// labels.
+const trackerLabel = "aiptx"
+
+// LinkID returns the link of a ticket to a finding, such as
+// "aiptx-finding-42".
+func LinkID(findingID int64) string {
+	return LinkPrefix + strconv.FormatInt(findingID, 10)
+}
+
+// ParseLinkID returns the finding ID of a link, and false if s is not one.
+func ParseLinkID(s string) (int64, bool) {
+	if !strings.HasPrefix(s, LinkPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, LinkPrefix), 10, 64)
+	return id, err == nil
+}
+
+// Ticket is a ticket linked to a finding.
+type Ticket struct {
+	// ID identifies the ticket to its tracker, such as a ServiceNow sys_id.
+	ID string
+	// Key is the identifier shown to people, such as "SEC-42" or "#17".
+	Key string
+	// URL links to the ticket, if the tracker provides one.
+	URL       string
+	FindingID int64
+	// State is the ticket's state in the tracker, such as "In Progress".
+	State     string
+	UpdatedAt time.Time
+}
+
+// Tracker is an external ticket system. Implementations link their tickets
+// to findings and map remediation statuses to ticket states. Several
+// statuses may map to the same state; the Reconciler only acts when the
+// state of a finding's status differs from its ticket's.
+type Tracker interface {
+	// Name names the tracker in remediation notes, such as "Jira".
+	Name() string
+	// Tickets returns every ticket linked to a finding.
+	Tickets(ctx context.Context) ([]Ticket, error)
+	// Create opens a ticket for a finding in the given state.
+	Create(ctx context.Context, f aiptx.Finding, state string) (*Ticket, error)
+	// Update moves a ticket to the given state.
+	Update(ctx context.Context, t *Ticket, state string) error
+	// State returns the ticket state for a remediation status.
+	State(status aiptx.RemediationStatus) string
+	// Status returns the remediation status for a ticket state, and false
+	// if the state has none, such as a custom workflow state.
+	Status(state string) (aiptx.RemediationStatus, bool)
+}
+
+// Strategy decides which side wins when a finding and its ticket disagree.
+type Strategy int
+
+// Conflict resolution strategies.
+const (
+	// LastWriteWins keeps the side changed most recently, comparing the
+	// remediation's UpdatedAt with the ticket's.
+	LastWriteWins Strategy = iota
+	// FindingWins always updates the ticket from the finding.
+	FindingWins
+	// TicketWins always updates the finding from the ticket.
+	TicketWins
+	// Manual changes neither side, leaving the conflict in
+	// Result.Conflicts for a person to resolve.
+	Manual
+)
+
+// Side is the side of a conflict whose status is kept.
+type Side int
+
+// Sides of a conflict.
+const (
+	Unresolved Side = iota
+	FindingSide
+	TicketSide
+)
+
+// Conflict is a finding whose remediation status disagrees with the state
+// of its ticket.
+type Conflict struct {
+	Finding aiptx.Finding
+	Ticket  Ticket
+	// Status is the finding's remediation status and TicketStatus the
+	// status its ticket's state maps to.
+	Status       aiptx.RemediationStatus
+	TicketStatus aiptx.RemediationStatus
+	// Winner is the side that was kept.
+	Winner Side
+}
+
+// Result reports the changes made by a reconciliation.
+type Result struct {
+	// Created is the number of tickets opened.
+	Created int
+	// Pushed is the number of tickets updated from their finding.
+	Pushed int
+	// Pulled is the number of findings updated from their ticket.
+	Pulled int
+	// Conflicts are the disagreements found, including those resolved.
+	Conflicts []Conflict
+}
+
+// Unresolved returns the conflicts left for a person to resolve.
+func (r *Result) Unresolved() []Conflict {
+	var conflicts []Conflict
+	for _, c := range r.Conflicts {
+		if c.Winner == Unresolved {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// Reconciler keeps findings matching Filter and their tickets in a Tracker
+// consistent. False positives are neither ticketed nor reconciled.
+type Reconciler struct {
+	Client  *aiptx.Client
+	Tracker Tracker
+	// Filter selects the findings to reconcile. If nil, all findings are.
+	Filter *aiptx.FindingsFilter
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+	// Strategy resolves conflicts. It defaults to LastWriteWins.
+	Strategy Strategy
+	// Resolve, if set, decides conflicts instead of Strategy.
+	Resolve func(c Conflict) Side
+	// OnError, if set, is called with the error of a failed reconciliation
+	// and Run carries on at the next interval. Otherwise Run returns it.
+	OnError func(err error)
+}
+
+// New returns a Reconciler for all findings.
+func New(client *aiptx.Client, tracker Tracker) *Reconciler {
+	return &Reconciler{Client: client, Tracker: tracker, Interval: DefaultInterval}
+}
+
+// Run reconciles every Interval until ctx is cancelled.
+func (r *Reconciler) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.ReconcileOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if r.OnError == nil {
+				return err
+			}
+			r.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileOnce opens missing tickets and resolves conflicts between the
+// findings matching Filter and their tickets once. A failure to reconcile one
+// finding does not stop the others; the failures are returned joined, along
+// with the changes made.
+func (r *Reconciler) ReconcileOnce(ctx context.Context) (*Result, error) {
+	var findings []aiptx.Finding
+	_, err := r.Client.ExportFindingsResumable(ctx, r.Filter, &aiptx.MemoryCursorStore{}, func(page []aiptx.Finding) error {
+		findings = append(findings, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	linked, err := r.tickets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var errs []error
+	for _, f := range findings {
+		if err := r.reconcile(ctx, f, linked, result); err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			errs = append(errs, err)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// Handle reconciles the finding of a finding.created or finding.updated
+// event, such as a webhook delivery decoded with aiptx.ParseWebhookEvent.
+// Other events are ignored.
+func (r *Reconciler) Handle(ctx context.Context, ev aiptx.TypedEvent) (*Result, error) {
+	var f aiptx.Finding
+	switch ev := ev.(type) {
+	case *aiptx.FindingCreatedEvent:
+		f = ev.Finding
+	case *aiptx.FindingUpdatedEvent:
+		f = ev.Finding
+	default:
+		return &Result{}, nil
+	}
+	if r.Filter != nil && !r.Filter.Matches(f) {
+		return &Result{}, nil
+	}
+	linked, err := r.tickets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	return result, r.reconcile(ctx, f, linked, result)
+}
+
+// tickets returns the tracker's tickets by finding ID.
+func (r *Reconciler) tickets(ctx context.Context) (map[int64]Ticket, error) {
+	tickets, err := r.Tracker.Tickets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s tickets: %w", r.Tracker.Name(), err)
+	}
+	linked := make(map[int64]Ticket, len(tickets))
+	for _, t := range tickets {
+		linked[t.FindingID] = t
+	}
+	return linked, nil
+}
+
+// reconcile opens a ticket for f or resolves a conflict with its ticket,
+// recording what it did in result.
+func (r *Reconciler) reconcile(ctx context.Context, f aiptx.Finding, linked map[int64]Ticket, result *Result) error {
+	if f.FalsePositive {
+		return nil
+	}
+	status := aiptx.RemediationOpen
+	var updated time.Time
+	if f.Remediation != nil {
+		status, updated = f.Remediation.Status, f.Remediation.UpdatedAt
+	}
+	state := r.Tracker.State(status)
+
+	ticket, ok := linked[f.ID]
+	if !ok {
+		if _, err := r.Tracker.Create(ctx, f, state); err != nil {
+			return fmt.Errorf("creating %s ticket for finding %d: %w", r.Tracker.Name(), f.ID, err)
+		}
+		result.Created++
+		return nil
+	}
+
+	remote, known := r.Tracker.Status(ticket.State)
+	if !known || ticket.State == state {
+		return nil
+	}
+	c := Conflict{Finding: f, Ticket: ticket, Status: status, TicketStatus: remote}
+	c.Winner = r.resolve(c, updated)
+	switch c.Winner {
+	case FindingSide:
+		if err := r.Tracker.Update(ctx, &ticket, state); err != nil {
+			return fmt.Errorf("updating %s ticket %s for finding %d: %w", r.Tracker.Name(), ticket.Key, f.ID, err)
+		}
+		result.Pushed++
+	case TicketSide:
+		notes := fmt.Sprintf("Synced from %s %s", r.Tracker.Name(), ticket.Key)
+		if _, err := r.Client.SetRemediationStatusContext(ctx, f.ID, remote, notes); err != nil {
+			return fmt.Errorf("updating finding %d from %s ticket %s: %w", f.ID, r.Tracker.Name(), ticket.Key, err)
+		}
+		result.Pulled++
+	}
+	result.Conflicts = append(result.Conflicts, c)
+	return nil
+}
+
+// resolve picks the winner of a conflict, given when the finding's
+// remediation was last updated.
+func (r *Reconciler) resolve(c Conflict, updated time.Time) Side {
+	if r.Resolve != nil {
+		return r.Resolve(c)
+	}
+	switch r.Strategy {
+	case FindingWins:
+		return FindingSide
+	case TicketWins:
+		return TicketSide
+	case Manual:
+		return Unresolved
+	}
+	if c.Ticket.UpdatedAt.After(updated) {
+		return TicketSide
+	}
+	return FindingSide
+}
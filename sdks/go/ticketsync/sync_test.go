@@ -0,0 +1,195 @@
+package ticketsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+// fakeTracker keeps tickets in memory, with GitHub's states.
+type fakeTracker struct {
+	GitHub
+	tickets []Ticket
+	created []int64
+	updated map[string]string
+}
+
+func (f *fakeTracker) Tickets(ctx context.Context) ([]Ticket, error) {
+	return f.tickets, nil
+}
+
+func (f *fakeTracker) Create(ctx context.Context, finding aiptx.Finding, state string) (*Ticket, error) {
+	f.created = append(f.created, finding.ID)
+	return &Ticket{FindingID: finding.ID, State: state}, nil
+}
+
+func (f *fakeTracker) Update(ctx context.Context, t *Ticket, state string) error {
+	f.updated[t.ID] = state
+	return nil
+}
+
+func findingsServer(t *testing.T, pulled map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /findings":
+			w.Write([]byte(`[
+				{"id": 1, "severity": "high"},
+				{"id": 2, "remediation": {"status": "verified", "updated_at": "2024-01-15T10:00:00Z"}},
+				{"id": 3, "remediation": {"status": "in_progress", "updated_at": "2024-01-15T08:00:00Z"}},
+				{"id": 4, "remediation": {"status": "accepted", "updated_at": "2024-01-15T08:00:00Z"}},
+				{"id": 5, "false_positive": true}
+			]`))
+		case "PATCH /findings/3/remediation":
+			json.NewDecoder(r.Body).Decode(&pulled)
+			w.Write([]byte(`{"id": 3}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func newFakeTracker() *fakeTracker {
+	at := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	return &fakeTracker{
+		tickets: []Ticket{
+			{ID: "12", Key: "#12", FindingID: 2, State: IssueOpen, UpdatedAt: at},
+			{ID: "13", Key: "#13", FindingID: 3, State: IssueClosed, UpdatedAt: at},
+			{ID: "14", Key: "#14", FindingID: 4, State: IssueClosed, UpdatedAt: at},
+		},
+		updated: map[string]string{},
+	}
+}
+
+func TestReconcileOnce(t *testing.T) {
+	pulled := map[string]interface{}{}
+	api := findingsServer(t, pulled)
+	defer api.Close()
+
+	tracker := newFakeTracker()
+	result, err := New(aiptx.NewClient(api.URL), tracker).ReconcileOnce(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(tracker.created) != 1 || tracker.created[0] != 1 {
+		t.Errorf("Expected a ticket for finding 1 only, got %v", tracker.created)
+	}
+	// Finding 2 was verified after its issue was last updated.
+	if tracker.updated["12"] != IssueClosed || len(tracker.updated) != 1 {
+		t.Errorf("Expected issue #12 to be closed, got %v", tracker.updated)
+	}
+	// Issue #13 was closed after finding 3 was last updated.
+	if pulled["status"] != "fixed" || pulled["notes"] != "Synced from GitHub #13" {
+		t.Errorf("Expected finding 3 to be fixed, got %v", pulled)
+	}
+	if result.Created != 1 || result.Pushed != 1 || result.Pulled != 1 {
+		t.Errorf("Expected 1 created, pushed and pulled, got %+v", result)
+	}
+	if len(result.Conflicts) != 2 || len(result.Unresolved()) != 0 {
+		t.Errorf("Expected 2 resolved conflicts, got %+v", result.Conflicts)
+	}
+}
+
+// failingTracker fails to update tickets.
+type failingTracker struct {
+	*fakeTracker
+}
+
+func (f failingTracker) Update(ctx context.Context, t *Ticket, state string) error {
+	return errors.New("tracker unavailable")
+}
+
+func TestReconcileErrors(t *testing.T) {
+	pulled := map[string]interface{}{}
+	api := findingsServer(t, pulled)
+	defer api.Close()
+
+	tracker := newFakeTracker()
+	result, err := New(aiptx.NewClient(api.URL), failingTracker{tracker}).ReconcileOnce(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "#12") {
+		t.Errorf("Expected error updating issue #12, got %v", err)
+	}
+	// The failure does not stop the other findings.
+	if result.Created != 1 || result.Pulled != 1 || pulled["status"] != "fixed" {
+		t.Errorf("Expected the other findings to be reconciled, got %+v", result)
+	}
+}
+
+func TestReconcileStrategies(t *testing.T) {
+	pulled := map[string]interface{}{}
+	api := findingsServer(t, pulled)
+	defer api.Close()
+
+	tracker := newFakeTracker()
+	r := New(aiptx.NewClient(api.URL), tracker)
+	r.Strategy = Manual
+	result, err := r.ReconcileOnce(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tracker.updated) != 0 || len(pulled) != 0 {
+		t.Errorf("Expected Manual to change neither side")
+	}
+	if unresolved := result.Unresolved(); len(unresolved) != 2 || unresolved[1].TicketStatus != aiptx.RemediationFixed {
+		t.Errorf("Expected 2 unresolved conflicts, got %+v", unresolved)
+	}
+
+	tracker = newFakeTracker()
+	r.Tracker = tracker
+	r.Strategy = FindingWins
+	if _, err := r.ReconcileOnce(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tracker.updated["12"] != IssueClosed || tracker.updated["13"] != IssueOpen {
+		t.Errorf("Expected FindingWins to update both issues, got %v", tracker.updated)
+	}
+
+	r.Resolve = func(c Conflict) Side {
+		return Unresolved
+	}
+	tracker = newFakeTracker()
+	r.Tracker = tracker
+	if _, err := r.ReconcileOnce(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tracker.updated) != 0 {
+		t.Errorf("Expected Resolve to override Strategy")
+	}
+}
+
+func TestHandle(t *testing.T) {
+	pulled := map[string]interface{}{}
+	api := findingsServer(t, pulled)
+	defer api.Close()
+
+	tracker := newFakeTracker()
+	r := New(aiptx.NewClient(api.URL), tracker)
+	r.Filter = &aiptx.FindingsFilter{MinSeverity: aiptx.SeverityMedium}
+
+	ev, err := aiptx.ParseWebhookEvent([]byte(`{"id": "e1", "type": "finding.created", "data": {"id": 9, "severity": "critical"}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := r.Handle(context.Background(), ev)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Created != 1 || len(tracker.created) != 1 || tracker.created[0] != 9 {
+		t.Errorf("Expected a ticket for finding 9, got %v", tracker.created)
+	}
+
+	ev, _ = aiptx.ParseWebhookEvent([]byte(`{"id": "e2", "type": "finding.created", "data": {"id": 10, "severity": "low"}}`))
+	if _, err := r.Handle(context.Background(), ev); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tracker.created) != 1 {
+		t.Errorf("Expected findings outside Filter to be ignored")
+	}
+}
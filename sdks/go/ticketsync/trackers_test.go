@@ -0,0 +1,134 @@
+package ticketsync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiptx/aiptx-go"
+)
+
+func TestJira(t *testing.T) {
+	var created map[string]map[string]interface{}
+	var transitioned []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "bot@example.com" {
+			t.Errorf("Expected basic auth, got %q", user)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/api/2/search":
+			if jql := r.URL.Query().Get("jql"); jql != `project = "SEC" AND labels = "aiptx"` {
+				t.Errorf("Unexpected JQL %s", jql)
+			}
+			w.Write([]byte(`{"total": 2, "issues": [
+				{"key": "SEC-1", "fields": {"labels": ["aiptx", "aiptx-finding-7"], "status": {"name": "In Progress"}, "updated": "2024-01-15T09:00:00.000+0000"}},
+				{"key": "SEC-2", "fields": {"labels": ["aiptx"], "status": {"name": "Done"}}}
+			]}`))
+		case "POST /rest/api/2/issue":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"key": "SEC-3"}`))
+		case "GET /rest/api/2/issue/SEC-3/transitions":
+			w.Write([]byte(`{"transitions": [{"id": "21", "to": {"name": "In Progress"}}, {"id": "31", "to": {"name": "Done"}}]}`))
+		case "POST /rest/api/2/issue/SEC-3/transitions":
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			transitioned = append(transitioned, body.Transition.ID)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	jira := NewJira(server.URL, "SEC", "bot@example.com", "token")
+	tickets, err := jira.Tickets(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].Key != "SEC-1" || tickets[0].FindingID != 7 || tickets[0].State != "In Progress" {
+		t.Errorf("Expected SEC-1 linked to finding 7, got %+v", tickets)
+	}
+	if tickets[0].UpdatedAt.IsZero() {
+		t.Errorf("Expected update time to be parsed")
+	}
+
+	f := aiptx.Finding{ID: 8, Type: "xss", Value: "/search", Severity: "high"}
+	ticket, err := jira.Create(context.Background(), f, jira.State(aiptx.RemediationFixed))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created["fields"]["summary"] != "[HIGH] xss: /search" {
+		t.Errorf("Unexpected summary %v", created["fields"]["summary"])
+	}
+	if len(transitioned) != 1 || transitioned[0] != "31" || ticket.State != "Done" {
+		t.Errorf("Expected transition to Done, got %v", transitioned)
+	}
+
+	if err := jira.Update(context.Background(), ticket, "Blocked"); err == nil {
+		t.Errorf("Expected error for unreachable state")
+	}
+}
+
+func TestGitHub(t *testing.T) {
+	var created struct {
+		Body   string   `json:"body"`
+		Labels []string `json:"labels"`
+	}
+	var patched []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Expected bearer token")
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/web/issues":
+			if r.URL.Query().Get("labels") != "aiptx" || r.URL.Query().Get("state") != "all" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[
+				{"number": 17, "state": "closed", "html_url": "https://github.com/acme/web/issues/17", "body": "XSS\n\n<!-- aiptx-finding-7 -->", "updated_at": "2024-01-15T09:00:00Z"},
+				{"number": 18, "state": "open", "body": "<!-- aiptx-finding-8 -->", "pull_request": {}},
+				{"number": 20, "state": "open", "body": "Not a finding"}
+			]`))
+		case "POST /repos/acme/web/issues":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"number": 19, "state": "open"}`))
+		case "PATCH /repos/acme/web/issues/19":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			patched = append(patched, body)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	github := NewGitHub("acme", "web", "token")
+	github.URL = server.URL
+	tickets, err := github.Tickets(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].Key != "#17" || tickets[0].FindingID != 7 || tickets[0].State != IssueClosed {
+		t.Errorf("Expected #17 linked to finding 7, got %+v", tickets)
+	}
+	if status, _ := github.Status(tickets[0].State); status != aiptx.RemediationFixed {
+		t.Errorf("Expected closed issue to mean fixed, got %s", status)
+	}
+
+	ticket, err := github.Create(context.Background(), aiptx.Finding{ID: 9}, IssueClosed)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(patched) != 1 || patched[0]["state"] != IssueClosed || ticket.State != IssueClosed {
+		t.Errorf("Expected new issue to be closed, got %v", patched)
+	}
+	if id, _ := parseLinkComment(created.Body); id != 9 || len(created.Labels) != 1 {
+		t.Errorf("Expected issue linked to finding 9 by its body only, got %+v", created)
+	}
+}
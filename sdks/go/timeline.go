@@ -0,0 +1,84 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// =============================================================================
+// Scan Timeline
+// =============================================================================
+
+// Timeline entry kinds.
+const (
+	TimelinePhase      = "phase"
+	TimelineTool       = "tool"
+	TimelineDecision   = "decision"
+	TimelineAnnotation = "annotation"
+)
+
+// ScanTimeline is the record of what a scan did and when, for reviewing an
+// engagement afterwards.
+type ScanTimeline struct {
+	ScanID  string          `json:"scan_id"`
+	Entries []TimelineEntry `json:"entries"`
+
+	unknown *rawFields
+}
+
+// TimelineEntry is one step of a scan: a phase, a tool invocation, a
+// decision made by the AI, or an operator annotation.
+type TimelineEntry struct {
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Phase     Phase     `json:"phase,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	// EndedAt is zero for steps still running and for instantaneous
+	// entries such as decisions and annotations.
+	EndedAt time.Time `json:"ended_at,omitempty"`
+	// Detail is the command line of a tool, the reasoning behind a
+	// decision, or the note of an annotation.
+	Detail string `json:"detail,omitempty"`
+	// ExitCode is set on tool invocations that have finished.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// FindingIDs are the findings the step reported.
+	FindingIDs []int64 `json:"finding_ids,omitempty"`
+}
+
+// Duration returns how long the step took, or zero if it has not ended.
+func (e TimelineEntry) Duration() time.Duration {
+	if e.EndedAt.IsZero() {
+		return 0
+	}
+	return e.EndedAt.Sub(e.StartedAt)
+}
+
+// OfKind returns the entries of the given kind, in order.
+func (t *ScanTimeline) OfKind(kind string) []TimelineEntry {
+	var entries []TimelineEntry
+	for _, e := range t.Entries {
+		if e.Kind == kind {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// GetScanTimeline returns the timeline of a scan, ordered by start time.
+func (c *Client) GetScanTimeline(scanID string) (*ScanTimeline, error) {
+	return c.GetScanTimelineContext(context.Background(), scanID)
+}
+
+// GetScanTimelineContext is like GetScanTimeline but uses ctx for its request.
+func (c *Client) GetScanTimelineContext(ctx context.Context, scanID string) (*ScanTimeline, error) {
+	var timeline ScanTimeline
+	if err := c.requestContext(ctx, "GET", fmt.Sprintf("/scans/%s/timeline", scanID), nil, &timeline); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(timeline.Entries, func(i, j int) bool {
+		return timeline.Entries[i].StartedAt.Before(timeline.Entries[j].StartedAt)
+	})
+	return &timeline, nil
+}
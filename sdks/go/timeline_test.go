@@ -0,0 +1,51 @@
+package aiptx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetScanTimeline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/scan-1/timeline" {
+			t.Errorf("Expected path /scans/scan-1/timeline, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"scan_id": "scan-1", "entries": [
+			{"kind": "tool", "name": "nmap", "phase": "recon", "started_at": "2024-03-12T09:01:00Z",
+				"ended_at": "2024-03-12T09:03:30Z", "detail": "nmap -sV 10.0.0.5", "exit_code": 0, "finding_ids": [4, 5]},
+			{"kind": "phase", "name": "recon", "started_at": "2024-03-12T09:00:00Z", "ended_at": "2024-03-12T09:10:00Z"},
+			{"kind": "decision", "name": "skip exploit", "phase": "recon", "started_at": "2024-03-12T09:09:00Z",
+				"detail": "no exploitable services"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	timeline, err := client.GetScanTimeline("scan-1")
+	if err != nil {
+		t.Fatalf("GetScanTimeline failed: %v", err)
+	}
+	if len(timeline.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(timeline.Entries))
+	}
+	var kinds []string
+	for _, e := range timeline.Entries {
+		kinds = append(kinds, e.Kind)
+	}
+	if kinds[0] != TimelinePhase || kinds[1] != TimelineTool || kinds[2] != TimelineDecision {
+		t.Errorf("Expected entries ordered by start time, got %v", kinds)
+	}
+
+	tools := timeline.OfKind(TimelineTool)
+	if len(tools) != 1 || tools[0].Duration() != 150*time.Second {
+		t.Errorf("Expected one tool run of 2m30s, got %+v", tools)
+	}
+	if tools[0].ExitCode == nil || *tools[0].ExitCode != 0 || len(tools[0].FindingIDs) != 2 {
+		t.Errorf("Expected exit code 0 and two findings, got %+v", tools[0])
+	}
+	if d := timeline.OfKind(TimelineDecision)[0].Duration(); d != 0 {
+		t.Errorf("Expected zero duration for decision, got %v", d)
+	}
+}
@@ -0,0 +1,95 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// =============================================================================
+// Session Transcripts
+// =============================================================================
+
+// Transcript formats accepted by ExportSessionTranscript.
+const (
+	TranscriptMarkdown = "markdown"
+	TranscriptJSON     = "json"
+)
+
+// Transcript entry kinds.
+const (
+	TranscriptPrompt   = "prompt"
+	TranscriptResponse = "response"
+	TranscriptDecision = "decision"
+	TranscriptCommand  = "command"
+	TranscriptOutput   = "output"
+)
+
+// SessionTranscript is the complete record of a session: every AI prompt
+// and response, decision, tool command and output, in order.
+type SessionTranscript struct {
+	SessionID int64             `json:"session_id"`
+	Model     string            `json:"model,omitempty"`
+	Entries   []TranscriptEntry `json:"entries"`
+
+	unknown *rawFields
+}
+
+// TranscriptEntry is one step of a session.
+type TranscriptEntry struct {
+	Seq   int       `json:"seq"`
+	Time  time.Time `json:"time"`
+	Kind  string    `json:"kind"`
+	Phase Phase     `json:"phase,omitempty"`
+	// Tool is the tool of command and output entries.
+	Tool    string `json:"tool,omitempty"`
+	Content string `json:"content"`
+	// ExitCode is set on the output of finished commands.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// Tokens is the number of LLM tokens used by prompt and response
+	// entries.
+	Tokens int `json:"tokens,omitempty"`
+}
+
+// OfKind returns the entries of the given kind, in order.
+func (t *SessionTranscript) OfKind(kind string) []TranscriptEntry {
+	var entries []TranscriptEntry
+	for _, e := range t.Entries {
+		if e.Kind == kind {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// GetSessionTranscript returns the transcript of a session.
+func (c *Client) GetSessionTranscript(sessionID int64) (*SessionTranscript, error) {
+	return c.GetSessionTranscriptContext(context.Background(), sessionID)
+}
+
+// GetSessionTranscriptContext is like GetSessionTranscript but uses ctx for its
+// request.
+func (c *Client) GetSessionTranscriptContext(ctx context.Context, sessionID int64) (*SessionTranscript, error) {
+	var transcript SessionTranscript
+	if err := c.requestContext(ctx, "GET", transcriptPath(sessionID, TranscriptJSON), nil, &transcript); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+// ExportSessionTranscript writes the transcript of a session to w in the
+// given format, TranscriptMarkdown or TranscriptJSON, for engagement records
+// and model-behavior review. It returns the number of bytes written and is
+// verified against the server's checksum like DownloadReport.
+func (c *Client) ExportSessionTranscript(ctx context.Context, sessionID int64, format string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	if format != TranscriptMarkdown && format != TranscriptJSON {
+		return 0, fmt.Errorf("unsupported transcript format %q", format)
+	}
+	return c.download(ctx, transcriptPath(sessionID, format), w, opts)
+}
+
+func transcriptPath(sessionID int64, format string) string {
+	return withQuery(fmt.Sprintf("/sessions/%d/transcript", sessionID), url.Values{"format": {format}})
+}
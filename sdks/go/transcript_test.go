@@ -0,0 +1,70 @@
+package aiptx
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSessionTranscript(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sessions/7/transcript" || r.URL.Query().Get("format") != "json" {
+			t.Errorf("Expected /sessions/7/transcript?format=json, got %s", r.URL)
+		}
+		w.Write([]byte(`{
+			"session_id": 7,
+			"model": "gpt-4o",
+			"entries": [
+				{"seq": 1, "time": "2024-03-12T09:00:00Z", "kind": "prompt", "phase": "recon", "content": "Plan reconnaissance of example.com", "tokens": 812},
+				{"seq": 2, "time": "2024-03-12T09:00:03Z", "kind": "decision", "phase": "recon", "content": "Run nmap on the top 1000 ports"},
+				{"seq": 3, "time": "2024-03-12T09:00:04Z", "kind": "command", "tool": "nmap", "content": "nmap -sV --top-ports 1000 example.com"},
+				{"seq": 4, "time": "2024-03-12T09:02:10Z", "kind": "output", "tool": "nmap", "content": "22/tcp open ssh", "exit_code": 0}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	transcript, err := client.GetSessionTranscript(7)
+	if err != nil {
+		t.Fatalf("GetSessionTranscript failed: %v", err)
+	}
+	if transcript.SessionID != 7 || len(transcript.Entries) != 4 {
+		t.Fatalf("Expected 4 entries for session 7, got %+v", transcript)
+	}
+	commands := transcript.OfKind(TranscriptCommand)
+	if len(commands) != 1 || commands[0].Tool != "nmap" {
+		t.Errorf("Expected one nmap command, got %+v", commands)
+	}
+	if out := transcript.Entries[3]; out.ExitCode == nil || *out.ExitCode != 0 {
+		t.Errorf("Expected exit code 0, got %v", out.ExitCode)
+	}
+}
+
+func TestExportSessionTranscript(t *testing.T) {
+	const markdown = "# Session 7\n\n## recon\n\n**Decision:** Run nmap\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "markdown" {
+			t.Errorf("Expected format markdown, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "text/markdown")
+		w.Write([]byte(markdown))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("test-key"))
+	var buf bytes.Buffer
+	n, err := client.ExportSessionTranscript(context.Background(), 7, TranscriptMarkdown, &buf)
+	if err != nil {
+		t.Fatalf("ExportSessionTranscript failed: %v", err)
+	}
+	if n != int64(len(markdown)) || buf.String() != markdown {
+		t.Errorf("Expected the markdown transcript, got %q", buf.String())
+	}
+
+	if _, err := client.ExportSessionTranscript(context.Background(), 7, "pdf", &buf); err == nil {
+		t.Errorf("Expected error for unsupported format")
+	}
+}
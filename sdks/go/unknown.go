@@ -0,0 +1,316 @@
+package aiptx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Unknown Fields
+// =============================================================================
+
+// rawFields holds the JSON fields of a response that the SDK does not know.
+// Types refer to it by pointer so that they remain comparable.
+type rawFields map[string]json.RawMessage
+
+func (r *rawFields) get() map[string]json.RawMessage {
+	if r == nil {
+		return nil
+	}
+	return *r
+}
+
+// Each response type keeps the fields the SDK does not know, so that new
+// server data is reachable before the SDK is updated. Types opt in with an
+// unknown *rawFields field; their UnmarshalJSON and Unknown methods are
+// generated into unknown_gen.go.
+
+//go:generate go run gen_unknown.go
+
+// knownFieldsCache maps a struct type to its JSON field names and types.
+var knownFieldsCache sync.Map
+
+// knownFields returns the JSON field names of struct type t, with the type
+// of each field.
+func knownFields(t reflect.Type) map[string]reflect.Type {
+	if names, ok := knownFieldsCache.Load(t); ok {
+		return names.(map[string]reflect.Type)
+	}
+	names := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n, typ := range knownFields(f.Type) {
+				names[n] = typ
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = f.Type
+	}
+	knownFieldsCache.Store(t, names)
+	return names
+}
+
+// lookupField returns the type of the field of known named by a JSON key,
+// which like encoding/json matches case-insensitively.
+func lookupField(known map[string]reflect.Type, name string) (reflect.Type, bool) {
+	if t, ok := known[name]; ok {
+		return t, true
+	}
+	for k, t := range known {
+		if strings.EqualFold(k, name) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// fieldName decodes the raw key of a JSON object member.
+func fieldName(key []byte) string {
+	name := string(key)
+	if strings.IndexByte(name, '\\') >= 0 {
+		json.Unmarshal(append(append([]byte{'"'}, key...), '"'), &name)
+	}
+	return name
+}
+
+// unmarshalKnown decodes data into v, a pointer to a struct type without an
+// UnmarshalJSON method, and returns the fields of data that v's type does not
+// have, or nil if there are none. Like encoding/json, field names match
+// case-insensitively.
+func unmarshalKnown(data []byte, v interface{}) (*rawFields, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	known := knownFields(reflect.TypeOf(v).Elem())
+	var unknown rawFields
+	eachField(data, func(key, value []byte) {
+		if _, ok := known[string(key)]; ok {
+			return
+		}
+		name := fieldName(key)
+		if _, ok := lookupField(known, name); ok {
+			return
+		}
+		if unknown == nil {
+			unknown = make(rawFields)
+		}
+		unknown[name] = append(json.RawMessage(nil), value...)
+	})
+	if unknown == nil {
+		return nil, nil
+	}
+	return &unknown, nil
+}
+
+// eachField calls fn with the raw key and value of each member of the JSON
+// object in data, which must be valid JSON. It does nothing if data is not an
+// object.
+func eachField(data []byte, fn func(key, value []byte)) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return
+	}
+	i++
+	for {
+		i = skipSpace(data, i)
+		if i >= len(data) || data[i] == '}' {
+			return
+		}
+		if data[i] == ',' {
+			i++
+			continue
+		}
+		end := skipValue(data, i)
+		key := data[i+1 : end-1]
+		i = skipSpace(data, end) + 1 // colon
+		i = skipSpace(data, i)
+		end = skipValue(data, i)
+		fn(key, data[i:end])
+		i = end
+	}
+}
+
+// eachElement calls fn with the index and raw value of each element of the
+// JSON array in data, which must be valid JSON. It does nothing if data is
+// not an array.
+func eachElement(data []byte, fn func(i int, value []byte)) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '[' {
+		return
+	}
+	i++
+	for n := 0; ; n++ {
+		if i = skipSpace(data, i); i < len(data) && data[i] == ',' {
+			i = skipSpace(data, i+1)
+		}
+		if i >= len(data) || data[i] == ']' {
+			return
+		}
+		end := skipValue(data, i)
+		fn(n, data[i:end])
+		i = end
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipValue returns the offset just past the JSON value starting at i.
+func skipValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		for i++; i < len(data); i++ {
+			switch data[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+		return i
+	case '{', '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				i = skipValue(data, i)
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return i
+	default:
+		for i < len(data) {
+			switch data[i] {
+			case ',', '}', ']', ' ', '\t', '\r', '\n':
+				return i
+			}
+			i++
+		}
+		return i
+	}
+}
+
+// =============================================================================
+// Strict Decoding
+// =============================================================================
+
+// WithStrictDecoding makes the client fail with a *StrictDecodingError when a
+// response contains fields the SDK does not know, at any depth, such as in
+// the Remediation of a finding, and rejects responses with trailing data
+// after the JSON value. Integration test suites can use it to catch schema
+// mismatches between server and SDK, which otherwise go unnoticed.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// StrictDecodingError reports response fields that the SDK does not know,
+// found in strict decoding mode. The decoded value is complete apart from
+// those fields.
+type StrictDecodingError struct {
+	// Fields are the paths of the unknown fields, such as
+	// "$[3].cvss_score".
+	Fields []string
+}
+
+func (e *StrictDecodingError) Error() string {
+	return "aiptx: response has unknown fields: " + strings.Join(e.Fields, ", ")
+}
+
+// unmarshalStrict decodes data into out, failing on unknown fields at any
+// depth and on trailing data.
+func unmarshalStrict(data []byte, out interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(out); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("aiptx: unexpected data after JSON response")
+	}
+
+	var fields []string
+	strictUnknown(&fields, "$", data, reflect.TypeOf(out))
+	if len(fields) > 0 {
+		sort.Strings(fields)
+		return &StrictDecodingError{Fields: fields}
+	}
+	return nil
+}
+
+var (
+	unknownHolderType = reflect.TypeOf((*unknownHolder)(nil)).Elem()
+	unmarshalerType   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// unknownHolder is implemented by response types that capture unknown fields.
+type unknownHolder interface {
+	Unknown() map[string]json.RawMessage
+}
+
+// strictUnknown appends the paths of the members of the JSON value in data
+// that type t does not have, at any depth. Types that decode themselves,
+// such as time.Time, are not checked, apart from the response types that
+// capture unknown fields.
+func strictUnknown(fields *[]string, path string, data []byte, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) && !t.Implements(unknownHolderType) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		known := knownFields(t)
+		eachField(data, func(key, value []byte) {
+			name := fieldName(key)
+			if ft, ok := lookupField(known, name); ok {
+				strictUnknown(fields, path+"."+name, value, ft)
+			} else {
+				*fields = append(*fields, path+"."+name)
+			}
+		})
+	case reflect.Map:
+		eachField(data, func(key, value []byte) {
+			strictUnknown(fields, path+"."+fieldName(key), value, t.Elem())
+		})
+	case reflect.Slice, reflect.Array:
+		eachElement(data, func(i int, value []byte) {
+			strictUnknown(fields, fmt.Sprintf("%s[%d]", path, i), value, t.Elem())
+		})
+	}
+}
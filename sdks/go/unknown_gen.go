@@ -0,0 +1,453 @@
+// Code generated by gen_unknown.go; DO NOT EDIT.
+
+package aiptx
+
+import "encoding/json"
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *AttackGraph) UnmarshalJSON(data []byte) error {
+	type plain AttackGraph
+	unknown, err := unmarshalKnown(data, (*plain)(a))
+	a.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (a AttackGraph) Unknown() map[string]json.RawMessage {
+	return a.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *AttackSurfaceScore) UnmarshalJSON(data []byte) error {
+	type plain AttackSurfaceScore
+	unknown, err := unmarshalKnown(data, (*plain)(a))
+	a.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (a AttackSurfaceScore) Unknown() map[string]json.RawMessage {
+	return a.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Backup) UnmarshalJSON(data []byte) error {
+	type plain Backup
+	unknown, err := unmarshalKnown(data, (*plain)(b))
+	b.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (b Backup) Unknown() map[string]json.RawMessage {
+	return b.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Baseline) UnmarshalJSON(data []byte) error {
+	type plain Baseline
+	unknown, err := unmarshalKnown(data, (*plain)(b))
+	b.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (b Baseline) Unknown() map[string]json.RawMessage {
+	return b.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Burndown) UnmarshalJSON(data []byte) error {
+	type plain Burndown
+	unknown, err := unmarshalKnown(data, (*plain)(b))
+	b.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (b Burndown) Unknown() map[string]json.RawMessage {
+	return b.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ComponentDiagnostics) UnmarshalJSON(data []byte) error {
+	type plain ComponentDiagnostics
+	unknown, err := unmarshalKnown(data, (*plain)(c))
+	c.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (c ComponentDiagnostics) Unknown() map[string]json.RawMessage {
+	return c.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *DigestSubscription) UnmarshalJSON(data []byte) error {
+	type plain DigestSubscription
+	unknown, err := unmarshalKnown(data, (*plain)(d))
+	d.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (d DigestSubscription) Unknown() map[string]json.RawMessage {
+	return d.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type plain Event
+	unknown, err := unmarshalKnown(data, (*plain)(e))
+	e.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (e Event) Unknown() map[string]json.RawMessage {
+	return e.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Evidence) UnmarshalJSON(data []byte) error {
+	type plain Evidence
+	unknown, err := unmarshalKnown(data, (*plain)(e))
+	e.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (e Evidence) Unknown() map[string]json.RawMessage {
+	return e.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ExecutionPolicy) UnmarshalJSON(data []byte) error {
+	type plain ExecutionPolicy
+	unknown, err := unmarshalKnown(data, (*plain)(e))
+	e.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (e ExecutionPolicy) Unknown() map[string]json.RawMessage {
+	return e.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *FeatureFlags) UnmarshalJSON(data []byte) error {
+	type plain FeatureFlags
+	unknown, err := unmarshalKnown(data, (*plain)(f))
+	f.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (f FeatureFlags) Unknown() map[string]json.RawMessage {
+	return f.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *Finding) UnmarshalJSON(data []byte) error {
+	type plain Finding
+	unknown, err := unmarshalKnown(data, (*plain)(f))
+	f.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (f Finding) Unknown() map[string]json.RawMessage {
+	return f.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *HealthStatus) UnmarshalJSON(data []byte) error {
+	type plain HealthStatus
+	unknown, err := unmarshalKnown(data, (*plain)(h))
+	h.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (h HealthStatus) Unknown() map[string]json.RawMessage {
+	return h.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *HostReport) UnmarshalJSON(data []byte) error {
+	type plain HostReport
+	unknown, err := unmarshalKnown(data, (*plain)(h))
+	h.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (h HostReport) Unknown() map[string]json.RawMessage {
+	return h.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *License) UnmarshalJSON(data []byte) error {
+	type plain License
+	unknown, err := unmarshalKnown(data, (*plain)(l))
+	l.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (l License) Unknown() map[string]json.RawMessage {
+	return l.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PoC) UnmarshalJSON(data []byte) error {
+	type plain PoC
+	unknown, err := unmarshalKnown(data, (*plain)(p))
+	p.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (p PoC) Unknown() map[string]json.RawMessage {
+	return p.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type plain Project
+	unknown, err := unmarshalKnown(data, (*plain)(p))
+	p.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (p Project) Unknown() map[string]json.RawMessage {
+	return p.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Report) UnmarshalJSON(data []byte) error {
+	type plain Report
+	unknown, err := unmarshalKnown(data, (*plain)(r))
+	r.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (r Report) Unknown() map[string]json.RawMessage {
+	return r.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Restore) UnmarshalJSON(data []byte) error {
+	type plain Restore
+	unknown, err := unmarshalKnown(data, (*plain)(r))
+	r.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (r Restore) Unknown() map[string]json.RawMessage {
+	return r.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Retest) UnmarshalJSON(data []byte) error {
+	type plain Retest
+	unknown, err := unmarshalKnown(data, (*plain)(r))
+	r.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (r Retest) Unknown() map[string]json.RawMessage {
+	return r.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SLAPolicy) UnmarshalJSON(data []byte) error {
+	type plain SLAPolicy
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s SLAPolicy) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SavedSearch) UnmarshalJSON(data []byte) error {
+	type plain SavedSearch
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s SavedSearch) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScanStatus) UnmarshalJSON(data []byte) error {
+	type plain ScanStatus
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s ScanStatus) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScanTimeline) UnmarshalJSON(data []byte) error {
+	type plain ScanTimeline
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s ScanTimeline) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Schedule) UnmarshalJSON(data []byte) error {
+	type plain Schedule
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s Schedule) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ServerMetrics) UnmarshalJSON(data []byte) error {
+	type plain ServerMetrics
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s ServerMetrics) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Session) UnmarshalJSON(data []byte) error {
+	type plain Session
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s Session) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SessionTranscript) UnmarshalJSON(data []byte) error {
+	type plain SessionTranscript
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s SessionTranscript) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SuppressionRule) UnmarshalJSON(data []byte) error {
+	type plain SuppressionRule
+	unknown, err := unmarshalKnown(data, (*plain)(s))
+	s.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (s SuppressionRule) Unknown() map[string]json.RawMessage {
+	return s.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Tool) UnmarshalJSON(data []byte) error {
+	type plain Tool
+	unknown, err := unmarshalKnown(data, (*plain)(t))
+	t.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (t Tool) Unknown() map[string]json.RawMessage {
+	return t.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *Upload) UnmarshalJSON(data []byte) error {
+	type plain Upload
+	unknown, err := unmarshalKnown(data, (*plain)(u))
+	u.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (u Upload) Unknown() map[string]json.RawMessage {
+	return u.unknown.get()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	type plain Webhook
+	unknown, err := unmarshalKnown(data, (*plain)(w))
+	w.unknown = unknown
+	return err
+}
+
+// Unknown returns the fields of the response that this version of the SDK
+// does not know, or nil if there were none.
+func (w Webhook) Unknown() map[string]json.RawMessage {
+	return w.unknown.get()
+}
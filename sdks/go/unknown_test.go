@@ -0,0 +1,109 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	data := []byte(`{
+		"id": 7,
+		"Severity": "high",
+		"type": "xss",
+		"cvss_score": 7.4,
+		"tags": ["owasp", "a03"],
+		"advisory": {"text": "Encode \"output\" {properly}", "refs": [1, 2]},
+		"exploitable": true
+	}`)
+
+	var f Finding
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if f.ID != 7 || f.Severity != "high" || f.Type != "xss" {
+		t.Errorf("Expected known fields to decode, got %+v", f)
+	}
+
+	unknown := f.Unknown()
+	want := map[string]string{
+		"cvss_score":  `7.4`,
+		"tags":        `["owasp", "a03"]`,
+		"advisory":    `{"text": "Encode \"output\" {properly}", "refs": [1, 2]}`,
+		"exploitable": `true`,
+	}
+	if len(unknown) != len(want) {
+		t.Fatalf("Expected %d unknown fields, got %v", len(want), unknown)
+	}
+	for key, value := range want {
+		if string(unknown[key]) != value {
+			t.Errorf("Expected %s = %s, got %s", key, value, unknown[key])
+		}
+	}
+}
+
+func TestUnknownFieldsNone(t *testing.T) {
+	var s Session
+	if err := json.Unmarshal([]byte(`{"id": 1, "name": "a"}`), &s); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if s.Unknown() != nil {
+		t.Errorf("Expected no unknown fields, got %v", s.Unknown())
+	}
+
+	// Response types stay comparable.
+	if s != (Session{ID: 1, Name: "a"}) {
+		t.Errorf("Expected sessions to be equal")
+	}
+}
+
+func TestUnknownFieldsInList(t *testing.T) {
+	var scans []ScanStatus
+	data := []byte(`[{"id": "a", "queue_position": 3}, {"id": "b"}]`)
+	if err := json.Unmarshal(data, &scans); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if string(scans[0].Unknown()["queue_position"]) != "3" {
+		t.Errorf("Expected queue_position to be captured, got %v", scans[0].Unknown())
+	}
+	if scans[1].Unknown() != nil {
+		t.Errorf("Expected no unknown fields on second scan, got %v", scans[1].Unknown())
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/findings/1" {
+			w.Write([]byte(`{"id": 1} {"id": 2}`))
+			return
+		}
+		w.Write([]byte(`[
+			{"id": 1, "type": "xss", "remediation": {"status": "open", "team": "web"}},
+			{"id": 2, "type": "sqli", "cvss_score": 9.1, "references": [{"url": "https://a.example", "Title": "A", "rank": 1}]}
+		]`))
+	}))
+	defer server.Close()
+
+	findings, err := NewClient(server.URL).ListFindings(nil)
+	if err != nil || len(findings) != 2 {
+		t.Fatalf("Expected lenient client to decode findings, got %v (%v)", findings, err)
+	}
+
+	strict := NewClient(server.URL, WithStrictDecoding())
+	_, err = strict.ListFindings(nil)
+	var strictErr *StrictDecodingError
+	if !errors.As(err, &strictErr) {
+		t.Fatalf("Expected *StrictDecodingError, got %v", err)
+	}
+	want := []string{"$[0].remediation.team", "$[1].cvss_score", "$[1].references[0].rank"}
+	if strings.Join(strictErr.Fields, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected unknown fields %v, got %v", want, strictErr.Fields)
+	}
+
+	if _, err := strict.GetFinding(1); err == nil {
+		t.Errorf("Expected error for trailing data")
+	}
+}
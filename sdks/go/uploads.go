@@ -0,0 +1,243 @@
+package aiptx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// =============================================================================
+// Uploads
+// =============================================================================
+
+// DefaultChunkSize is the chunk size used for evidence uploads.
+const DefaultChunkSize = 8 << 20
+
+// Upload is a server-side upload session for a large artifact. Chunks are
+// appended at Offset until it reaches Size.
+type Upload struct {
+	ID          string    `json:"id"`
+	FindingID   int64     `json:"finding_id"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int64     `json:"size"`
+	Offset      int64     `json:"offset"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// UploadOptions configures an evidence upload.
+type UploadOptions struct {
+	// Name is the file name recorded on the evidence.
+	Name string
+	// Kind classifies the evidence, e.g. "pcap" or "screenshot".
+	Kind string
+	// ContentType defaults to application/octet-stream.
+	ContentType string
+
+	// ChunkSize defaults to DefaultChunkSize.
+	ChunkSize int64
+	// MaxRetries is the number of consecutive failed chunk uploads tolerated
+	// before giving up. The upload resumes from the server's offset after
+	// each failure. Defaults to 5.
+	MaxRetries int
+	// RetryDelay is multiplied by the number of consecutive failures to
+	// get the delay before resuming. Defaults to one second.
+	RetryDelay time.Duration
+
+	// OnStart is called once the upload session exists. Persist
+	// upload.ID to resume with ResumeUpload after the process restarts.
+	OnStart func(upload *Upload)
+	// Progress is called after each chunk with the bytes uploaded so far.
+	Progress func(done, total int64)
+}
+
+func (o *UploadOptions) chunkSize() int64 {
+	if o.ChunkSize > 0 {
+		return o.ChunkSize
+	}
+	return DefaultChunkSize
+}
+
+func (o *UploadOptions) maxRetries() int {
+	if o.MaxRetries > 0 {
+		return o.MaxRetries
+	}
+	return 5
+}
+
+func (o *UploadOptions) retryDelay() time.Duration {
+	if o.RetryDelay > 0 {
+		return o.RetryDelay
+	}
+	return time.Second
+}
+
+// UploadEvidence uploads size bytes from r as evidence for a finding. The
+// content is sent in chunks; a chunk that fails because of a network error or
+// an unavailable server is retried from the offset the server last
+// acknowledged. opts may be nil.
+func (c *Client) UploadEvidence(ctx context.Context, findingID int64, r io.ReaderAt, size int64, opts *UploadOptions) (*Evidence, error) {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	create := &Upload{
+		Name:        opts.Name,
+		Kind:        opts.Kind,
+		ContentType: contentType,
+		Size:        size,
+	}
+	var upload Upload
+	if err := c.requestContext(ctx, "POST", fmt.Sprintf("/findings/%d/evidence/uploads", findingID), create, &upload); err != nil {
+		return nil, err
+	}
+	return c.continueUpload(ctx, &upload, r, opts)
+}
+
+// ResumeUpload continues an interrupted upload from the offset the server
+// has stored. r must provide the same content as the original upload.
+func (c *Client) ResumeUpload(ctx context.Context, uploadID string, r io.ReaderAt, opts *UploadOptions) (*Evidence, error) {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+	upload, err := c.getUpload(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	return c.continueUpload(ctx, upload, r, opts)
+}
+
+// AbortUpload discards an unfinished upload and the chunks stored so far.
+func (c *Client) AbortUpload(ctx context.Context, uploadID string) error {
+	return c.requestContext(ctx, "DELETE", "/uploads/"+uploadID, nil, nil)
+}
+
+func (c *Client) getUpload(ctx context.Context, uploadID string) (*Upload, error) {
+	var upload Upload
+	if err := c.requestContext(ctx, "GET", "/uploads/"+uploadID, nil, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// continueUpload sends the remaining chunks of upload and completes it.
+func (c *Client) continueUpload(ctx context.Context, upload *Upload, r io.ReaderAt, opts *UploadOptions) (*Evidence, error) {
+	if opts.OnStart != nil {
+		opts.OnStart(upload)
+	}
+
+	// The digest is computed as chunks are sent, so the content is read
+	// only once. Bytes already on the server are hashed locally first.
+	h := &sequentialHash{Hash: sha256.New()}
+	buf := make([]byte, opts.chunkSize())
+	failures := 0
+
+	for upload.Offset < upload.Size {
+		n := int64(len(buf))
+		if remaining := upload.Size - upload.Offset; remaining < n {
+			n = remaining
+		}
+		chunk := buf[:n]
+		if _, err := r.ReadAt(chunk, upload.Offset); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading chunk at offset %d: %w", upload.Offset, err)
+		}
+		if err := h.writeAt(r, upload.Offset, chunk); err != nil {
+			return nil, err
+		}
+
+		offset, err := c.sendChunk(ctx, upload, chunk)
+		if err != nil {
+			if ctx.Err() != nil || !isTransient(err) && !isOffsetConflict(err) {
+				return nil, err
+			}
+			if failures++; failures > opts.maxRetries() {
+				return nil, fmt.Errorf("upload %s failed at offset %d: %w", upload.ID, upload.Offset, err)
+			}
+			if err := sleepContext(ctx, time.Duration(failures)*opts.retryDelay()); err != nil {
+				return nil, err
+			}
+			// Resume from whatever the server has actually stored.
+			current, err := c.getUpload(ctx, upload.ID)
+			if err != nil {
+				if isTransient(err) {
+					continue
+				}
+				return nil, err
+			}
+			upload.Offset = current.Offset
+			continue
+		}
+
+		failures = 0
+		upload.Offset = offset
+		if opts.Progress != nil {
+			opts.Progress(upload.Offset, upload.Size)
+		}
+	}
+
+	if err := h.writeAt(r, upload.Size, nil); err != nil {
+		return nil, err
+	}
+	complete := map[string]string{"sha256": hex.EncodeToString(h.Sum(nil))}
+	var evidence Evidence
+	if err := c.requestContext(ctx, "POST", "/uploads/"+upload.ID+"/complete", complete, &evidence); err != nil {
+		return nil, err
+	}
+	return &evidence, nil
+}
+
+// sendChunk appends chunk at upload.Offset and returns the new offset.
+func (c *Client) sendChunk(ctx context.Context, upload *Upload, chunk []byte) (int64, error) {
+	header := http.Header{}
+	header.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+
+	var result Upload
+	if err := c.send(ctx, "PATCH", "/uploads/"+upload.ID, "application/offset+octet-stream", header, chunk, &result); err != nil {
+		return 0, err
+	}
+	return result.Offset, nil
+}
+
+// isOffsetConflict reports whether the server rejected a chunk because its
+// offset differs from the stored one.
+func isOffsetConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// sequentialHash hashes content in order even when chunks are resent or
+// the upload starts part-way through.
+type sequentialHash struct {
+	hash.Hash
+	pos int64
+}
+
+// writeAt hashes the bytes of chunk (located at offset) that have not been
+// hashed yet, first reading any gap before offset from r.
+func (h *sequentialHash) writeAt(r io.ReaderAt, offset int64, chunk []byte) error {
+	if h.pos < offset {
+		if _, err := io.Copy(h.Hash, io.NewSectionReader(r, h.pos, offset-h.pos)); err != nil {
+			return fmt.Errorf("hashing content: %w", err)
+		}
+		h.pos = offset
+	}
+	if end := offset + int64(len(chunk)); end > h.pos {
+		h.Write(chunk[h.pos-offset:])
+		h.pos = end
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package aiptx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestUploadEvidenceResumes(t *testing.T) {
+	content := bytes.Repeat([]byte("pcap"), 1000)
+	var stored []byte
+	chunks := 0
+	var digest string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/findings/7/evidence/uploads":
+			var u Upload
+			json.NewDecoder(r.Body).Decode(&u)
+			json.NewEncoder(w).Encode(Upload{ID: "up-1", Size: u.Size})
+		case r.Method == "PATCH":
+			chunks++
+			data, _ := io.ReadAll(r.Body)
+			offset, _ := strconv.Atoi(r.Header.Get("Upload-Offset"))
+			if chunks == 3 {
+				// Store half the chunk, then fail.
+				stored = append(stored[:offset], data[:len(data)/2]...)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			stored = append(stored[:offset], data...)
+			json.NewEncoder(w).Encode(Upload{ID: "up-1", Offset: int64(len(stored))})
+		case r.Method == "GET" && r.URL.Path == "/uploads/up-1":
+			json.NewEncoder(w).Encode(Upload{ID: "up-1", Offset: int64(len(stored)), Size: int64(len(content))})
+		case r.URL.Path == "/uploads/up-1/complete":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			digest = body["sha256"]
+			json.NewEncoder(w).Encode(Evidence{ID: 1, FindingID: 7, Size: int64(len(stored))})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var progress []int64
+	client := NewClient(server.URL)
+	evidence, err := client.UploadEvidence(context.Background(), 7, bytes.NewReader(content), int64(len(content)), &UploadOptions{
+		Name:       "capture.pcap",
+		ChunkSize:  1024,
+		RetryDelay: time.Millisecond,
+		Progress:   func(done, total int64) { progress = append(progress, done) },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(stored, content) {
+		t.Errorf("Stored content differs from upload")
+	}
+	sum := sha256.Sum256(content)
+	if digest != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected SHA-256 of whole content, got %s", digest)
+	}
+	if evidence.Size != int64(len(content)) {
+		t.Errorf("Unexpected evidence: %+v", evidence)
+	}
+	if progress[len(progress)-1] != int64(len(content)) {
+		t.Errorf("Expected final progress to equal size, got %v", progress)
+	}
+}
@@ -0,0 +1,162 @@
+package aiptx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Version Skew
+// =============================================================================
+
+// The range of server versions this SDK supports, from MinServerVersion up to
+// but excluding MaxServerVersion.
+const (
+	MinServerVersion = "3.0.0"
+	MaxServerVersion = "5.0.0"
+)
+
+// VersionSkewWarning reports a server whose version is outside the range
+// this SDK supports. Requests may still work, but fields and endpoints can
+// differ, which typically shows up as confusing 404 or 422 errors.
+type VersionSkewWarning struct {
+	ServerVersion string
+	MinVersion    string
+	MaxVersion    string
+}
+
+func (w VersionSkewWarning) String() string {
+	return fmt.Sprintf("AIPTX server version %s is outside the range supported by this SDK (>= %s, < %s)",
+		w.ServerVersion, w.MinVersion, w.MaxVersion)
+}
+
+// CheckVersion fetches the server version and returns a warning if it is
+// outside the supported range, or nil if it is supported or cannot be
+// parsed.
+func (c *Client) CheckVersion(ctx context.Context) (*VersionSkewWarning, error) {
+	var health HealthStatus
+	if err := c.requestContext(ctx, "GET", "/health", nil, &health); err != nil {
+		return nil, err
+	}
+	return versionSkew(health.Version), nil
+}
+
+// versionRetryInterval is how long a client waits before checking the
+// server version again after a check failed.
+const versionRetryInterval = time.Minute
+
+// versionCheck records whether a client has checked the server version. It
+// is shared by clients created with With, and replaced when the base URL
+// changes.
+type versionCheck struct {
+	mu   sync.Mutex
+	done bool
+	// next is the earliest time for the next attempt. It is pushed back
+	// while a check is in flight and after a failed one.
+	next time.Time
+}
+
+// start reports whether a check should run now, reserving it if so.
+func (v *versionCheck) start() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := time.Now()
+	if v.done || now.Before(v.next) {
+		return false
+	}
+	v.next = now.Add(versionRetryInterval)
+	return true
+}
+
+func (v *versionCheck) finish() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.done = true
+}
+
+// checkVersion reports a server version outside the supported range to the
+// OnVersionSkew hook and the client's logger. It runs before the first
+// request of a client that has either set, and is retried at most every
+// versionRetryInterval until it succeeds. Requests made while a check is
+// in flight do not wait for it.
+func (c *Client) checkVersion(ctx context.Context) {
+	c.lock().RLock()
+	check := c.versionCheck
+	c.lock().RUnlock()
+	if check == nil || (c.Hooks.OnVersionSkew == nil && c.logger == nil) {
+		return
+	}
+	if !check.start() {
+		return
+	}
+
+	// Use a single attempt, without retries or a nested check.
+	_, resp, err := c.attempt(ctx, "GET", "/health", "", nil, nil)
+	if err != nil {
+		return
+	}
+	var health HealthStatus
+	if err := decodeResponse(resp, &health, false); err != nil {
+		return
+	}
+	check.finish()
+	if w := versionSkew(health.Version); w != nil {
+		c.logf("aiptx: %v", w)
+		if c.Hooks.OnVersionSkew != nil {
+			c.Hooks.OnVersionSkew(*w)
+		}
+	}
+}
+
+// versionSkew returns a warning if version is outside the supported range.
+func versionSkew(version string) *VersionSkewWarning {
+	v, ok := parseVersion(version)
+	if !ok {
+		return nil
+	}
+	min, _ := parseVersion(MinServerVersion)
+	max, _ := parseVersion(MaxServerVersion)
+	if compareVersions(v, min) >= 0 && compareVersions(v, max) < 0 {
+		return nil
+	}
+	return &VersionSkewWarning{ServerVersion: version, MinVersion: MinServerVersion, MaxVersion: MaxServerVersion}
+}
+
+// parseVersion parses a version such as "4.0.1", "v4.1" or "4.0.1-rc.1"
+// into its major, minor and patch numbers. Pre-release and build suffixes
+// are ignored.
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
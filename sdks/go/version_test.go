@@ -0,0 +1,131 @@
+package aiptx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVersionSkew(t *testing.T) {
+	tests := []struct {
+		version string
+		skewed  bool
+	}{
+		{"3.0.0", false},
+		{"4.0.1", false},
+		{"v4.2", false},
+		{"4.9.9-rc.1", false},
+		{"2.0.8", true},
+		{"5.0.0", true},
+		{"dev", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := versionSkew(tt.version) != nil; got != tt.skewed {
+			t.Errorf("Expected skew %v for %q, got %v", tt.skewed, tt.version, got)
+		}
+	}
+}
+
+func TestVersionSkewHook(t *testing.T) {
+	var healthChecks int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			healthChecks++
+			w.Write([]byte(`{"status": "ok", "version": "2.0.8"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var warnings []VersionSkewWarning
+	client := NewClient(server.URL)
+	client.Hooks.OnVersionSkew = func(w VersionSkewWarning) { warnings = append(warnings, w) }
+
+	client.ListTools()
+	client.ListTools()
+	client.With(WithAPIKey("other")).ListTools()
+
+	if healthChecks != 1 {
+		t.Errorf("Expected one version check, got %d", healthChecks)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("Expected one warning, got %d", len(warnings))
+	}
+	w := warnings[0]
+	if w.ServerVersion != "2.0.8" || w.MinVersion != MinServerVersion || w.MaxVersion != MaxServerVersion {
+		t.Errorf("Expected warning for 2.0.8, got %+v", w)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok", "version": "4.0.1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	w, err := client.CheckVersion(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if w != nil {
+		t.Errorf("Expected no warning, got %v", w)
+	}
+}
+
+func TestVersionSkewLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.Write([]byte(`{"status": "ok", "version": "5.1.0"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	client := NewClient(server.URL, WithLogger(log.New(&out, "", 0)))
+	client.ListTools()
+
+	if !strings.Contains(out.String(), "server version 5.1.0 is outside the range") {
+		t.Errorf("Expected skew warning in log, got %q", out.String())
+	}
+}
+
+func TestVersionCheckFailure(t *testing.T) {
+	var healthChecks int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			healthChecks++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.Hooks.OnVersionSkew = func(VersionSkewWarning) {}
+	for i := 0; i < 3; i++ {
+		if _, err := client.ListTools(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if healthChecks != 1 {
+		t.Errorf("Expected failed check to be cached, got %d checks", healthChecks)
+	}
+}
+
+func TestVersionSkewWarningString(t *testing.T) {
+	w := VersionSkewWarning{ServerVersion: "2.0.0", MinVersion: "3.0.0", MaxVersion: "5.0.0"}
+	if got := fmt.Sprint(w); !strings.HasPrefix(got, "AIPTX server version 2.0.0") {
+		t.Errorf("Expected String to be used, got %q", got)
+	}
+}
@@ -0,0 +1,317 @@
+package aiptx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"time"
+)
+
+// =============================================================================
+// Watching Findings
+// =============================================================================
+
+// WatchFindings sends new findings matching filter to the returned channel
+// as they are discovered. The server is asked to filter the event stream, and
+// findings are checked against filter again in case it does not. filter may
+// be nil to watch all findings; opts configure the underlying event stream,
+// e.g. WithCursorStore to resume after a restart.
+//
+// The findings channel is closed when the stream ends, after the error that
+// ended it, if any, is sent on the error channel. Cancel ctx to stop
+// watching:
+//
+//	findings, errc := client.WatchFindings(ctx, &aiptx.FindingsFilter{
+//	    ProjectID:   42,
+//	    MinSeverity: aiptx.SeverityHigh,
+//	})
+//	for f := range findings {
+//	    alert(f)
+//	}
+//	if err := <-errc; err != nil && ctx.Err() == nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) WatchFindings(ctx context.Context, filter *FindingsFilter, opts ...EventOption) (<-chan Finding, <-chan error) {
+	if filter == nil {
+		filter = &FindingsFilter{}
+	}
+	opts = append([]EventOption{WithEventTypes(EventFindingCreated), WithEventFilter(filter)}, opts...)
+	stream := c.Events(ctx, opts...)
+
+	findings := make(chan Finding)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(findings)
+		defer close(errc)
+		for stream.Next() {
+			ev := stream.Event()
+			if ev.Type != EventFindingCreated {
+				continue
+			}
+			var f Finding
+			if err := ev.Decode(&f); err != nil {
+				errc <- fmt.Errorf("decoding event %s: %w", ev.ID, err)
+				return
+			}
+			if !filter.Matches(f) {
+				continue
+			}
+			select {
+			case findings <- f:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		errc <- stream.Err()
+	}()
+	return findings, errc
+}
+
+// Matches reports whether finding satisfies the filter. ListOptions and
+// NewSinceBaseline, which only the server can evaluate, are ignored.
+func (f *FindingsFilter) Matches(finding Finding) bool {
+	if f.ProjectID > 0 && finding.ProjectID != f.ProjectID {
+		return false
+	}
+	if f.MinSeverity != "" && finding.Severity.rank() < f.MinSeverity.rank() {
+		return false
+	}
+	// Severity and Severities are sent as one query parameter, so a
+	// finding matches if it has any of them, and likewise for types.
+	if (f.Severity != "" || len(f.Severities) > 0) &&
+		finding.Severity != f.Severity && !slices.Contains(f.Severities, finding.Severity) {
+		return false
+	}
+	if (f.Type != "" || len(f.Types) > 0) && finding.Type != f.Type && !slices.Contains(f.Types, finding.Type) {
+		return false
+	}
+	if f.Tool != "" && finding.Tool != f.Tool {
+		return false
+	}
+	if len(f.KillChainStages) > 0 && !slices.Contains(f.KillChainStages, finding.KillChainStage) {
+		return false
+	}
+	if f.Owner != "" || len(f.RemediationStatuses) > 0 || !f.DueBefore.IsZero() || f.Overdue {
+		r := finding.Remediation
+		if r == nil {
+			r = &Remediation{Status: RemediationOpen}
+		}
+		if f.Owner != "" && r.Owner != f.Owner {
+			return false
+		}
+		if len(f.RemediationStatuses) > 0 && !slices.Contains(f.RemediationStatuses, r.Status) {
+			return false
+		}
+		if !f.DueBefore.IsZero() && (r.DueDate.IsZero() || !r.DueDate.Before(f.DueBefore)) {
+			return false
+		}
+		if f.Overdue && !r.Overdue(time.Now()) {
+			return false
+		}
+	}
+	if f.Verified != nil && finding.Verified != *f.Verified {
+		return false
+	}
+	if f.FalsePositive != nil && finding.FalsePositive != *f.FalsePositive {
+		return false
+	}
+	if f.MinConfidence > 0 && finding.Confidence > 0 && finding.Confidence < f.MinConfidence {
+		return false
+	}
+	if !f.DiscoveredAfter.IsZero() && !finding.DiscoveredAt.After(f.DiscoveredAfter) {
+		return false
+	}
+	if !f.DiscoveredBefore.IsZero() && !finding.DiscoveredAt.Before(f.DiscoveredBefore) {
+		return false
+	}
+	if f.DiscoveredWithin > 0 && time.Since(finding.DiscoveredAt) > f.DiscoveredWithin {
+		return false
+	}
+	return true
+}
+
+// =============================================================================
+// Watching Scans
+// =============================================================================
+
+// WatchScan streams the events of a scan over a WebSocket as they happen:
+// *ScanProgressEvent, *ScanPhaseChangedEvent, *FindingCreatedEvent and
+// finally *ScanCompletedEvent or *ScanFailedEvent. Unlike polling
+// GetScanStatus, no intermediate finding or phase is missed while the
+// connection is up.
+//
+// The connection is pinged and reconnected after drops and stalls as
+// configured by DefaultKeepalive. The server does not replay the events sent
+// while it was down, so a *ReconnectedEvent marks each recovery; call
+// GetScanStatus then if the gap matters.
+//
+// The events channel is closed when the scan has ended or the stream fails
+// in a way reconnecting cannot fix, after the error that ended the stream,
+// if any, is sent on the error channel. Cancel ctx to stop watching:
+//
+//	events, errc := client.WatchScan(ctx, scan.ID)
+//	for ev := range events {
+//	    switch ev := ev.(type) {
+//	    case *aiptx.ScanProgressEvent:
+//	        fmt.Printf("%s %d%%\n", ev.Scan.Phase, ev.Scan.Progress)
+//	    case *aiptx.FindingCreatedEvent:
+//	        triage(ev.Finding)
+//	    }
+//	}
+//	if err := <-errc; err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Servers without the WebSocket endpoint reject the connection with an
+// error matching ErrNotFound; use Events with WithEventTypes instead.
+func (c *Client) WatchScan(ctx context.Context, scanID string) (<-chan TypedEvent, <-chan error) {
+	events := make(chan TypedEvent)
+	errc := make(chan error, 1)
+	w := &scanWatch{
+		client:    c,
+		scanID:    scanID,
+		keepalive: DefaultKeepalive,
+		events:    events,
+	}
+	go func() {
+		defer close(events)
+		defer close(errc)
+		errc <- w.run(ctx)
+	}()
+	return events, errc
+}
+
+// scanWatch follows a scan over a WebSocket, reconnecting after stalls and
+// transient failures as configured by its Keepalive.
+type scanWatch struct {
+	client    *Client
+	scanID    string
+	keepalive Keepalive
+	events    chan<- TypedEvent
+
+	reconnect reconnector
+	lastErr   string
+}
+
+// run sends the scan's events until it ends, ctx is done or the stream fails
+// in a way reconnecting cannot fix.
+func (w *scanWatch) run(ctx context.Context) error {
+	w.reconnect = newReconnector(w.keepalive)
+	for {
+		err := w.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			return nil
+		}
+		w.lastErr = err.Error()
+		if err := w.reconnect.wait(ctx, err, 0); err != nil {
+			return err
+		}
+	}
+}
+
+// connect makes one connection and sends the events read from it until it
+// ends. Dropped and stalled connections are reported as a *TransportError.
+// The time spent waiting for the consumer does not count towards a stall.
+func (w *scanWatch) connect(ctx context.Context) error {
+	connCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stall := w.keepalive.PingInterval + w.keepalive.StallTimeout
+	var timer *time.Timer
+	if w.keepalive.StallTimeout > 0 {
+		timer = time.AfterFunc(stall, func() { cancel(errStreamStalled) })
+		defer timer.Stop()
+	}
+
+	path := fmt.Sprintf("/scans/%s/ws", w.scanID)
+	conn, err := w.client.dialWebSocket(connCtx, path)
+	if err != nil {
+		if cause := context.Cause(connCtx); errors.Is(cause, errStreamStalled) {
+			err = &TransportError{Method: "GET", URL: path, Err: cause}
+		}
+		return err
+	}
+	defer conn.close()
+	if timer != nil {
+		// Any data, even a pong or part of a message, shows the connection
+		// is alive.
+		conn.r = bufio.NewReader(&activityReader{r: conn.rw, active: func() { timer.Reset(stall) }})
+	}
+	if w.keepalive.PingInterval > 0 {
+		go conn.ping(w.keepalive.PingInterval)
+	}
+
+	for {
+		msg, err := conn.readMessage()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, errWebSocketClosed) {
+			return nil
+		}
+		if cause := context.Cause(connCtx); err != nil && cause != nil {
+			err = cause
+		}
+		if isConnError(err) {
+			return &TransportError{Method: "GET", URL: path, Err: err}
+		}
+		if err != nil {
+			return fmt.Errorf("watching scan %s: %w", w.scanID, err)
+		}
+		var ev Event
+		if err := json.Unmarshal(msg, &ev); err != nil {
+			return fmt.Errorf("watching scan %s: decoding event: %w", w.scanID, err)
+		}
+		typed, err := ParseEvent(ev)
+		if err != nil {
+			return err
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+		if failures := w.reconnect.failures; failures > 0 {
+			data, _ := json.Marshal(Reconnected{Attempts: failures, LastError: w.lastErr})
+			reconnected, _ := ParseEvent(Event{Type: EventReconnected, Time: time.Now(), Data: data})
+			if err := w.send(ctx, reconnected); err != nil {
+				return err
+			}
+			w.reconnect.reset()
+		}
+		if err := w.send(ctx, typed); err != nil {
+			return err
+		}
+		if ev.Type == EventScanCompleted || ev.Type == EventScanFailed {
+			return nil
+		}
+		if timer != nil {
+			timer.Reset(stall)
+		}
+	}
+}
+
+// send passes ev to the consumer.
+func (w *scanWatch) send(ctx context.Context, ev TypedEvent) error {
+	select {
+	case w.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isConnError reports whether err is a failure of the connection itself
+// rather than a protocol error or a closure by the server.
+func isConnError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, errStreamStalled) || errors.As(err, &netErr)
+}
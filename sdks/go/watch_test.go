@@ -0,0 +1,77 @@
+package aiptx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWatchFindings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("event_type") != EventFindingCreated || q.Get("project_id") != "42" || q.Get("min_severity") != "high" {
+			t.Errorf("Expected server-side filter, got %s", r.URL.RawQuery)
+		}
+		if q.Get("cursor") != "" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		// The server ignores the filter, so the client filters instead.
+		w.Write([]byte(`{"events": [
+			{"id": "1", "type": "finding.created", "data": {"id": 1, "project_id": 42, "severity": "low"}},
+			{"id": "2", "type": "scan.started"},
+			{"id": "3", "type": "finding.created", "data": {"id": 3, "project_id": 42, "severity": "critical"}},
+			{"id": "4", "type": "finding.created", "data": {"id": 4, "project_id": 7, "severity": "high"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	findings, errc := client.WatchFindings(context.Background(), &FindingsFilter{ProjectID: 42, MinSeverity: SeverityHigh})
+
+	var ids []int64
+	for f := range findings {
+		ids = append(ids, f.ID)
+	}
+	if len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("Expected finding 3 only, got %v", ids)
+	}
+	var apiErr *APIError
+	if err := <-errc; !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusGone {
+		t.Errorf("Expected the error that ended the stream, got %v", err)
+	}
+}
+
+func TestFindingsFilterMatches(t *testing.T) {
+	yes := true
+	filter := &FindingsFilter{Severity: "high", Severities: []Severity{SeverityCritical}, Types: []string{"vuln"}, Verified: &yes}
+
+	tests := []struct {
+		finding Finding
+		want    bool
+	}{
+		{Finding{Severity: "high", Type: "vuln", Verified: true}, true},
+		{Finding{Severity: "critical", Type: "vuln", Verified: true}, true},
+		{Finding{Severity: "medium", Type: "vuln", Verified: true}, false},
+		{Finding{Severity: "high", Type: "port", Verified: true}, false},
+		{Finding{Severity: "high", Type: "vuln"}, false},
+	}
+	for _, tt := range tests {
+		if got := filter.Matches(tt.finding); got != tt.want {
+			t.Errorf("Expected Matches(%+v) = %v, got %v", tt.finding, tt.want, got)
+		}
+	}
+
+	// Findings without a confidence come from tools and always match.
+	filter = &FindingsFilter{MinConfidence: 0.7}
+	for _, tt := range []struct {
+		confidence float64
+		want       bool
+	}{{0.9, true}, {0.7, true}, {0.4, false}, {0, true}} {
+		if got := filter.Matches(Finding{Confidence: tt.confidence}); got != tt.want {
+			t.Errorf("Expected Matches with confidence %v = %v, got %v", tt.confidence, tt.want, got)
+		}
+	}
+}
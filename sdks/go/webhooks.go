@@ -0,0 +1,83 @@
+package aiptx
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// Webhooks
+// =============================================================================
+
+// Webhook delivers events to a URL.
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Events lists the event types delivered, or all events if empty.
+	Events    []string  `json:"events,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+
+	unknown *rawFields
+}
+
+// WebhookCreate represents data for creating or updating a webhook.
+type WebhookCreate struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+	// Secret signs deliveries. The server never returns it.
+	Secret string `json:"secret,omitempty"`
+	Active bool   `json:"active"`
+}
+
+// ListWebhooks returns the webhooks of the organization.
+func (c *Client) ListWebhooks() ([]Webhook, error) {
+	return c.ListWebhooksContext(context.Background())
+}
+
+// ListWebhooksContext is like ListWebhooks but uses ctx for its request.
+func (c *Client) ListWebhooksContext(ctx context.Context) ([]Webhook, error) {
+	var webhooks []Webhook
+	if err := c.requestContext(ctx, "GET", "/webhooks", nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// CreateWebhook creates a webhook.
+func (c *Client) CreateWebhook(data *WebhookCreate) (*Webhook, error) {
+	return c.CreateWebhookContext(context.Background(), data)
+}
+
+// CreateWebhookContext is like CreateWebhook but uses ctx for its request.
+func (c *Client) CreateWebhookContext(ctx context.Context, data *WebhookCreate) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.requestContext(ctx, "POST", "/webhooks", data, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// UpdateWebhook replaces a webhook. An empty Secret keeps the current one.
+func (c *Client) UpdateWebhook(id string, data *WebhookCreate) (*Webhook, error) {
+	return c.UpdateWebhookContext(context.Background(), id, data)
+}
+
+// UpdateWebhookContext is like UpdateWebhook but uses ctx for its request.
+func (c *Client) UpdateWebhookContext(ctx context.Context, id string, data *WebhookCreate) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.requestContext(ctx, "PUT", "/webhooks/"+id, data, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook deletes a webhook.
+func (c *Client) DeleteWebhook(id string) error {
+	return c.DeleteWebhookContext(context.Background(), id)
+}
+
+// DeleteWebhookContext is like DeleteWebhook but uses ctx for its request.
+func (c *Client) DeleteWebhookContext(ctx context.Context, id string) error {
+	return c.requestContext(ctx, "DELETE", "/webhooks/"+id, nil, nil)
+}
@@ -0,0 +1,258 @@
+package aiptx
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// WebSockets
+// =============================================================================
+
+// The SDK only needs to read JSON messages pushed by the server, so it
+// implements the client side of RFC 6455 itself rather than depending on a
+// WebSocket package: no extensions, subprotocols or outgoing data messages.
+
+// WebSocket opcodes.
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+)
+
+// wsMaxMessage bounds the size of a message, to protect against a
+// misbehaving server.
+const wsMaxMessage = 16 << 20
+
+// wsGUID is appended to the handshake key to compute the accept key.
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// errWebSocketClosed is returned by readMessage after the server closed the
+// connection normally.
+var errWebSocketClosed = errors.New("websocket closed")
+
+// wsConn is a client WebSocket connection.
+type wsConn struct {
+	rw io.ReadWriteCloser
+	r  *bufio.Reader
+
+	mu     sync.Mutex // serializes writes
+	closed bool
+	done   chan struct{}
+}
+
+// dialWebSocket opens a WebSocket connection to path. The handshake is an
+// ordinary request of the client, with its headers and hooks, and the
+// connection is not subject to the client's timeout. Closing ctx closes the
+// connection.
+func (c *Client) dialWebSocket(ctx context.Context, path string) (*wsConn, error) {
+	c.checkVersion(ctx)
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+	header := http.Header{
+		"Connection":            {"Upgrade"},
+		"Upgrade":               {"websocket"},
+		"Sec-Websocket-Version": {"13"},
+		"Sec-Websocket-Key":     {key},
+	}
+	_, resp, err := c.With(WithTimeout(0)).attempt(ctx, "GET", path, "", header, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		resp.Body.Close()
+		return nil, fmt.Errorf("websocket handshake for %s: server responded with %s", path, resp.Status)
+	}
+	sum := sha1.Sum([]byte(key + wsGUID))
+	if resp.Header.Get("Sec-Websocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("websocket handshake for %s: invalid accept key", path)
+	}
+	rw, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, fmt.Errorf("websocket handshake for %s: connection is not writable", path)
+	}
+
+	conn := &wsConn{rw: rw, r: bufio.NewReader(rw), done: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.close()
+		case <-conn.done:
+		}
+	}()
+	return conn, nil
+}
+
+// readMessage returns the next data message, answering pings on the way. It
+// returns errWebSocketClosed once the server closes the connection normally.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case wsPing:
+			if err := c.writeFrame(wsPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			code := 1005 // no status
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			c.close()
+			if code == 1000 || code == 1005 {
+				return nil, errWebSocketClosed
+			}
+			return nil, fmt.Errorf("websocket closed with status %d: %s", code, payload[2:])
+		case wsText, wsBinary:
+			if started {
+				return nil, fmt.Errorf("websocket: new message before the previous one ended")
+			}
+			started = true
+		case wsContinuation:
+			if !started {
+				return nil, fmt.Errorf("websocket: continuation without a message")
+			}
+		default:
+			return nil, fmt.Errorf("websocket: unknown opcode %d", op)
+		}
+		if len(msg)+len(payload) > wsMaxMessage {
+			return nil, fmt.Errorf("websocket: message exceeds %d bytes", wsMaxMessage)
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// readFrame reads a single frame.
+func (c *wsConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op = head[0]&0x80 != 0, head[0]&0x0F
+	masked := head[1]&0x80 != 0
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > wsMaxMessage {
+		return false, 0, nil, fmt.Errorf("websocket: frame exceeds %d bytes", wsMaxMessage)
+	}
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// writeFrame writes a single masked frame, as clients must.
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errWebSocketClosed
+	}
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|op)
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := c.rw.Write(frame)
+	return err
+}
+
+// ping sends a ping every interval until the connection is closed, keeping
+// idle connections open through proxies and drawing pongs that show the
+// connection is alive.
+func (c *wsConn) ping(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.writeFrame(wsPing, nil); err != nil {
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// close closes the connection, sending a normal close frame first if it is
+// still open.
+func (c *wsConn) close() {
+	c.writeFrame(wsClose, []byte{0x03, 0xE8}) // 1000, normal closure
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+		c.rw.Close()
+	}
+}
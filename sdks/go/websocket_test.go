@@ -0,0 +1,200 @@
+package aiptx
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// acceptWebSocket completes a WebSocket handshake on the server side.
+func acceptWebSocket(t *testing.T, w http.ResponseWriter, r *http.Request) (net.Conn, *bufio.ReadWriter) {
+	if r.Header.Get("Upgrade") != "websocket" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		t.Errorf("Expected WebSocket upgrade, got %v", r.Header)
+	}
+	sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + wsGUID))
+	conn, rw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Fatalf("Hijack failed: %v", err)
+	}
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	rw.Flush()
+	return conn, rw
+}
+
+// writeServerFrame writes an unmasked frame, as servers do.
+func writeServerFrame(rw *bufio.ReadWriter, fin bool, op byte, payload string) {
+	b := op
+	if fin {
+		b |= 0x80
+	}
+	rw.WriteByte(b)
+	rw.WriteByte(byte(len(payload)))
+	rw.WriteString(payload)
+	rw.Flush()
+}
+
+// readClientFrame reads a masked frame sent by the client.
+func readClientFrame(t *testing.T, rw *bufio.ReadWriter) (byte, string) {
+	var head [6]byte
+	if _, err := io.ReadFull(rw, head[:]); err != nil {
+		t.Fatalf("Reading client frame: %v", err)
+	}
+	if head[1]&0x80 == 0 {
+		t.Errorf("Expected client frame to be masked")
+	}
+	payload := make([]byte, head[1]&0x7F)
+	io.ReadFull(rw, payload)
+	for i := range payload {
+		payload[i] ^= head[2+i%4]
+	}
+	return head[0] & 0x0F, string(payload)
+}
+
+func TestWatchScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/s-1/ws" || r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("Unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		conn, rw := acceptWebSocket(t, w, r)
+		defer conn.Close()
+
+		writeServerFrame(rw, true, wsPing, "hi")
+		if op, payload := readClientFrame(t, rw); op != wsPong || payload != "hi" {
+			t.Errorf("Expected pong, got %d %q", op, payload)
+		}
+		// A message split into two frames.
+		writeServerFrame(rw, false, wsText, `{"id": "1", "type": "scan.progress", `)
+		writeServerFrame(rw, true, wsContinuation, `"data": {"id": "s-1", "progress": 40}}`)
+		writeServerFrame(rw, true, wsText, `{"id": "2", "type": "scan.phase_changed", "data": {"id": "s-1", "phase": "exploit"}}`)
+		writeServerFrame(rw, true, wsText, `{"id": "3", "type": "finding.created", "data": {"id": 9, "severity": "high"}}`)
+		writeServerFrame(rw, true, wsText, `{"id": "4", "type": "scan.completed", "data": {"id": "s-1", "status": "completed"}}`)
+		if op, _ := readClientFrame(t, rw); op != wsClose {
+			t.Errorf("Expected close frame, got %d", op)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithAPIKey("key"), WithTimeout(time.Millisecond))
+	events, errc := client.WatchScan(context.Background(), "s-1")
+	var got []TypedEvent
+	for ev := range events {
+		got = append(got, ev)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(got))
+	}
+	if ev, ok := got[0].(*ScanProgressEvent); !ok || ev.Scan.Progress != 40 {
+		t.Errorf("Expected progress 40, got %#v", got[0])
+	}
+	if ev, ok := got[1].(*ScanPhaseChangedEvent); !ok || ev.Scan.Phase != PhaseExploit {
+		t.Errorf("Expected exploit phase, got %#v", got[1])
+	}
+	if ev, ok := got[2].(*FindingCreatedEvent); !ok || ev.Finding.ID != 9 {
+		t.Errorf("Expected finding 9, got %#v", got[2])
+	}
+	if _, ok := got[3].(*ScanCompletedEvent); !ok {
+		t.Errorf("Expected completion, got %#v", got[3])
+	}
+}
+
+func TestWatchScanErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scans/missing/ws" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		conn, rw := acceptWebSocket(t, w, r)
+		defer conn.Close()
+		if r.URL.Path == "/scans/abnormal/ws" {
+			writeServerFrame(rw, true, wsClose, "\x03\xf3overloaded")
+			return
+		}
+		// Wait for the client to go away.
+		readClientFrame(t, rw)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	_, errc := client.WatchScan(context.Background(), "missing")
+	if err := <-errc; !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	_, errc = client.WatchScan(context.Background(), "abnormal")
+	if err := <-errc; err == nil || err.Error() != "watching scan abnormal: websocket closed with status 1011: overloaded" {
+		t.Errorf("Expected abnormal closure, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errc := client.WatchScan(ctx, "idle")
+	time.AfterFunc(50*time.Millisecond, cancel)
+	for range events {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWatchScanReconnect(t *testing.T) {
+	defer func(k Keepalive) { DefaultKeepalive = k }(DefaultKeepalive)
+	DefaultKeepalive = Keepalive{
+		PingInterval:   50 * time.Millisecond,
+		StallTimeout:   50 * time.Millisecond,
+		ReconnectDelay: time.Millisecond,
+		MaxReconnects:  2,
+	}
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections++
+		conn, rw := acceptWebSocket(t, w, r)
+		defer conn.Close()
+		switch connections {
+		case 1:
+			writeServerFrame(rw, true, wsText, `{"id": "1", "type": "scan.progress", "data": {"id": "s-1", "progress": 40}}`)
+			// Stall: the client's ping goes unanswered.
+			if op, _ := readClientFrame(t, rw); op != wsPing {
+				t.Errorf("Expected ping, got %d", op)
+			}
+			io.Copy(io.Discard, rw)
+		case 2:
+			// Drop the connection without a close frame.
+			writeServerFrame(rw, true, wsText, `{"id": "2", "type": "scan.phase_changed", "data": {"id": "s-1", "phase": "exploit"}}`)
+		default:
+			writeServerFrame(rw, true, wsText, `{"id": "3", "type": "scan.completed", "data": {"id": "s-1", "status": "completed"}}`)
+			readClientFrame(t, rw)
+		}
+	}))
+	defer server.Close()
+
+	events, errc := NewClient(server.URL).WatchScan(context.Background(), "s-1")
+	var types []string
+	for ev := range events {
+		types = append(types, ev.Header().Type)
+		if ev, ok := ev.(*ReconnectedEvent); ok && ev.Reconnected.Attempts != 1 {
+			t.Errorf("Expected 1 attempt, got %d", ev.Reconnected.Attempts)
+		}
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []string{EventScanProgress, EventReconnected, EventScanPhaseChanged, EventReconnected, EventScanCompleted}
+	if !slices.Equal(types, want) {
+		t.Errorf("Expected %v, got %v", want, types)
+	}
+	if connections != 3 {
+		t.Errorf("Expected 3 connections, got %d", connections)
+	}
+}
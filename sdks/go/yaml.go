@@ -0,0 +1,544 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// YAML
+// =============================================================================
+
+// The SDK has no dependencies, so manifests are read with a small YAML
+// decoder. It supports the block style used in configuration files: nested
+// mappings and sequences, plain, quoted and block scalars, single-line flow
+// sequences and mappings, and comments. Anchors, tags and multiple documents
+// are not supported.
+
+// yamlLine is a line of a YAML document without its indentation.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML decodes a YAML document into maps, slices, strings, float64s,
+// bools and nils, like encoding/json decodes into an interface{}, except
+// that bools and numbers are wrapped in a yamlPlain. They encode to JSON
+// unchanged.
+func parseYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(raw) - len(text), text: strings.TrimRight(text, " \t")})
+	}
+	p.skip()
+	if p.pos < len(p.lines) && p.lines[p.pos].text == "---" {
+		p.pos++
+		p.skip()
+	}
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	v, err := p.block(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.skip(); p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected content %q", p.lines[p.pos].text)
+	}
+	return v, nil
+}
+
+// skip moves past blank and comment lines.
+func (p *yamlParser) skip() {
+	for p.pos < len(p.lines) {
+		text := p.lines[p.pos].text
+		if text != "" && !strings.HasPrefix(text, "#") {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos].num
+	} else if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1].num
+	}
+	return fmt.Errorf("yaml: line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// isItem reports whether text starts a sequence item.
+func isItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// block parses the mapping or sequence starting at the current line.
+func (p *yamlParser) block(indent int) (interface{}, error) {
+	if isItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *yamlParser) sequence(indent int) ([]interface{}, error) {
+	items := []interface{}{}
+	for p.skip(); p.pos < len(p.lines); p.skip() {
+		line := p.lines[p.pos]
+		if line.indent < indent || line.indent == indent && !isItem(line.text) {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("bad indentation")
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		if rest == "" || strings.HasPrefix(rest, "#") {
+			p.pos++
+			v, err := p.nested(indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			continue
+		}
+		// An item starting a nested block continues on the same line, so
+		// the line is reparsed as if the dash were indentation.
+		if _, _, ok := splitKey(rest); ok || isItem(rest) {
+			p.lines[p.pos] = yamlLine{num: line.num, indent: line.indent + len(line.text) - len(rest), text: rest}
+			v, err := p.block(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			continue
+		}
+		v, err := p.scalar(rest, indent)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+func (p *yamlParser) mapping(indent int) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	for p.skip(); p.pos < len(p.lines); p.skip() {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent || isItem(line.text) {
+			return nil, p.errorf("bad indentation")
+		}
+		key, rest, ok := splitKey(line.text)
+		if !ok {
+			return nil, p.errorf("expected key: value, got %q", line.text)
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		if rest == "" || strings.HasPrefix(rest, "#") {
+			p.pos++
+			// Sequences may be indented at the level of their key.
+			if p.skip(); p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isItem(p.lines[p.pos].text) {
+				v, err := p.sequence(indent)
+				if err != nil {
+					return nil, err
+				}
+				m[key] = v
+				continue
+			}
+			v, err := p.nested(indent)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+			continue
+		}
+		v, err := p.scalar(rest, indent)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+// nested parses the block after a key or dash with nothing after it, which
+// is null unless the next line is indented deeper than indent.
+func (p *yamlParser) nested(indent int) (interface{}, error) {
+	if p.skip(); p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return p.block(p.lines[p.pos].indent)
+	}
+	return nil, nil
+}
+
+// splitKey splits "key: value" into its key and value. ok is false if text
+// is not a mapping entry.
+func splitKey(text string) (key, rest string, ok bool) {
+	end := -1
+	if text[0] == '"' || text[0] == '\'' {
+		quoted, n, err := unquote(text)
+		if err != nil || (n < len(text) && text[n] != ':') || n == len(text) {
+			return "", "", false
+		}
+		key, end = quoted, n
+	} else {
+		for i := 0; i < len(text); i++ {
+			if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+				end = i
+				break
+			}
+			if text[i] == '#' && i > 0 && text[i-1] == ' ' {
+				break
+			}
+		}
+		if end <= 0 || strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+			return "", "", false
+		}
+		key = strings.TrimSpace(text[:end])
+	}
+	return key, strings.TrimSpace(text[end+1:]), true
+}
+
+// scalar parses the value after a key or dash on the current line, which
+// may start a block scalar continuing on the lines indented deeper than
+// indent.
+func (p *yamlParser) scalar(text string, indent int) (interface{}, error) {
+	if text == "|" || text == ">" || text == "|-" || text == ">-" {
+		p.pos++
+		return p.blockScalar(text, indent), nil
+	}
+	v, rest, err := parseFlow(text)
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, p.errorf("unexpected %q after value", rest)
+	}
+	p.pos++
+	return v, nil
+}
+
+// blockScalar reads a literal (|) or folded (>) block scalar.
+func (p *yamlParser) blockScalar(style string, indent int) string {
+	var lines []string
+	blockIndent := -1
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if line.text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if line.indent <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = line.indent
+		}
+		lines = append(lines, strings.Repeat(" ", max(line.indent-blockIndent, 0))+line.text)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	s := strings.Join(lines, "\n")
+	if style[0] == '>' {
+		s = fold(lines)
+	}
+	if !strings.HasSuffix(style, "-") && s != "" {
+		s += "\n"
+	}
+	return s
+}
+
+// fold joins the lines of a folded block scalar: line breaks between lines
+// become spaces, blank lines become line breaks, and lines indented deeper
+// than the block keep their line breaks.
+func fold(lines []string) string {
+	var b strings.Builder
+	last := -1 // the previous non-blank line
+	for i, line := range lines {
+		if line == "" {
+			b.WriteByte('\n')
+			continue
+		}
+		if last >= 0 {
+			moreIndented := line[0] == ' ' || lines[last][0] == ' '
+			switch {
+			case moreIndented:
+				b.WriteByte('\n')
+			case last == i-1:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(line)
+		last = i
+	}
+	return b.String()
+}
+
+// parseFlow parses a scalar, flow sequence or flow mapping at the start of
+// text and returns the remaining text.
+func parseFlow(text string) (interface{}, string, error) {
+	switch {
+	case text == "":
+		return nil, "", nil
+	case text[0] == '"' || text[0] == '\'':
+		s, n, err := unquote(text)
+		return s, text[n:], err
+	case text[0] == '[':
+		items := []interface{}{}
+		rest := strings.TrimSpace(text[1:])
+		for !strings.HasPrefix(rest, "]") {
+			v, r, err := parseFlowItem(rest, ",]")
+			if err != nil {
+				return nil, "", err
+			}
+			items = append(items, v)
+			if rest, err = nextFlowItem(r, "]"); err != nil {
+				return nil, "", err
+			}
+		}
+		return items, rest[1:], nil
+	case text[0] == '{':
+		m := map[string]interface{}{}
+		rest := strings.TrimSpace(text[1:])
+		for !strings.HasPrefix(rest, "}") {
+			k, r, err := parseFlowItem(rest, ":,}")
+			if err != nil {
+				return nil, "", err
+			}
+			key, ok := k.(string)
+			if r = strings.TrimSpace(r); !ok || !strings.HasPrefix(r, ":") {
+				return nil, "", fmt.Errorf("expected key: value in flow mapping")
+			}
+			v, r, err := parseFlowItem(strings.TrimSpace(r[1:]), ",}")
+			if err != nil {
+				return nil, "", err
+			}
+			m[key] = v
+			if rest, err = nextFlowItem(r, "}"); err != nil {
+				return nil, "", err
+			}
+		}
+		return m, rest[1:], nil
+	}
+	end := len(text)
+	if i := strings.Index(text, " #"); i >= 0 {
+		end = i
+	}
+	return plainScalar(strings.TrimSpace(text[:end])), text[end:], nil
+}
+
+// nextFlowItem skips the comma after an item of a flow collection, returning
+// the text of the next item or the closing bracket.
+func nextFlowItem(text, closing string) (string, error) {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, ","):
+		return strings.TrimSpace(text[1:]), nil
+	case strings.HasPrefix(text, closing):
+		return text, nil
+	}
+	return "", fmt.Errorf("expected , or %s in flow collection", closing)
+}
+
+// parseFlowItem parses an item of a flow collection, ending before one of
+// the terminators.
+func parseFlowItem(text, terminators string) (interface{}, string, error) {
+	if text == "" {
+		return nil, "", fmt.Errorf("unterminated flow collection")
+	}
+	if strings.ContainsAny(text[:1], "\"'[{") {
+		return parseFlow(text)
+	}
+	end := strings.IndexAny(text, terminators)
+	if end < 0 {
+		return nil, "", fmt.Errorf("unterminated flow collection")
+	}
+	return plainScalar(strings.TrimSpace(text[:end])), text[end:], nil
+}
+
+// unquote parses the quoted string at the start of text and returns its
+// value and length.
+func unquote(text string) (string, int, error) {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			if quote == '\'' {
+				return strings.ReplaceAll(text[1:i], "''", "'"), i + 1, nil
+			}
+			s, err := unescape(text[1:i])
+			return s, i + 1, err
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string %s", text)
+}
+
+// yamlEscapes maps the single-character escapes of double-quoted scalars to
+// their values.
+var yamlEscapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v",
+	'f': "\f", 'r': "\r", 'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\",
+	'N': "\u0085", '_': "\u00a0", 'L': "\u2028", 'P': "\u2029",
+}
+
+// unescape decodes the escape sequences of a double-quoted scalar.
+func unescape(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i++; i == len(s) {
+			return "", fmt.Errorf("invalid escape at end of string")
+		}
+		if esc, ok := yamlEscapes[s[i]]; ok {
+			b.WriteString(esc)
+			continue
+		}
+		digits := 0
+		switch s[i] {
+		case 'x':
+			digits = 2
+		case 'u':
+			digits = 4
+		case 'U':
+			digits = 8
+		}
+		if digits == 0 || i+digits >= len(s) {
+			return "", fmt.Errorf("invalid escape \\%c", s[i])
+		}
+		r, err := strconv.ParseUint(s[i+1:i+1+digits], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape \\%s", s[i:i+1+digits])
+		}
+		b.WriteRune(rune(r))
+		i += digits
+	}
+	return b.String(), nil
+}
+
+// yamlPlain is an unquoted scalar that reads as a bool or number. It keeps
+// its text, so that yamlToJSON can decode it into a string as written, as in
+// "name: 007".
+type yamlPlain struct {
+	text  string
+	value interface{}
+}
+
+func (s yamlPlain) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.value)
+}
+
+// plainScalar converts an unquoted scalar to null, a bool, a number or a
+// string.
+func plainScalar(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return yamlPlain{s, true}
+	case "false", "False", "FALSE":
+		return yamlPlain{s, false}
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !strings.ContainsAny(s, "xXpP_") && !strings.EqualFold(s, "nan") && !strings.Contains(strings.ToLower(s), "inf") {
+		return yamlPlain{s, f}
+	}
+	return s
+}
+
+// yamlToJSON converts a YAML document to JSON for decoding into a value of
+// type t. Unquoted scalars are kept as written where t has a string.
+func yamlToJSON(data []byte, t reflect.Type) ([]byte, error) {
+	doc, err := parseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(conform(doc, t))
+}
+
+// conform replaces the unquoted scalars of v that t decodes as strings with
+// their text.
+func conform(v interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch v := v.(type) {
+	case yamlPlain:
+		if t.Kind() == reflect.String {
+			return v.text
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i := range v {
+				v[i] = conform(v[i], t.Elem())
+			}
+		}
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for k := range v {
+				v[k] = conform(v[k], t.Elem())
+			}
+		case reflect.Struct:
+			for k := range v {
+				if f, ok := jsonField(t, k); ok {
+					v[k] = conform(v[k], f.Type)
+				}
+			}
+		}
+	}
+	return v
+}
+
+// jsonField returns the field of struct type t that encoding/json decodes
+// the key name into.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	var match reflect.StructField
+	found := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = f.Name
+		}
+		if key == name {
+			return f, true
+		}
+		if !found && strings.EqualFold(key, name) {
+			match, found = f, true
+		}
+	}
+	return match, found
+}
@@ -0,0 +1,110 @@
+package aiptx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	doc := `---
+# Engagements
+projects:
+- name: payments   # inline comment
+  target: "pay.example.com"
+  scope: [pay.example.com, 'api.pay.example.com']
+  port: 8443
+  notes: |
+    Line one
+      indented
+  summary: >-
+    folded
+    text
+  schedules:
+    - name: nightly
+      cron: "0 2 * * *"
+      enabled: false
+    -
+      name: weekly
+- name: 'it''s'
+  url: https://example.com/a#b
+  empty:
+  flow: {a: 1, b: [x, "y, z"]}
+webhooks: []
+`
+	v, err := parseYAML([]byte(doc))
+	if err != nil {
+		t.Fatalf("parseYAML failed: %v", err)
+	}
+	got, _ := json.Marshal(v)
+	want := `{"projects":[{"name":"payments","notes":"Line one\n  indented\n","port":8443,` +
+		`"schedules":[{"cron":"0 2 * * *","enabled":false,"name":"nightly"},{"name":"weekly"}],` +
+		`"scope":["pay.example.com","api.pay.example.com"],"summary":"folded text","target":"pay.example.com"},` +
+		`{"empty":null,"flow":{"a":1,"b":["x","y, z"]},"name":"it's","url":"https://example.com/a#b"}],"webhooks":[]}`
+	if string(got) != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, doc := range []string{
+		"a: 1\na: 2\n",
+		"a: 1\n   b: 2\n",
+		"a: [1, 2\n",
+		"a: \"open\n",
+		"- a\nb: 1\n",
+		"a:\n\t- b\n",
+		"a: \"\\q\"\n",
+		"a: \"\\x4\"\n",
+	} {
+		if _, err := parseYAML([]byte(doc)); err == nil {
+			t.Errorf("Expected error for %q", doc)
+		}
+	}
+}
+
+func TestParseYAMLScalars(t *testing.T) {
+	doc := `folded: >
+  First paragraph
+  continues.
+
+  Second paragraph.
+
+
+  Third, after two blank lines.
+    Indented lines keep
+    their breaks.
+  Last.
+escapes: "x\/y \t\x41\u00e9\U0001F600 \"q\" \\"
+`
+	v, err := parseYAML([]byte(doc))
+	if err != nil {
+		t.Fatalf("parseYAML failed: %v", err)
+	}
+	m := v.(map[string]interface{})
+	folded := "First paragraph continues.\nSecond paragraph.\n\nThird, after two blank lines.\n" +
+		"  Indented lines keep\n  their breaks.\nLast.\n"
+	if m["folded"] != folded {
+		t.Errorf("Expected folded %q, got %q", folded, m["folded"])
+	}
+	if escapes := "x/y \tA\u00e9\U0001F600 \"q\" \\"; m["escapes"] != escapes {
+		t.Errorf("Expected escapes %q, got %q", escapes, m["escapes"])
+	}
+}
+
+func TestYAMLToJSONStrings(t *testing.T) {
+	type target struct {
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Port    int      `json:"port"`
+		Enabled bool     `json:"enabled"`
+	}
+	data, err := yamlToJSON([]byte("name: 007\ntags: [true, 1.10]\nport: 8443\nenabled: true\n"), reflect.TypeOf(target{}))
+	if err != nil {
+		t.Fatalf("yamlToJSON failed: %v", err)
+	}
+	want := `{"enabled":true,"name":"007","port":8443,"tags":["true","1.10"]}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
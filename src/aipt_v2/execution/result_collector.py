@@ -21,6 +21,7 @@ from datetime import datetime
 from enum import Enum
 from typing import Any, Dict, List, Optional, Set, Tuple
 
+from ..utils.report_labels import get_labels, severity_label
 from .tool_registry import ToolPhase
 from .local_tool_executor import ToolExecution, ExecutionBatch
 from .parser import Finding
@@ -585,43 +586,53 @@ class ResultCollector:
         """Export to JSON string."""
         return json.dumps(self.to_dict(), indent=indent, default=str)
 
-    def to_markdown(self) -> str:
-        """Export to markdown report format."""
+    def to_markdown(self, language: Optional[str] = None) -> str:
+        """
+        Export to markdown report format.
+
+        Args:
+            language: Language tag of the severity and section labels, such
+                as "de" or "ja". Defaults to English.
+        """
+        labels = get_labels(language)
         lines = [
-            f"# Scan Results: {self.target}",
+            f"# {labels['scan_results']}: {self.target}",
             "",
-            f"**Scan Date:** {self.start_time.strftime('%Y-%m-%d %H:%M:%S')}",
-            f"**Total Findings:** {len(self._all_findings)}",
+            f"**{labels['scan_date']}:** {self.start_time.strftime('%Y-%m-%d %H:%M:%S')}",
+            f"**{labels['total_findings']}:** {len(self._all_findings)}",
             "",
-            "## Summary by Severity",
+            f"## {labels['summary_by_severity']}",
             "",
         ]
 
         for severity in FindingSeverity:
             count = len(self._by_severity.get(severity, set()))
             emoji = {"critical": "🔴", "high": "🟠", "medium": "🟡", "low": "🔵", "info": "⚪"}
-            lines.append(f"- {emoji.get(severity.value, '')} **{severity.value.title()}:** {count}")
+            lines.append(f"- {emoji.get(severity.value, '')} **{labels[severity.value]}:** {count}")
 
-        lines.extend(["", "## Critical & High Findings", ""])
+        lines.extend(["", f"## {labels['critical_high_findings']}", ""])
 
         for finding in self.get_critical_findings()[:20]:
-            lines.append(f"### [{finding.severity.value.upper()}] {finding.value[:60]}")
-            lines.append(f"- **Type:** {finding.type}")
-            lines.append(f"- **Tool:** {finding.source_tool}")
-            lines.append(f"- **Description:** {finding.description[:200]}")
+            lines.append(f"### [{severity_label(finding.severity.value, language).upper()}] {finding.value[:60]}")
+            lines.append(f"- **{labels['type']}:** {finding.type}")
+            lines.append(f"- **{labels['tool']}:** {finding.source_tool}")
+            lines.append(f"- **{labels['description']}:** {finding.description[:200]}")
             if finding.chain_potential:
-                lines.append(f"- **Attack Potential:** {', '.join(finding.chain_potential)}")
+                lines.append(f"- **{labels['attack_potential']}:** {', '.join(finding.chain_potential)}")
             lines.append("")
 
         # Attack paths
         paths = self.detect_attack_paths()
         if paths:
-            lines.extend(["## Potential Attack Paths", ""])
+            lines.extend([f"## {labels['attack_paths']}", ""])
             for path in paths:
                 lines.append(f"### {path.name}")
-                lines.append(f"**Impact:** {path.impact.title()} | **Confidence:** {path.confidence:.0%}")
+                lines.append(
+                    f"**{labels['impact']}:** {severity_label(path.impact, language)} | "
+                    f"**{labels['confidence']}:** {path.confidence:.0%}"
+                )
                 lines.append("")
-                lines.append("**Steps:**")
+                lines.append(f"**{labels['steps']}:**")
                 for i, step in enumerate(path.steps, 1):
                     lines.append(f"{i}. {step}")
                 lines.append("")
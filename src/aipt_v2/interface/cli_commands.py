@@ -152,6 +152,13 @@ Examples:
         help="Output format (default: all)",
     )
 
+    scan_parser.add_argument(
+        "--language",
+        type=str,
+        default="en",
+        help="Language of report labels, e.g. de or ja (default: en)",
+    )
+
     # Concurrency
     scan_parser.add_argument(
         "--concurrent",
@@ -315,7 +322,7 @@ async def run_scan_command(args: argparse.Namespace) -> int:
         if not args.quiet:
             console.print("\n[cyan]Generating reports...[/]")
 
-        _generate_reports(collector, output_dir, args.format)
+        _generate_reports(collector, output_dir, args.format, args.language)
 
         # Display summary
         _display_scan_summary(collector, output_dir, args)
@@ -445,13 +452,16 @@ def _generate_reports(
     collector,
     output_dir: Path,
     format: str,
+    language: str = "en",
 ) -> None:
-    """Generate output reports."""
+    """Generate output reports, with labels in the given language."""
     if format in ["json", "all"]:
         collector.export_json(str(output_dir / "findings.json"))
 
     if format in ["markdown", "all"]:
-        collector.export_markdown(str(output_dir / "findings.md"))
+        (output_dir / "findings.md").write_text(
+            collector.to_markdown(language=language), encoding="utf-8"
+        )
 
     # Save compact format for LLM
     compact = collector.export_compact()
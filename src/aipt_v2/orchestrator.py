@@ -120,6 +120,9 @@ from aipt_v2.payloads.ssrf import SSRFPayloads
 from aipt_v2.payloads.traversal import PathTraversalPayloads
 from aipt_v2.payloads.templates import TemplateInjectionPayloads
 
+# Report Labels - localized severity and section labels
+from aipt_v2.utils.report_labels import get_labels, resolve_language, severity_label
+
 # XXE Payloads - imported conditionally for compatibility
 try:
     from aipt_v2.payloads.xxe import XXEPayloads
@@ -368,6 +371,7 @@ class OrchestratorConfig:
     # Report settings
     report_format: str = "html"
     report_template: str = "professional"
+    report_language: str = "en"  # Language of report labels, e.g. "de" or "ja"
 
     # Shell access tracking (set during exploitation)
     shell_obtained: bool = False
@@ -6553,7 +6557,9 @@ class Orchestrator:
         return "Unknown Finding"
 
     def _generate_html_report(self) -> str:
-        """Generate HTML report."""
+        """Generate HTML report, labelled in the configured report language."""
+        language = resolve_language(self.config.report_language)
+        labels = get_labels(language)
         severity_counts = {"critical": 0, "high": 0, "medium": 0, "low": 0, "info": 0}
         for f in self.findings:
             # Handle severity as string, enum, or int
@@ -6571,7 +6577,7 @@ class Orchestrator:
             if hasattr(sev, "value"):
                 sev = sev.value
             sev_class = str(sev).lower()
-            sev_upper = str(sev).upper()
+            sev_upper = severity_label(sev_class, language).upper()
 
             # Generate proper title from finding type and value
             # Bug fix: f.value may be a numeric count (e.g., "0" for subdomain_count)
@@ -6587,17 +6593,17 @@ class Orchestrator:
                 </div>
                 <div class="finding-body">
                     <p>{f.description}</p>
-                    <small>Target: {f.target or self.target} | Phase: {f.phase}</small>
+                    <small>{labels['target']}: {f.target or self.target} | {labels['phase']}: {f.phase}</small>
                 </div>
             </div>
             """
 
         return f"""<!DOCTYPE html>
-<html lang="en">
+<html lang="{language}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>VAPT Report - {self.domain}</title>
+    <title>{labels['report_title']} - {self.domain}</title>
     <style>
         :root {{
             --critical: #dc3545;
@@ -6641,28 +6647,28 @@ class Orchestrator:
 <body>
     <div class="container">
         <div class="header">
-            <h1>🔒 VAPT Report</h1>
-            <p><strong>Target:</strong> {self.domain}</p>
-            <p><strong>Date:</strong> {datetime.now().strftime('%Y-%m-%d %H:%M:%S')}</p>
-            <p><strong>Report ID:</strong> VAPT-{self.domain.upper().replace('.', '-')}-{datetime.now().strftime('%Y%m%d')}</p>
+            <h1>🔒 {labels['report_title']}</h1>
+            <p><strong>{labels['target']}:</strong> {self.domain}</p>
+            <p><strong>{labels['date']}:</strong> {datetime.now().strftime('%Y-%m-%d %H:%M:%S')}</p>
+            <p><strong>{labels['report_id']}:</strong> VAPT-{self.domain.upper().replace('.', '-')}-{datetime.now().strftime('%Y%m%d')}</p>
         </div>
 
         <div class="stats">
-            <div class="stat critical"><div class="number">{severity_counts['critical']}</div><div>Critical</div></div>
-            <div class="stat high"><div class="number">{severity_counts['high']}</div><div>High</div></div>
-            <div class="stat medium"><div class="number">{severity_counts['medium']}</div><div>Medium</div></div>
-            <div class="stat low"><div class="number">{severity_counts['low']}</div><div>Low</div></div>
-            <div class="stat info"><div class="number">{severity_counts['info']}</div><div>Info</div></div>
+            <div class="stat critical"><div class="number">{severity_counts['critical']}</div><div>{labels['critical']}</div></div>
+            <div class="stat high"><div class="number">{severity_counts['high']}</div><div>{labels['high']}</div></div>
+            <div class="stat medium"><div class="number">{severity_counts['medium']}</div><div>{labels['medium']}</div></div>
+            <div class="stat low"><div class="number">{severity_counts['low']}</div><div>{labels['low']}</div></div>
+            <div class="stat info"><div class="number">{severity_counts['info']}</div><div>{labels['info']}</div></div>
         </div>
 
         <div class="findings">
-            <h2>Findings ({len(self.findings)})</h2>
-            {findings_html if findings_html else '<p>No vulnerabilities found.</p>'}
+            <h2>{labels['findings']} ({len(self.findings)})</h2>
+            {findings_html if findings_html else f"<p>{labels['no_findings']}</p>"}
         </div>
 
         <div style="text-align: center; margin-top: 30px; color: #666;">
-            <p>Generated by AIPT - AI-Powered Penetration Testing</p>
-            <p>Scanners: {', '.join(self.scan_ids.keys()) if self.scan_ids else 'Open Source Tools'}</p>
+            <p>{labels['generated_by']}</p>
+            <p>{labels['scanners']}: {', '.join(self.scan_ids.keys()) if self.scan_ids else 'Open Source Tools'}</p>
         </div>
     </div>
 </body>
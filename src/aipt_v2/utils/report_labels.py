@@ -0,0 +1,127 @@
+"""
+AIPTX Report Labels
+
+Localized severity and section labels for the offline report writers.
+Reports are written in English unless a language tag such as "de" or
+"ja" is given; findings themselves are not translated.
+"""
+from __future__ import annotations
+
+from typing import Dict
+
+
+DEFAULT_LANGUAGE = "en"
+
+REPORT_LABELS: Dict[str, Dict[str, str]] = {
+    "en": {
+        "critical": "Critical",
+        "high": "High",
+        "medium": "Medium",
+        "low": "Low",
+        "info": "Info",
+        "scan_results": "Scan Results",
+        "scan_date": "Scan Date",
+        "total_findings": "Total Findings",
+        "summary_by_severity": "Summary by Severity",
+        "critical_high_findings": "Critical & High Findings",
+        "type": "Type",
+        "tool": "Tool",
+        "description": "Description",
+        "attack_potential": "Attack Potential",
+        "attack_paths": "Potential Attack Paths",
+        "impact": "Impact",
+        "confidence": "Confidence",
+        "steps": "Steps",
+        "report_title": "VAPT Report",
+        "target": "Target",
+        "date": "Date",
+        "report_id": "Report ID",
+        "phase": "Phase",
+        "findings": "Findings",
+        "no_findings": "No vulnerabilities found.",
+        "generated_by": "Generated by AIPT - AI-Powered Penetration Testing",
+        "scanners": "Scanners",
+    },
+    "de": {
+        "critical": "Kritisch",
+        "high": "Hoch",
+        "medium": "Mittel",
+        "low": "Niedrig",
+        "info": "Info",
+        "scan_results": "Scan-Ergebnisse",
+        "scan_date": "Scan-Datum",
+        "total_findings": "Befunde insgesamt",
+        "summary_by_severity": "Übersicht nach Schweregrad",
+        "critical_high_findings": "Kritische und hohe Befunde",
+        "type": "Typ",
+        "tool": "Werkzeug",
+        "description": "Beschreibung",
+        "attack_potential": "Angriffspotenzial",
+        "attack_paths": "Mögliche Angriffspfade",
+        "impact": "Auswirkung",
+        "confidence": "Konfidenz",
+        "steps": "Schritte",
+        "report_title": "VAPT-Bericht",
+        "target": "Ziel",
+        "date": "Datum",
+        "report_id": "Berichts-ID",
+        "phase": "Phase",
+        "findings": "Befunde",
+        "no_findings": "Keine Schwachstellen gefunden.",
+        "generated_by": "Erstellt mit AIPT - KI-gestützte Penetrationstests",
+        "scanners": "Scanner",
+    },
+    "ja": {
+        "critical": "緊急",
+        "high": "高",
+        "medium": "中",
+        "low": "低",
+        "info": "情報",
+        "scan_results": "スキャン結果",
+        "scan_date": "スキャン日時",
+        "total_findings": "検出件数",
+        "summary_by_severity": "深刻度別の概要",
+        "critical_high_findings": "緊急および高の検出事項",
+        "type": "種別",
+        "tool": "ツール",
+        "description": "説明",
+        "attack_potential": "攻撃の可能性",
+        "attack_paths": "想定される攻撃経路",
+        "impact": "影響",
+        "confidence": "確度",
+        "steps": "手順",
+        "report_title": "脆弱性診断レポート",
+        "target": "対象",
+        "date": "日付",
+        "report_id": "レポートID",
+        "phase": "フェーズ",
+        "findings": "検出事項",
+        "no_findings": "脆弱性は検出されませんでした。",
+        "generated_by": "AIPT（AIによるペネトレーションテスト）で生成",
+        "scanners": "スキャナー",
+    },
+}
+
+
+def resolve_language(language: str | None) -> str:
+    """
+    Return the supported language for a language tag.
+
+    Regional tags fall back to their primary language ("de-AT" is "de"),
+    and unsupported or empty tags to English.
+    """
+    if not language:
+        return DEFAULT_LANGUAGE
+    primary = language.replace("_", "-").split("-")[0].lower()
+    return primary if primary in REPORT_LABELS else DEFAULT_LANGUAGE
+
+
+def get_labels(language: str | None) -> Dict[str, str]:
+    """Return the report labels for a language tag."""
+    return REPORT_LABELS[resolve_language(language)]
+
+
+def severity_label(severity: str, language: str | None) -> str:
+    """Return the label of a severity such as "high" in a language."""
+    labels = get_labels(language)
+    return labels.get(str(severity).lower(), str(severity).title())
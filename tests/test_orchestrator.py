@@ -681,6 +681,30 @@ class TestReportPhase:
         assert "example.com" in html
         assert "XSS" in html  # Finding should be included
 
+    def test_generate_html_report_localized(self, orchestrator):
+        """Test HTML report labels follow the report language."""
+        orchestrator.config.report_language = "de"
+        html = orchestrator._generate_html_report()
+
+        assert '<html lang="de">' in html
+        assert "VAPT-Bericht" in html
+        assert "HOCH" in html  # Severity badge of the XSS finding
+        assert "Kritisch" in html
+
+        orchestrator.config.report_language = "ja"
+        html = orchestrator._generate_html_report()
+        assert "脆弱性診断レポート" in html
+        assert "緊急" in html
+
+    def test_report_language_falls_back_to_english(self):
+        """Test unsupported and regional language tags."""
+        from aipt_v2.utils.report_labels import resolve_language, severity_label
+
+        assert resolve_language("de-AT") == "de"
+        assert resolve_language("fr") == "en"
+        assert resolve_language(None) == "en"
+        assert severity_label("medium", "ja") == "中"
+
 
 # ============== Full Pipeline Tests ==============
 